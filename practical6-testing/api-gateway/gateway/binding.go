@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBodyBytes caps request bodies; no request message comes close.
+const maxBodyBytes = 1 << 20
+
+// updateMaskField is the field naming which fields an update request changes.
+const updateMaskField = "update_mask"
+
+// bind fills req from the JSON body, then the query string, then the path
+// wildcards, so values in the URL win over the body.
+func bind(r *http.Request, req proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	if len(body) > maxBodyBytes {
+		return status.Errorf(codes.InvalidArgument, "request body is larger than %d bytes", maxBodyBytes)
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+		}
+	}
+
+	msg := req.ProtoReflect()
+	for name, values := range r.URL.Query() {
+		if err := setField(msg, name, values); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query parameter %q: %v", name, err)
+		}
+	}
+	for _, name := range wildcards(r.Pattern) {
+		if err := setField(msg, name, []string{r.PathValue(name)}); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", name, err)
+		}
+	}
+
+	if r.Method == http.MethodPatch {
+		return deriveUpdateMask(msg, body)
+	}
+	return nil
+}
+
+// deriveUpdateMask sets the update mask of a PATCH request to the top-level
+// fields present in its body, unless the caller sent one. PATCH without any
+// fields is refused rather than passed on as a full replacement.
+func deriveUpdateMask(msg protoreflect.Message, body []byte) error {
+	fd := msg.Descriptor().Fields().ByName(updateMaskField)
+	if fd == nil || msg.Has(fd) {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+		}
+	}
+
+	mask := &fieldmaskpb.FieldMask{}
+	for key := range fields {
+		if field := lookupField(msg.Descriptor(), key); field != nil {
+			mask.Paths = append(mask.Paths, string(field.Name()))
+		}
+	}
+	if len(mask.Paths) == 0 {
+		return status.Error(codes.InvalidArgument, "PATCH body names no fields to update")
+	}
+	msg.Set(fd, protoreflect.ValueOfMessage(mask.ProtoReflect()))
+	return nil
+}
+
+// wildcards returns the names of the {wildcards} in a ServeMux pattern.
+func wildcards(pattern string) []string {
+	var names []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.TrimSuffix(strings.Trim(segment, "{}"), "..."))
+		}
+	}
+	return names
+}
+
+// lookupField finds a field by its proto name or its JSON name.
+func lookupField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// setField parses values into the named field. Repeated fields take every
+// value; other fields take the last.
+func setField(msg protoreflect.Message, name string, values []string) error {
+	fd := lookupField(msg.Descriptor(), name)
+	if fd == nil {
+		return fmt.Errorf("no such field")
+	}
+	if fd.IsMap() {
+		return fmt.Errorf("map fields are not supported")
+	}
+
+	if fd.IsList() {
+		list := msg.Mutable(fd).List()
+		for _, raw := range values {
+			value, err := parseValue(fd, raw)
+			if err != nil {
+				return err
+			}
+			list.Append(value)
+		}
+		return nil
+	}
+
+	value, err := parseValue(fd, values[len(values)-1])
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, value)
+	return nil
+}
+
+// parseValue parses raw as a single value of fd's type. Timestamps are
+// RFC 3339; enums take their value name or number.
+func parseValue(fd protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(raw)), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(raw)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(raw, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(raw, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(raw, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(raw, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByName(protoreflect.Name(raw)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		v, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", fd.Enum().Name(), raw)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			t, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("%s fields cannot be set from the URL", fd.Kind())
+}
@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// statusClientClosed is the non-standard status for requests whose caller
+// went away first.
+const statusClientClosed = 499
+
+// httpStatuses maps gRPC codes onto HTTP statuses. Codes not listed are
+// answered with 500.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           statusClientClosed,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// HTTPStatus returns the HTTP status the gateway answers with for code.
+func HTTPStatus(code codes.Code) int {
+	if status, ok := httpStatuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// errorBody is the JSON body of every error response, e.g.
+//
+//	{"error": {"code": 404, "status": "NOT_FOUND", "message": "order not found"}}
+//
+// Details holds the gRPC status details, such as field violations, in their
+// proto JSON form.
+type errorBody struct {
+	Error errorStatus `json:"error"`
+}
+
+type errorStatus struct {
+	Code    int               `json:"code"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body := errorBody{Error: errorStatus{
+		Code:    HTTPStatus(st.Code()),
+		Status:  rpccode.Code(st.Code()).String(),
+		Message: st.Message(),
+	}}
+	for _, detail := range st.Proto().GetDetails() {
+		raw, err := protojson.Marshal(detail)
+		if err != nil {
+			log.Printf("Dropping error detail %s: %v", detail.GetTypeUrl(), err)
+			continue
+		}
+		body.Error.Details = append(body.Error.Details, raw)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		log.Printf("Failed to encode error response: %v", err)
+		http.Error(w, st.Message(), body.Error.Code)
+		return
+	}
+	write(w, body.Error.Code, data)
+}
+
+func writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	write(w, code, data)
+}
+
+func write(w http.ResponseWriter, code int, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package gateway serves the cafe's gRPC services as HTTP/JSON under /api.
+// Request and response bodies use the proto3 JSON mapping of the userv1,
+// menuv1 and orderv1 messages. Path wildcards and query parameters fill in
+// the request fields of the same name, so GET /api/orders/7 calls GetOrder
+// with id 7.
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultTimeout bounds calls to the services when Gateway.MaxTimeout is
+// not changed.
+const DefaultTimeout = 10 * time.Second
+
+// Gateway is an http.Handler that translates REST calls into gRPC calls.
+type Gateway struct {
+	users  userv1.UserServiceClient
+	menu   menuv1.MenuServiceClient
+	orders orderv1.OrderServiceClient
+	mux    *http.ServeMux
+
+	// MaxTimeout is how long a call to the services may take. Callers may
+	// ask for less with the Grpc-Timeout header. Zero means no limit beyond
+	// the caller's own.
+	MaxTimeout time.Duration
+}
+
+func New(users userv1.UserServiceClient, menu menuv1.MenuServiceClient, orders orderv1.OrderServiceClient) *Gateway {
+	g := &Gateway{
+		users:      users,
+		menu:       menu,
+		orders:     orders,
+		mux:        http.NewServeMux(),
+		MaxTimeout: DefaultTimeout,
+	}
+	g.routes()
+	return g
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) routes() {
+	// Users and authentication
+	g.mux.Handle("POST /api/users", unary(g, http.StatusCreated, g.users.CreateUser))
+	g.mux.Handle("GET /api/users", unary(g, http.StatusOK, g.users.GetUsers))
+	g.mux.Handle("GET /api/users/{id}", unary(g, http.StatusOK, g.users.GetUser))
+	g.mux.Handle("PUT /api/users/{id}", unary(g, http.StatusOK, g.users.UpdateUser))
+	g.mux.Handle("PATCH /api/users/{id}", unary(g, http.StatusOK, g.users.UpdateUser))
+	g.mux.Handle("DELETE /api/users/{id}", unary(g, http.StatusOK, g.users.DeleteUser))
+	g.mux.Handle("GET /api/users/deleted", unary(g, http.StatusOK, g.users.ListDeletedUsers))
+	g.mux.Handle("POST /api/users/{id}/restore", unary(g, http.StatusOK, g.users.RestoreUser))
+	g.mux.Handle("GET /api/users/{user_id}/points", unary(g, http.StatusOK, g.users.GetPointsLedger))
+	g.mux.Handle("POST /api/users/login", unary(g, http.StatusOK, g.users.Login))
+	g.mux.Handle("POST /api/users/refresh", unary(g, http.StatusOK, g.users.RefreshToken))
+	g.mux.Handle("POST /api/users/logout", unary(g, http.StatusOK, g.users.Logout))
+
+	// Menu items
+	g.mux.Handle("POST /api/menu", unary(g, http.StatusCreated, g.menu.CreateMenuItem))
+	g.mux.Handle("GET /api/menu", unary(g, http.StatusOK, g.menu.GetMenuItems))
+	g.mux.Handle("GET /api/menu/{id}", unary(g, http.StatusOK, g.menu.GetMenuItem))
+	g.mux.Handle("PUT /api/menu/{id}", unary(g, http.StatusOK, g.menu.UpdateMenuItem))
+	g.mux.Handle("PATCH /api/menu/{id}", unary(g, http.StatusOK, g.menu.UpdateMenuItem))
+	g.mux.Handle("DELETE /api/menu/{id}", unary(g, http.StatusOK, g.menu.DeleteMenuItem))
+	g.mux.Handle("GET /api/menu/deleted", unary(g, http.StatusOK, g.menu.ListDeletedMenuItems))
+	g.mux.Handle("POST /api/menu/{id}/restore", unary(g, http.StatusOK, g.menu.RestoreMenuItem))
+	g.mux.Handle("POST /api/menu/{id}/stock", unary(g, http.StatusOK, g.menu.AdjustStock))
+
+	// Categories and tags
+	g.mux.Handle("POST /api/menu/categories", unary(g, http.StatusCreated, g.menu.CreateCategory))
+	g.mux.Handle("GET /api/menu/categories", unary(g, http.StatusOK, g.menu.GetCategories))
+	g.mux.Handle("GET /api/menu/categories/{id}", unary(g, http.StatusOK, g.menu.GetCategory))
+	g.mux.Handle("PUT /api/menu/categories/{id}", unary(g, http.StatusOK, g.menu.UpdateCategory))
+	g.mux.Handle("DELETE /api/menu/categories/{id}", unary(g, http.StatusOK, g.menu.DeleteCategory))
+	g.mux.Handle("POST /api/menu/tags", unary(g, http.StatusCreated, g.menu.CreateTag))
+	g.mux.Handle("GET /api/menu/tags", unary(g, http.StatusOK, g.menu.GetTags))
+	g.mux.Handle("PUT /api/menu/tags/{id}", unary(g, http.StatusOK, g.menu.UpdateTag))
+	g.mux.Handle("DELETE /api/menu/tags/{id}", unary(g, http.StatusOK, g.menu.DeleteTag))
+
+	// Orders
+	g.mux.Handle("POST /api/orders", unary(g, http.StatusCreated, g.orders.CreateOrder))
+	g.mux.Handle("GET /api/orders", unary(g, http.StatusOK, g.orders.GetOrders))
+	g.mux.Handle("GET /api/orders/{id}", unary(g, http.StatusOK, g.orders.GetOrder))
+	g.mux.Handle("PATCH /api/orders/{id}", unary(g, http.StatusOK, g.orders.UpdateOrderStatus))
+	g.mux.Handle("POST /api/orders/{id}/cancel", unary(g, http.StatusOK, g.orders.CancelOrder))
+	g.mux.Handle("POST /api/orders/{id}/refund", unary(g, http.StatusOK, g.orders.RefundOrder))
+}
+
+// unary adapts a unary gRPC method to an HTTP handler that answers with
+// code when the call succeeds.
+func unary[Req, Resp proto.Message](g *Gateway, code int, call func(context.Context, Req, ...grpc.CallOption) (Resp, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var zero Req
+		req := zero.ProtoReflect().Type().New().Interface().(Req)
+		if err := bind(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		ctx, cancel, err := g.outgoingContext(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		defer cancel()
+
+		resp, err := call(ctx, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeMessage(w, code, resp)
+	})
+}
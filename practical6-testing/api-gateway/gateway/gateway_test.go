@@ -0,0 +1,272 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// call records the last request a fake client received.
+type call struct {
+	req      any
+	md       metadata.MD
+	deadline time.Time
+	err      error
+}
+
+func (c *call) record(ctx context.Context, req any) error {
+	c.req = req
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	c.deadline, _ = ctx.Deadline()
+	return c.err
+}
+
+type fakeUsers struct {
+	userv1.UserServiceClient
+	call
+}
+
+func (f *fakeUsers) CreateUser(ctx context.Context, req *userv1.CreateUserRequest, _ ...grpc.CallOption) (*userv1.CreateUserResponse, error) {
+	if err := f.record(ctx, req); err != nil {
+		return nil, err
+	}
+	return &userv1.CreateUserResponse{User: &userv1.User{Id: 1, Name: req.Name, Email: req.Email}}, nil
+}
+
+func (f *fakeUsers) GetUser(ctx context.Context, req *userv1.GetUserRequest, _ ...grpc.CallOption) (*userv1.GetUserResponse, error) {
+	if err := f.record(ctx, req); err != nil {
+		return nil, err
+	}
+	return &userv1.GetUserResponse{User: &userv1.User{Id: req.Id, Name: "Ada"}}, nil
+}
+
+func (f *fakeUsers) GetUsers(ctx context.Context, req *userv1.GetUsersRequest, _ ...grpc.CallOption) (*userv1.GetUsersResponse, error) {
+	return &userv1.GetUsersResponse{}, f.record(ctx, req)
+}
+
+func (f *fakeUsers) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest, _ ...grpc.CallOption) (*userv1.UpdateUserResponse, error) {
+	return &userv1.UpdateUserResponse{}, f.record(ctx, req)
+}
+
+type fakeMenu struct {
+	menuv1.MenuServiceClient
+	call
+}
+
+func (f *fakeMenu) AdjustStock(ctx context.Context, req *menuv1.AdjustStockRequest, _ ...grpc.CallOption) (*menuv1.AdjustStockResponse, error) {
+	return &menuv1.AdjustStockResponse{}, f.record(ctx, req)
+}
+
+type fakeOrders struct {
+	orderv1.OrderServiceClient
+	call
+}
+
+func (f *fakeOrders) GetOrders(ctx context.Context, req *orderv1.GetOrdersRequest, _ ...grpc.CallOption) (*orderv1.GetOrdersResponse, error) {
+	return &orderv1.GetOrdersResponse{}, f.record(ctx, req)
+}
+
+func (f *fakeOrders) CancelOrder(ctx context.Context, req *orderv1.CancelOrderRequest, _ ...grpc.CallOption) (*orderv1.CancelOrderResponse, error) {
+	return &orderv1.CancelOrderResponse{}, f.record(ctx, req)
+}
+
+func newTestGateway() (*Gateway, *fakeUsers, *fakeMenu, *fakeOrders) {
+	users, menu, orders := &fakeUsers{}, &fakeMenu{}, &fakeOrders{}
+	return New(users, menu, orders), users, menu, orders
+}
+
+func serve(g *Gateway, method, target, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) errorStatus {
+	t.Helper()
+	var body errorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), rec.Body.String())
+	return body.Error
+}
+
+func TestRoutes(t *testing.T) {
+	g, users, menu, orders := newTestGateway()
+
+	t.Run("path wildcard fills the id", func(t *testing.T) {
+		rec := serve(g, http.MethodGet, "/api/users/7", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"user": {"id": 7, "name": "Ada"}}`, rec.Body.String())
+		assert.Equal(t, uint32(7), users.req.(*userv1.GetUserRequest).Id)
+	})
+
+	t.Run("create answers 201 with the body bound", func(t *testing.T) {
+		rec := serve(g, http.MethodPost, "/api/users", `{"name": "Bo", "email": "bo@example.com", "isCafeOwner": true}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		req := users.req.(*userv1.CreateUserRequest)
+		assert.Equal(t, "Bo", req.Name)
+		assert.Equal(t, "bo@example.com", req.Email)
+		assert.True(t, req.IsCafeOwner)
+	})
+
+	t.Run("path wins over the body", func(t *testing.T) {
+		rec := serve(g, http.MethodPost, "/api/menu/4/stock", `{"id": 9, "delta": -2}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		req := menu.req.(*menuv1.AdjustStockRequest)
+		assert.Equal(t, uint32(4), req.Id)
+		assert.Equal(t, int64(-2), req.GetDelta())
+	})
+
+	t.Run("query parameters by proto or JSON name", func(t *testing.T) {
+		rec := serve(g, http.MethodGet, "/api/users?page_size=10&isCafeOwner=true&email_prefix=ad", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		req := users.req.(*userv1.GetUsersRequest)
+		assert.Equal(t, int32(10), req.PageSize)
+		require.NotNil(t, req.IsCafeOwner)
+		assert.True(t, *req.IsCafeOwner)
+		assert.Equal(t, "ad", req.EmailPrefix)
+	})
+
+	t.Run("query parameters for enums and timestamps", func(t *testing.T) {
+		rec := serve(g, http.MethodGet, "/api/orders?status=ORDER_STATUS_READY&created_after=2026-01-02T03:04:05Z", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		req := orders.req.(*orderv1.GetOrdersRequest)
+		assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_READY, req.Status)
+		assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), req.CreatedAfter.AsTime())
+	})
+
+	t.Run("action routes", func(t *testing.T) {
+		rec := serve(g, http.MethodPost, "/api/orders/12/cancel", `{"reason": "CANCELLATION_REASON_CUSTOMER_REQUEST"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, uint32(12), orders.req.(*orderv1.CancelOrderRequest).Id)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(g, http.MethodGet, "/api/nothing", "").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(g, http.MethodDelete, "/api/orders", "").Code)
+	})
+}
+
+func TestBindErrors(t *testing.T) {
+	g, users, _, _ := newTestGateway()
+
+	for name, tc := range map[string]struct {
+		method, target, body string
+	}{
+		"malformed JSON":       {http.MethodPost, "/api/users", `{"name":`},
+		"unknown body field":   {http.MethodPost, "/api/users", `{"nickname": "Bo"}`},
+		"unknown query param":  {http.MethodGet, "/api/users?colour=red", ""},
+		"unparsable query":     {http.MethodGet, "/api/users?page_size=ten", ""},
+		"unparsable path":      {http.MethodGet, "/api/users/abc", ""},
+		"unknown enum value":   {http.MethodGet, "/api/orders?status=ORDER_STATUS_LOST", ""},
+		"PATCH with no fields": {http.MethodPatch, "/api/users/3", `{}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			users.req = nil
+			rec := serve(g, tc.method, tc.target, tc.body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			assert.Equal(t, "INVALID_ARGUMENT", decodeError(t, rec).Status)
+			assert.Nil(t, users.req, "the service must not be called")
+		})
+	}
+}
+
+func TestPatchDerivesUpdateMask(t *testing.T) {
+	g, users, _, _ := newTestGateway()
+
+	rec := serve(g, http.MethodPatch, "/api/users/3", `{"name": "Bo", "isCafeOwner": false}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	req := users.req.(*userv1.UpdateUserRequest)
+	assert.Equal(t, uint32(3), req.Id)
+	assert.ElementsMatch(t, []string{"name", "is_cafe_owner"}, req.UpdateMask.GetPaths())
+
+	// A mask sent by the caller is kept
+	rec = serve(g, http.MethodPatch, "/api/users/3", `{"name": "Bo", "email": "bo@example.com", "updateMask": "email"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []string{"email"}, users.req.(*userv1.UpdateUserRequest).UpdateMask.GetPaths())
+
+	// PUT replaces the whole resource
+	rec = serve(g, http.MethodPut, "/api/users/3", `{"name": "Bo"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Nil(t, users.req.(*userv1.UpdateUserRequest).UpdateMask)
+}
+
+func TestErrorResponses(t *testing.T) {
+	g, users, _, _ := newTestGateway()
+
+	users.err = status.Error(codes.NotFound, "user not found")
+	rec := serve(g, http.MethodGet, "/api/users/7", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error": {"code": 404, "status": "NOT_FOUND", "message": "user not found"}}`, rec.Body.String())
+
+	st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "must be an email"}},
+	})
+	require.NoError(t, err)
+	users.err = st.Err()
+	rec = serve(g, http.MethodGet, "/api/users/7", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	body := decodeError(t, rec)
+	require.Len(t, body.Details, 1)
+	assert.JSONEq(t, `{
+		"@type": "type.googleapis.com/google.rpc.BadRequest",
+		"fieldViolations": [{"field": "email", "description": "must be an email"}]
+	}`, string(body.Details[0]))
+
+	for code, want := range map[codes.Code]int{
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.Aborted:            http.StatusConflict,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.Unknown:            http.StatusInternalServerError,
+	} {
+		assert.Equal(t, want, HTTPStatus(code), code.String())
+	}
+}
+
+func TestForwardsHeadersAndDeadline(t *testing.T) {
+	g, users, _, _ := newTestGateway()
+	g.MaxTimeout = time.Minute
+
+	start := time.Now()
+	rec := serve(g, http.MethodPost, "/api/users", `{"name": "Bo"}`,
+		"Authorization", "Bearer token", "Idempotency-Key", "abc", "Cookie", "secret=1")
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, []string{"Bearer token"}, users.md.Get("authorization"))
+	assert.Equal(t, []string{"abc"}, users.md.Get("idempotency-key"))
+	assert.Empty(t, users.md.Get("cookie"))
+	assert.WithinDuration(t, start.Add(time.Minute), users.deadline, 5*time.Second)
+
+	// Callers may ask for less time than the gateway allows, but not more
+	serve(g, http.MethodGet, "/api/users/1", "", "Grpc-Timeout", "2S")
+	assert.WithinDuration(t, start.Add(2*time.Second), users.deadline, 5*time.Second)
+	serve(g, http.MethodGet, "/api/users/1", "", "Grpc-Timeout", "5H")
+	assert.WithinDuration(t, start.Add(time.Minute), users.deadline, 5*time.Second)
+
+	rec = serve(g, http.MethodGet, "/api/users/1", "", "Grpc-Timeout", "soon")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
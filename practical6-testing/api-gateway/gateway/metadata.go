@@ -0,0 +1,83 @@
+package gateway
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedHeaders are passed on to the services as gRPC metadata of the
+// same name, lower-cased.
+var forwardedHeaders = []string{"Authorization", "Idempotency-Key"}
+
+// timeoutHeader lets callers bound how long the services may take, in the
+// gRPC timeout format, e.g. "500m" for half a second or "5S" for five.
+const timeoutHeader = "Grpc-Timeout"
+
+// timeoutUnits are the units of the gRPC timeout format.
+var timeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// outgoingContext derives the context for the gRPC call made on behalf of r.
+// It is cancelled when the caller goes away or its deadline passes, and
+// carries the forwarded headers.
+func (g *Gateway) outgoingContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	timeout := g.MaxTimeout
+	if raw := r.Header.Get(timeoutHeader); raw != "" {
+		requested, err := parseTimeout(raw)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid %s header %q", timeoutHeader, raw)
+		}
+		if timeout <= 0 || requested < timeout {
+			timeout = requested
+		}
+	}
+
+	md := metadata.MD{}
+	for _, header := range forwardedHeaders {
+		if values := r.Header.Values(header); len(values) > 0 {
+			md.Set(strings.ToLower(header), values...)
+		}
+	}
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+	if timeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// parseTimeout parses a timeout in the gRPC format: up to eight digits
+// followed by a unit.
+func parseTimeout(raw string) (time.Duration, error) {
+	if len(raw) < 2 || len(raw) > 9 {
+		return 0, strconv.ErrSyntax
+	}
+	unit, ok := timeoutUnits[raw[len(raw)-1]]
+	if !ok {
+		return 0, strconv.ErrSyntax
+	}
+	n, err := strconv.ParseUint(raw[:len(raw)-1], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if time.Duration(n) > math.MaxInt64/unit {
+		return math.MaxInt64, nil
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -1,3 +1,62 @@
 module api-gateway
 
-go 1.23.2
+go 1.24.0
+
+toolchain go1.24.10
+
+replace user-service => ../user-service
+
+replace menu-service => ../menu-service
+
+replace order-service => ../order-service
+
+replace authz => ../authz
+
+replace dberr => ../dberr
+
+replace validation => ../validation
+
+replace prototime => ../prototime
+
+replace healthcheck => ../healthcheck
+
+replace graceful => ../graceful
+
+replace pagination => ../pagination
+
+replace fieldmask => ../fieldmask
+
+replace softdelete => ../softdelete
+
+replace storedriver => ../storedriver
+
+replace envconfig => ../envconfig
+
+require (
+	envconfig v0.0.0
+	github.com/stretchr/testify v1.11.1
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8
+	google.golang.org/grpc v1.77.0
+	google.golang.org/protobuf v1.36.10
+	menu-service v0.0.0
+	order-service v0.0.0
+	user-service v0.0.0
+	validation v0.0.0
+)
+
+require (
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.10-20251209175733-2a1774d88802.1 // indirect
+	buf.build/go/protovalidate v1.1.0 // indirect
+	cel.dev/expr v0.24.0 // indirect
+	github.com/antlr4-go/antlr/v4 v4.13.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/cel-go v0.26.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stoewer/go-strcase v1.3.1 // indirect
+	golang.org/x/exp v0.0.0-20250813145105-42675adae3e6 // indirect
+	golang.org/x/net v0.46.1-0.20251013234738-63d1a5100f82 // indirect
+	golang.org/x/sys v0.37.0 // indirect
+	golang.org/x/text v0.30.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20251022142026-3a174f9686a8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
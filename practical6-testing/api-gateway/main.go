@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"api-gateway/gateway"
+	"envconfig"
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+	"validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	// Connect to user service
+	userConn, err := dial(envconfig.String("USER_SERVICE_ADDR", "localhost:50051"))
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userConn.Close()
+
+	// Connect to menu service
+	menuConn, err := dial(envconfig.String("MENU_SERVICE_ADDR", "localhost:50052"))
+	if err != nil {
+		log.Fatalf("Failed to connect to menu service: %v", err)
+	}
+	defer menuConn.Close()
+
+	// Connect to order service
+	orderConn, err := dial(envconfig.String("ORDER_SERVICE_ADDR", "localhost:50053"))
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer orderConn.Close()
+
+	gw := gateway.New(
+		userv1.NewUserServiceClient(userConn),
+		menuv1.NewMenuServiceClient(menuConn),
+		orderv1.NewOrderServiceClient(orderConn),
+	)
+
+	// Longest a request may wait on the services
+	timeout, err := time.ParseDuration(envconfig.String("REQUEST_TIMEOUT", gateway.DefaultTimeout.String()))
+	if err != nil || timeout < 0 {
+		log.Fatalf("Invalid REQUEST_TIMEOUT: %q", os.Getenv("REQUEST_TIMEOUT"))
+	}
+	gw.MaxTimeout = timeout
+
+	httpPort := envconfig.String("HTTP_PORT", "8080")
+	server := &http.Server{
+		Addr:              ":" + httpPort,
+		Handler:           gw,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("API gateway listening on port %s", httpPort)
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// dial connects to a backend service. Requests are validated before they
+// are sent, so malformed input is refused without a round trip.
+func dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(validation.UnaryClientInterceptor))
+}
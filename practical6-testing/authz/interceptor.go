@@ -0,0 +1,176 @@
+package authz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationHeader is the metadata key that carries the bearer token.
+const authorizationHeader = "authorization"
+
+// Authorizer enforces a Policy using access tokens verified against Keys.
+type Authorizer struct {
+	Keys   *KeySet
+	Policy Policy
+}
+
+func NewAuthorizer(keys *KeySet, policy Policy) *Authorizer {
+	return &Authorizer{Keys: keys, Policy: policy}
+}
+
+// UnaryServerInterceptor authorizes unary calls and makes the caller's
+// claims available to handlers through FromContext.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rule, claims, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Check != nil {
+			if err := rule.Check(claims, req); err != nil {
+				return nil, err
+			}
+		}
+		if claims != nil {
+			ctx = NewContext(ctx, claims)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authorizes streaming calls. Rule checks run on
+// every message the client sends.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		rule, claims, err := a.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		ctx := stream.Context()
+		if claims != nil {
+			ctx = NewContext(ctx, claims)
+		}
+		return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx, rule: rule, claims: claims})
+	}
+}
+
+// authenticate looks up the rule for method and verifies the caller's token
+// against it. claims is nil for anonymous calls to Public methods.
+func (a *Authorizer) authenticate(ctx context.Context, method string) (Rule, *Claims, error) {
+	rule := a.Policy[method]
+	if rule.Access == Deny {
+		return rule, nil, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+	}
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return rule, nil, err
+	}
+	if token == "" {
+		if rule.Access == Public {
+			return rule, nil, nil
+		}
+		return rule, nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims, err := VerifyAccessToken(a.Keys, token)
+	if err != nil {
+		return rule, nil, status.Errorf(codes.Unauthenticated, "invalid bearer token: %v", err)
+	}
+	if rule.Access == Owner && !claims.CafeOwner {
+		return rule, nil, status.Errorf(codes.PermissionDenied, "only cafe owners may call %s", method)
+	}
+	if rule.Access == Service && claims.Service == "" {
+		return rule, nil, status.Errorf(codes.PermissionDenied, "only cafe services may call %s", method)
+	}
+	return rule, claims, nil
+}
+
+// bearerToken returns the token from the authorization metadata, or an
+// empty string when there is none.
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", status.Error(codes.Unauthenticated, `authorization must be "Bearer <token>"`)
+	}
+	return token, nil
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	rule   Rule
+	claims *Claims
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.rule.Check != nil {
+		return s.rule.Check(s.claims, m)
+	}
+	return nil
+}
+
+// ForwardToken is a client interceptor that passes the bearer token of the
+// incoming call on to downstream services, so they authorize the original
+// caller. Calls made with their own credentials, such as
+// ServiceCredentials, are sent with those alone.
+func ForwardToken(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	for _, opt := range opts {
+		if _, ok := opt.(grpc.PerRPCCredsCallOption); ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationHeader); len(values) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, values[0])
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// ServiceCredentials authenticate calls a service makes on its own behalf,
+// such as from background jobs, with a freshly signed service token.
+type ServiceCredentials struct {
+	Keys    *KeySet
+	Service string
+	// TTL is how long each token is valid.
+	TTL time.Duration
+}
+
+func NewServiceCredentials(keys *KeySet, service string) *ServiceCredentials {
+	return &ServiceCredentials{Keys: keys, Service: service, TTL: time.Minute}
+}
+
+func (c *ServiceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := IssueServiceToken(c.Keys, c.Service, time.Now(), c.TTL)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{authorizationHeader: "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity is false because the services talk over the
+// private network without TLS.
+func (c *ServiceCredentials) RequireTransportSecurity() bool {
+	return false
+}
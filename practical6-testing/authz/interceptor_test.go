@@ -0,0 +1,189 @@
+package authz
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRequest struct {
+	UserID uint32
+}
+
+var testPolicy = Policy{
+	"/test.v1.Test/Public":        {Access: Public},
+	"/test.v1.Test/Authenticated": {Access: Authenticated},
+	"/test.v1.Test/Owner":         {Access: Owner},
+	"/test.v1.Test/Service":       {Access: Service},
+	"/test.v1.Test/Self": {
+		Access: Authenticated,
+		Check:  SelfOrOwner(func(req *fakeRequest) uint32 { return req.UserID }),
+	},
+}
+
+func issue(t *testing.T, keys *KeySet, userID uint, owner bool) string {
+	token, err := IssueAccessToken(keys, userID, owner, time.Now(), time.Minute)
+	require.NoError(t, err)
+	return token
+}
+
+func withToken(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+	interceptor := NewAuthorizer(keys, testPolicy).UnaryServerInterceptor()
+
+	customer := issue(t, keys, 7, false)
+	owner := issue(t, keys, 1, true)
+	service, err := IssueServiceToken(keys, "order-service", time.Now(), time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		req    any
+		want   codes.Code
+	}{
+		{"public without token", "/test.v1.Test/Public", "", &fakeRequest{}, codes.OK},
+		{"public with bad token", "/test.v1.Test/Public", "garbage", &fakeRequest{}, codes.Unauthenticated},
+		{"authenticated without token", "/test.v1.Test/Authenticated", "", &fakeRequest{}, codes.Unauthenticated},
+		{"authenticated customer", "/test.v1.Test/Authenticated", customer, &fakeRequest{}, codes.OK},
+		{"owner rpc as customer", "/test.v1.Test/Owner", customer, &fakeRequest{}, codes.PermissionDenied},
+		{"owner rpc as owner", "/test.v1.Test/Owner", owner, &fakeRequest{}, codes.OK},
+		{"self as self", "/test.v1.Test/Self", customer, &fakeRequest{UserID: 7}, codes.OK},
+		{"self as someone else", "/test.v1.Test/Self", customer, &fakeRequest{UserID: 8}, codes.PermissionDenied},
+		{"self as owner", "/test.v1.Test/Self", owner, &fakeRequest{UserID: 8}, codes.OK},
+		{"service rpc as owner", "/test.v1.Test/Service", owner, &fakeRequest{}, codes.PermissionDenied},
+		{"service rpc as service", "/test.v1.Test/Service", service, &fakeRequest{}, codes.OK},
+		{"self as service", "/test.v1.Test/Self", service, &fakeRequest{UserID: 8}, codes.PermissionDenied},
+		{"unlisted method", "/test.v1.Test/Unlisted", owner, &fakeRequest{}, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerClaims *Claims
+			handler := func(ctx context.Context, req any) (any, error) {
+				handlerClaims, _ = FromContext(ctx)
+				return "ok", nil
+			}
+
+			_, err := interceptor(withToken(tt.token), tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.want, status.Code(err))
+			if tt.want == codes.OK && tt.token != "" {
+				require.NotNil(t, handlerClaims)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_MalformedHeader(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+	interceptor := NewAuthorizer(keys, testPolicy).UnaryServerInterceptor()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc"))
+	_, err = interceptor(ctx, &fakeRequest{}, &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Public"},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *fakeRequest
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) RecvMsg(m any) error {
+	*m.(*fakeRequest) = *s.req
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+	interceptor := NewAuthorizer(keys, testPolicy).StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.v1.Test/Self", IsServerStream: true}
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		if _, ok := FromContext(stream.Context()); !ok {
+			return status.Error(codes.Internal, "no claims")
+		}
+		return stream.RecvMsg(&fakeRequest{})
+	}
+
+	stream := &fakeStream{ctx: withToken(issue(t, keys, 7, false)), req: &fakeRequest{UserID: 7}}
+	assert.NoError(t, interceptor(nil, stream, info, handler))
+
+	stream.req = &fakeRequest{UserID: 8}
+	assert.Equal(t, codes.PermissionDenied, status.Code(interceptor(nil, stream, info, handler)))
+
+	stream.ctx = context.Background()
+	assert.Equal(t, codes.Unauthenticated, status.Code(interceptor(nil, stream, info, handler)))
+}
+
+func TestForwardToken(t *testing.T) {
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc"))
+
+	var forwarded []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		forwarded = md.Get("authorization")
+		return nil
+	}
+
+	require.NoError(t, ForwardToken(incoming, "/test.v1.Test/Public", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"Bearer abc"}, forwarded)
+
+	require.NoError(t, ForwardToken(context.Background(), "/test.v1.Test/Public", nil, nil, nil, invoker))
+	assert.Empty(t, forwarded)
+
+	// A call with its own credentials does not also carry the caller's token
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+	creds := grpc.PerRPCCredentials(NewServiceCredentials(keys, "order-service"))
+	require.NoError(t, ForwardToken(incoming, "/test.v1.Test/Public", nil, nil, nil, invoker, creds))
+	assert.Empty(t, forwarded)
+}
+
+func TestAuthorizeUser(t *testing.T) {
+	claims := &Claims{}
+	claims.Subject = "2"
+	ctx := NewContext(context.Background(), claims)
+
+	assert.NoError(t, AuthorizeUser(ctx, 2))
+	assert.Equal(t, codes.PermissionDenied, status.Code(AuthorizeUser(ctx, 3)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(AuthorizeUser(context.Background(), 2)),
+		"calls the interceptor did not authenticate are rejected")
+}
+
+func TestServiceCredentials(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+
+	md, err := NewServiceCredentials(keys, "order-service").GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+
+	scheme, token, _ := strings.Cut(md["authorization"], " ")
+	assert.Equal(t, "Bearer", scheme)
+	claims, err := VerifyAccessToken(keys, token)
+	require.NoError(t, err)
+	assert.Equal(t, "order-service", claims.Service)
+	assert.False(t, claims.CafeOwner)
+}
@@ -0,0 +1,122 @@
+package authz
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testKey struct {
+	ID         string `json:"id"`
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+}
+
+func generateKey(t *testing.T, id string) testKey {
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	return testKey{ID: id, PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))}
+}
+
+func publicOnly(t *testing.T, key testKey) testKey {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(private.(ed25519.PrivateKey).Public())
+	require.NoError(t, err)
+	return testKey{ID: key.ID, PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))}
+}
+
+func writeKeyFile(t *testing.T, path, activeID string, keys ...testKey) {
+	data, err := json.Marshal(map[string]any{"active_key_id": activeID, "keys": keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestKeyRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	oldKey, newKey := generateKey(t, "2026-07"), generateKey(t, "2026-10")
+	now := time.Now()
+
+	writeKeyFile(t, path, oldKey.ID, oldKey)
+	keys, err := LoadKeySet(path)
+	require.NoError(t, err)
+
+	oldToken, err := IssueAccessToken(keys, 7, true, now, time.Hour)
+	require.NoError(t, err)
+
+	// Activate the new key, keeping only the public half of the old one
+	writeKeyFile(t, path, newKey.ID, newKey, publicOnly(t, oldKey))
+	require.NoError(t, keys.Reload())
+
+	newToken, err := IssueAccessToken(keys, 8, false, now, time.Hour)
+	require.NoError(t, err)
+
+	for _, token := range []string{oldToken, newToken} {
+		claims, err := VerifyAccessToken(keys, token)
+		require.NoError(t, err)
+		assert.Equal(t, Issuer, claims.Issuer)
+	}
+
+	claims, err := VerifyAccessToken(keys, oldToken)
+	require.NoError(t, err)
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), userID)
+	assert.True(t, claims.CafeOwner)
+
+	// Once the old key is dropped its tokens no longer verify
+	writeKeyFile(t, path, newKey.ID, newKey)
+	require.NoError(t, keys.Reload())
+	_, err = VerifyAccessToken(keys, oldToken)
+	assert.Error(t, err)
+	_, err = VerifyAccessToken(keys, newToken)
+	assert.NoError(t, err)
+}
+
+func TestReload_KeepsKeysOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	key := generateKey(t, "k1")
+	writeKeyFile(t, path, key.ID, key)
+	keys, err := LoadKeySet(path)
+	require.NoError(t, err)
+
+	// The active key must have a private half
+	writeKeyFile(t, path, key.ID, publicOnly(t, key))
+	assert.Error(t, keys.Reload())
+
+	id, _, ok := keys.SigningKey()
+	assert.True(t, ok)
+	assert.Equal(t, "k1", id)
+}
+
+func TestVerifyAccessToken_Rejects(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+	other, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+
+	expired, err := IssueAccessToken(keys, 1, false, time.Now().Add(-time.Hour), time.Minute)
+	require.NoError(t, err)
+	_, err = VerifyAccessToken(keys, expired)
+	assert.Error(t, err)
+
+	// Same key ID, different key
+	forged, err := IssueAccessToken(other, 1, true, time.Now(), time.Hour)
+	require.NoError(t, err)
+	_, err = VerifyAccessToken(keys, forged)
+	assert.Error(t, err)
+
+	_, err = VerifyAccessToken(keys, "not-a-token")
+	assert.Error(t, err)
+}
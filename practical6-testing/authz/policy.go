@@ -0,0 +1,89 @@
+package authz
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access is who may call an RPC.
+type Access int
+
+const (
+	// Deny rejects every caller. It is the zero value so that a missing
+	// policy entry fails closed.
+	Deny Access = iota
+	// Public RPCs may be called without a token. A token that is sent must
+	// still be valid.
+	Public
+	// Authenticated RPCs need a valid token from any user.
+	Authenticated
+	// Owner RPCs need a valid token from a cafe owner.
+	Owner
+	// Service RPCs need a valid token from IssueServiceToken, so only other
+	// cafe services may call them.
+	Service
+)
+
+// Rule is the authorization policy for one RPC.
+type Rule struct {
+	Access Access
+	// Check, when set, inspects each request message after Access has
+	// passed. claims is nil for anonymous calls to Public RPCs.
+	Check func(claims *Claims, req any) error
+}
+
+// Policy maps full gRPC method names, such as
+// "/user.v1.UserService/GetUser", to their rules. Methods that are not
+// listed are denied.
+type Policy map[string]Rule
+
+// SelfOrOwner returns a Check that lets cafe owners act for anyone and
+// everyone else act only for themselves. userID extracts the user the
+// request acts for.
+func SelfOrOwner[Req any](userID func(req Req) uint32) func(*Claims, any) error {
+	return func(claims *Claims, req any) error {
+		typed, ok := req.(Req)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected request type %T", req)
+		}
+		return CheckSelfOrOwner(claims, userID(typed))
+	}
+}
+
+// CheckSelfOrOwner returns PermissionDenied unless claims belong to a cafe
+// owner or to the user userID.
+func CheckSelfOrOwner(claims *Claims, userID uint32) error {
+	if claims == nil {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	if claims.CafeOwner {
+		return nil
+	}
+	if id, err := claims.UserID(); err != nil || id != userID {
+		return status.Errorf(codes.PermissionDenied, "user %s cannot act for user %d", claims.Subject, userID)
+	}
+	return nil
+}
+
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx carrying the caller's claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// AuthorizeUser enforces CheckSelfOrOwner for handlers that only learn which
+// user a resource belongs to after loading it. A context without claims was
+// not authenticated and is rejected.
+func AuthorizeUser(ctx context.Context, userID uint32) error {
+	claims, _ := FromContext(ctx)
+	return CheckSelfOrOwner(claims, userID)
+}
@@ -0,0 +1,211 @@
+// Package dberr translates errors returned by GORM, on either the Postgres
+// or the SQLite driver, into gRPC status errors with the matching code.
+package dberr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Domain identifies the ErrorInfo details attached by this package.
+const Domain = "db"
+
+// ReasonUniqueViolation is the ErrorInfo reason attached to AlreadyExists
+// errors. Its metadata holds the conflicting field under "field".
+const ReasonUniqueViolation = "UNIQUE_VIOLATION"
+
+// Postgres SQLSTATE codes, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgAdminShutdown       = "57P01"
+	pgCrashShutdown       = "57P02"
+	pgCannotConnectNow    = "57P03"
+	pgTooManyConnections  = "53300"
+)
+
+// UniqueViolation is returned by stores without a database driver, such as
+// in-memory ones, when a write would break a uniqueness rule. Field names
+// the conflicting column, as the driver errors do.
+type UniqueViolation struct {
+	Field string
+}
+
+func (e *UniqueViolation) Error() string {
+	return "unique constraint failed: " + e.Field
+}
+
+// Status translates err into a gRPC status error. resource names what was
+// being read or written, such as "user" or "menu item", and is used in the
+// messages. Errors that already carry a status, such as those returned from
+// inside a transaction, are passed through unchanged; nil stays nil.
+func Status(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Errorf(codes.NotFound, "%s not found", resource)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "%s: request cancelled", resource)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "%s: deadline exceeded", resource)
+	}
+
+	var unique *UniqueViolation
+	if errors.As(err, &unique) {
+		return alreadyExists(resource, unique.Field)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if translated := fromPostgres(pgErr, resource); translated != nil {
+			return translated
+		}
+	} else if translated := fromSQLite(err, resource); translated != nil {
+		return translated
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return alreadyExists(resource, "")
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return foreignKeyViolated(resource, "")
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return checkViolated(resource, "")
+	case isConnectionError(err):
+		return status.Errorf(codes.Unavailable, "%s: database unavailable: %v", resource, err)
+	}
+	return status.Errorf(codes.Internal, "%s: database error: %v", resource, err)
+}
+
+// ConflictingField returns the field named by an AlreadyExists error from
+// Status, or an empty string when err carries none.
+func ConflictingField(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain && info.Reason == ReasonUniqueViolation {
+			return info.Metadata["field"]
+		}
+	}
+	return ""
+}
+
+func fromPostgres(pgErr *pgconn.PgError, resource string) error {
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		field := keyColumns(pgErr.Detail)
+		if field == "" {
+			field = pgErr.ConstraintName
+		}
+		return alreadyExists(resource, field)
+	case pgForeignKeyViolation:
+		return foreignKeyViolated(resource, pgErr.ConstraintName)
+	case pgCheckViolation:
+		return checkViolated(resource, pgErr.ConstraintName)
+	case pgAdminShutdown, pgCrashShutdown, pgCannotConnectNow, pgTooManyConnections:
+		return status.Errorf(codes.Unavailable, "%s: database unavailable: %s", resource, pgErr.Message)
+	}
+	if strings.HasPrefix(pgErr.Code, "08") { // connection exception class
+		return status.Errorf(codes.Unavailable, "%s: database unavailable: %s", resource, pgErr.Message)
+	}
+	return nil
+}
+
+// keyColumns extracts the column list from a Postgres unique violation
+// detail such as `Key (email)=(a@example.com) already exists.`.
+func keyColumns(detail string) string {
+	rest, ok := strings.CutPrefix(detail, "Key (")
+	if !ok {
+		return ""
+	}
+	columns, _, ok := strings.Cut(rest, ")=")
+	if !ok {
+		return ""
+	}
+	return columns
+}
+
+// fromSQLite recognises the go-sqlite3 driver's errors by their message, so
+// that services do not link the cgo driver just to inspect error codes.
+func fromSQLite(err error, resource string) error {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "UNIQUE constraint failed: "):
+		return alreadyExists(resource, sqliteColumns(strings.TrimPrefix(msg, "UNIQUE constraint failed: ")))
+	case strings.HasPrefix(msg, "FOREIGN KEY constraint failed"):
+		return foreignKeyViolated(resource, "")
+	case strings.HasPrefix(msg, "CHECK constraint failed"):
+		return checkViolated(resource, strings.TrimPrefix(strings.TrimPrefix(msg, "CHECK constraint failed"), ": "))
+	case strings.HasPrefix(msg, "database is locked"), strings.HasPrefix(msg, "unable to open database file"):
+		return status.Errorf(codes.Unavailable, "%s: database unavailable: %s", resource, msg)
+	}
+	return nil
+}
+
+// sqliteColumns turns "users.email" or "items.a, items.b" into "email" or
+// "a, b".
+func sqliteColumns(list string) string {
+	columns := strings.Split(list, ", ")
+	for i, column := range columns {
+		if _, name, ok := strings.Cut(column, "."); ok {
+			columns[i] = name
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+func isConnectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		strings.Contains(err.Error(), "sql: database is closed")
+}
+
+func alreadyExists(resource, field string) error {
+	if field == "" {
+		return status.Errorf(codes.AlreadyExists, "%s already exists", resource)
+	}
+	st := status.Newf(codes.AlreadyExists, "%s with this %s already exists", resource, field)
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   ReasonUniqueViolation,
+		Domain:   Domain,
+		Metadata: map[string]string{"field": field},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withInfo.Err()
+}
+
+func foreignKeyViolated(resource, constraint string) error {
+	if constraint == "" {
+		return status.Errorf(codes.FailedPrecondition, "%s conflicts with related records", resource)
+	}
+	return status.Errorf(codes.FailedPrecondition, "%s conflicts with related records (%s)", resource, constraint)
+}
+
+func checkViolated(resource, constraint string) error {
+	if constraint == "" {
+		return status.Errorf(codes.FailedPrecondition, "%s violates a check constraint", resource)
+	}
+	return status.Errorf(codes.FailedPrecondition, "%s violates check constraint %s", resource, constraint)
+}
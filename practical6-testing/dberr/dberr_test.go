@@ -0,0 +1,160 @@
+package dberr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type account struct {
+	ID    uint
+	Email string `gorm:"uniqueIndex"`
+	Age   int    `gorm:"check:age >= 0"`
+}
+
+type session struct {
+	ID        uint
+	AccountID uint
+	Account   account
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:dberr_%d?mode=memory&cache=shared&_foreign_keys=1", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&account{}, &session{}))
+	return db
+}
+
+func TestStatus_SQLite(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&account{Email: "ada@example.com"}).Error)
+
+	t.Run("record not found", func(t *testing.T) {
+		var a account
+		err := Status(db.First(&a, 999).Error, "account")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "account not found", status.Convert(err).Message())
+	})
+
+	t.Run("unique violation names the field", func(t *testing.T) {
+		err := Status(db.Create(&account{Email: "ada@example.com"}).Error, "account")
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		assert.Equal(t, "email", ConflictingField(err))
+	})
+
+	t.Run("foreign key violation", func(t *testing.T) {
+		err := Status(db.Create(&session{AccountID: 999}).Error, "session")
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("check violation", func(t *testing.T) {
+		err := Status(db.Create(&account{Email: "bob@example.com", Age: -1}).Error, "account")
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("closed database", func(t *testing.T) {
+		closed := setupTestDB(t)
+		sqlDB, err := closed.DB()
+		require.NoError(t, err)
+		require.NoError(t, sqlDB.Close())
+
+		var a account
+		err = Status(closed.First(&a, 1).Error, "account")
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestStatus_Postgres(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  codes.Code
+		wantField string
+	}{
+		{
+			name:      "unique violation",
+			err:       &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email", Detail: "Key (email)=(ada@example.com) already exists."},
+			wantCode:  codes.AlreadyExists,
+			wantField: "email",
+		},
+		{
+			name:      "unique violation without detail",
+			err:       &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"},
+			wantCode:  codes.AlreadyExists,
+			wantField: "idx_users_email",
+		},
+		{
+			name:     "foreign key violation",
+			err:      &pgconn.PgError{Code: "23503", ConstraintName: "fk_orders_user"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "check violation",
+			err:      &pgconn.PgError{Code: "23514", ConstraintName: "chk_menu_items_stock"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "connection exception",
+			err:      &pgconn.PgError{Code: "08006", Message: "connection failure"},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "server shutting down",
+			err:      &pgconn.PgError{Code: "57P01", Message: "terminating connection due to administrator command"},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "other server error",
+			err:      &pgconn.PgError{Code: "42P01", Message: `relation "users" does not exist`},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Status(fmt.Errorf("query: %w", tt.err), "user")
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantField, ConflictingField(err))
+		})
+	}
+}
+
+func TestStatus_Generic(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"cancelled", context.Canceled, codes.Canceled},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, codes.Unavailable},
+		{"translated duplicate", gorm.ErrDuplicatedKey, codes.AlreadyExists},
+		{"status passes through", status.Error(codes.FailedPrecondition, "order was modified concurrently"), codes.FailedPrecondition},
+		{"unknown", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, status.Code(Status(tt.err, "order")))
+		})
+	}
+}
+
+func TestStatus_UniqueViolation(t *testing.T) {
+	err := Status(fmt.Errorf("create user: %w", &UniqueViolation{Field: "email"}), "user")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "email", ConflictingField(err))
+}
@@ -0,0 +1,40 @@
+// Package envconfig reads the cafe services' settings from environment
+// variables. A variable that is unset or empty takes the given default.
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// String returns the value of key, or defaultValue when it is not set.
+func String(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Duration parses key as a positive duration such as "90s" or "24h".
+func Duration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value, err := time.ParseDuration(String(key, defaultValue.String()))
+	if err != nil || value <= 0 {
+		return 0, invalid(key)
+	}
+	return value, nil
+}
+
+// Bool parses key as a boolean such as "true" or "0".
+func Bool(key string, defaultValue bool) (bool, error) {
+	value, err := strconv.ParseBool(String(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		return false, invalid(key)
+	}
+	return value, nil
+}
+
+func invalid(key string) error {
+	return fmt.Errorf("invalid %s: %q", key, os.Getenv(key))
+}
@@ -0,0 +1,51 @@
+package envconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	t.Setenv("CAFE_NAME", "")
+	assert.Equal(t, "Corner Cafe", String("CAFE_NAME", "Corner Cafe"))
+
+	t.Setenv("CAFE_NAME", "Bean There")
+	assert.Equal(t, "Bean There", String("CAFE_NAME", "Corner Cafe"))
+}
+
+func TestDuration(t *testing.T) {
+	t.Setenv("PURGE_AFTER", "")
+	value, err := Duration("PURGE_AFTER", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, value)
+
+	t.Setenv("PURGE_AFTER", "90s")
+	value, err = Duration("PURGE_AFTER", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, value)
+
+	for _, raw := range []string{"soon", "0s", "-1m"} {
+		t.Setenv("PURGE_AFTER", raw)
+		_, err = Duration("PURGE_AFTER", time.Hour)
+		assert.EqualError(t, err, `invalid PURGE_AFTER: "`+raw+`"`)
+	}
+}
+
+func TestBool(t *testing.T) {
+	t.Setenv("ENABLED", "")
+	value, err := Bool("ENABLED", true)
+	require.NoError(t, err)
+	assert.True(t, value)
+
+	t.Setenv("ENABLED", "0")
+	value, err = Bool("ENABLED", true)
+	require.NoError(t, err)
+	assert.False(t, value)
+
+	t.Setenv("ENABLED", "maybe")
+	_, err = Bool("ENABLED", true)
+	assert.EqualError(t, err, `invalid ENABLED: "maybe"`)
+}
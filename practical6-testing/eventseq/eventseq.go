@@ -0,0 +1,69 @@
+// Package eventseq numbers the events of a feed in the order their
+// transactions commit.
+//
+// Autoincrement IDs are handed out when a row is inserted, not when its
+// transaction commits, so on Postgres an event with a lower ID can become
+// visible after one with a higher ID. A consumer that resumes after the
+// highest ID it has seen would skip it forever. Next instead increments a
+// single counter row inside the transaction that writes the event. The row
+// stays locked until that transaction ends, so a concurrent writer only gets
+// the next number once the previous event has committed or rolled back.
+package eventseq
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Counter is the last sequence number handed out for a feed.
+type Counter struct {
+	Feed string `gorm:"primaryKey;size:64"`
+	Last uint64 `gorm:"not null"`
+}
+
+// TableName keeps the counters of every feed in one table.
+func (Counter) TableName() string {
+	return "event_sequences"
+}
+
+// Migrate creates the counter table and the feed's counter. events is the
+// model of the feed's table, which must have id and sequence columns:
+// events written before sequences existed are numbered by their ID, and the
+// counter starts after the highest number.
+func Migrate(db *gorm.DB, feed string, events any) error {
+	if err := db.AutoMigrate(&Counter{}); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(events).Where("sequence IS NULL").Update("sequence", gorm.Expr("id")).Error; err != nil {
+			return err
+		}
+		var last uint64
+		if err := tx.Model(events).Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Counter{Feed: feed, Last: last}).Error
+	})
+}
+
+// Next returns the feed's next sequence number. tx must be the transaction
+// that writes the event, which then holds the feed's counter until it
+// commits: writers of the same feed are serialised from this call on.
+func Next(tx *gorm.DB, feed string) (uint64, error) {
+	result := tx.Model(&Counter{}).Where("feed = ?", feed).Update("last", gorm.Expr("last + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// The first event of a feed that Migrate has not seen starts it
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Counter{Feed: feed}).Error
+		if err != nil {
+			return 0, err
+		}
+		return Next(tx, feed)
+	}
+
+	var counter Counter
+	err := tx.Where("feed = ?", feed).Take(&counter).Error
+	return counter.Last, err
+}
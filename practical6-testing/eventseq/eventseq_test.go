@@ -0,0 +1,65 @@
+package eventseq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type event struct {
+	ID       uint `gorm:"primaryKey"`
+	Sequence uint64
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&event{}))
+	return db
+}
+
+func TestMigrate_NumbersExistingEvents(t *testing.T) {
+	db := openDB(t)
+	require.NoError(t, db.Exec("INSERT INTO events (id) VALUES (1), (2), (5)").Error)
+
+	require.NoError(t, Migrate(db, "events", &event{}))
+	require.NoError(t, Migrate(db, "events", &event{}), "migrating twice keeps the counter")
+
+	var sequences []uint64
+	require.NoError(t, db.Model(&event{}).Order("id").Pluck("sequence", &sequences).Error)
+	assert.Equal(t, []uint64{1, 2, 5}, sequences)
+
+	next, err := Next(db, "events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), next)
+}
+
+func TestNext(t *testing.T) {
+	db := openDB(t)
+	require.NoError(t, Migrate(db, "events", &event{}))
+
+	first, err := Next(db, "events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first)
+
+	// A rolled back writer does not use up a number
+	errRollback := errors.New("rollback")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		_, err := Next(tx, "events")
+		require.NoError(t, err)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	second, err := Next(db, "events")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), second)
+
+	other, err := Next(db, "other")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), other, "feeds are numbered independently")
+}
@@ -0,0 +1,29 @@
+// Package fieldmask applies the update_mask of partial update RPCs.
+package fieldmask
+
+import (
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdatePaths returns the fields an update should write: those listed in
+// mask, or every updatable field when the mask is empty so that clients
+// which predate update_mask keep replacing the whole record.
+func UpdatePaths(mask *fieldmaskpb.FieldMask, updatable []string) (map[string]bool, error) {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		paths = updatable
+	}
+
+	fields := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if !slices.Contains(updatable, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask: %q cannot be updated, use one of %v", path, updatable)
+		}
+		fields[path] = true
+	}
+	return fields, nil
+}
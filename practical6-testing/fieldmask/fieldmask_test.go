@@ -0,0 +1,27 @@
+package fieldmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdatePaths(t *testing.T) {
+	updatable := []string{"name", "email"}
+
+	fields, err := UpdatePaths(&fieldmaskpb.FieldMask{Paths: []string{"email"}}, updatable)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"email": true}, fields)
+
+	// Without a mask the whole record is replaced
+	fields, err = UpdatePaths(nil, updatable)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"name": true, "email": true}, fields)
+
+	_, err = UpdatePaths(&fieldmaskpb.FieldMask{Paths: []string{"id"}}, updatable)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -4,21 +4,21 @@
 protoc --go_out=user-service --go_opt=paths=source_relative \
   --go-grpc_out=user-service --go-grpc_opt=paths=source_relative \
   --go_opt=Mproto/user.proto=user-service/proto/userv1 \
-  -I=. \
+  -I=. -I=third_party/protovalidate \
   proto/user.proto
 
 # Generate menu service proto
 protoc --go_out=menu-service --go_opt=paths=source_relative \
   --go-grpc_out=menu-service --go-grpc_opt=paths=source_relative \
   --go_opt=Mproto/menu.proto=menu-service/proto/menuv1 \
-  -I=. \
+  -I=. -I=third_party/protovalidate \
   proto/menu.proto
 
 # Generate order service proto
 protoc --go_out=order-service --go_opt=paths=source_relative \
   --go-grpc_out=order-service --go-grpc_opt=paths=source_relative \
   --go_opt=Mproto/order.proto=order-service/proto/orderv1 \
-  -I=. \
+  -I=. -I=third_party/protovalidate \
   proto/order.proto
 
 # Move generated files to correct locations
@@ -0,0 +1,139 @@
+// Package graceful shuts the cafe services down without cutting off the
+// calls they are serving. On shutdown a service first reports itself
+// unhealthy, keeps serving for a drain period while load balancers notice,
+// then stops accepting calls and waits, up to a deadline, for the ones in
+// flight to finish.
+package graceful
+
+import (
+	"context"
+	"envconfig"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Defaults for Config.
+const (
+	DefaultDrain   = 5 * time.Second
+	DefaultTimeout = 20 * time.Second
+)
+
+// Config controls how Serve shuts down.
+type Config struct {
+	// Drain is how long the server keeps serving after OnShutdown.
+	Drain time.Duration
+	// Timeout is how long calls in flight may take to finish once the
+	// server stops accepting new ones. Calls still running are then cut
+	// off.
+	Timeout time.Duration
+	// OnShutdown is called as soon as shutdown begins, typically to report
+	// NOT_SERVING.
+	OnShutdown func()
+}
+
+// ConfigFromEnv reads how long to keep serving once shutdown begins, from
+// SHUTDOWN_DRAIN, and how long calls in flight then have to finish, from
+// SHUTDOWN_TIMEOUT. A drain of 0s stops accepting calls at once.
+func ConfigFromEnv() (Config, error) {
+	raw := envconfig.String("SHUTDOWN_DRAIN", DefaultDrain.String())
+	drain, err := time.ParseDuration(raw)
+	if err != nil || drain < 0 {
+		return Config{}, fmt.Errorf("invalid SHUTDOWN_DRAIN: %q", raw)
+	}
+	timeout, err := envconfig.Duration("SHUTDOWN_TIMEOUT", DefaultTimeout)
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{Drain: drain, Timeout: timeout}, nil
+}
+
+// Serve serves s on lis until ctx is done, then shuts it down as configured.
+// It returns nil after a shutdown, even one that had to cut calls off, and
+// the error from s.Serve if the server failed on its own.
+func Serve(ctx context.Context, s *grpc.Server, lis net.Listener, cfg Config) error {
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(lis) }()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down, draining for %v", cfg.Drain)
+	if cfg.OnShutdown != nil {
+		cfg.OnShutdown()
+	}
+	select {
+	case err := <-served:
+		return err
+	case <-time.After(cfg.Drain):
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		log.Println("All calls finished")
+	case <-time.After(cfg.Timeout):
+		log.Printf("Calls still running after %v, cutting them off", cfg.Timeout)
+		s.Stop()
+		<-stopped
+	}
+	return <-served
+}
+
+// Jobs runs background jobs, such as periodic purges, that stop with the
+// service.
+type Jobs struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewJobs() *Jobs {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Jobs{ctx: ctx, cancel: cancel}
+}
+
+// Go runs job in its own goroutine. job must return soon after its context
+// is cancelled.
+func (j *Jobs) Go(job func(ctx context.Context)) {
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		job(j.ctx)
+	}()
+}
+
+// Every runs job once per interval, starting one interval from now, until
+// the jobs are stopped.
+func (j *Jobs) Every(interval time.Duration, job func(ctx context.Context)) {
+	j.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job(ctx)
+			}
+		}
+	})
+}
+
+// Stop cancels every job and waits for them to return.
+func (j *Jobs) Stop() {
+	j.cancel()
+	j.wg.Wait()
+}
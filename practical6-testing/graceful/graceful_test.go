@@ -0,0 +1,183 @@
+package graceful
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// testServer answers "/test.Test/Fast" at once and "/test.Test/Block" once
+// release is closed or the call is cancelled.
+type testServer struct {
+	server   *grpc.Server
+	listener *bufconn.Listener
+	conn     *grpc.ClientConn
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{
+		listener: bufconn.Listen(1024 * 1024),
+		started:  make(chan struct{}, 10),
+		release:  make(chan struct{}),
+	}
+	ts.server = grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+		if method, _ := grpc.MethodFromServerStream(stream); method == "/test.Test/Block" {
+			ts.started <- struct{}{}
+			select {
+			case <-ts.release:
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			}
+		}
+		return stream.SendMsg(&emptypb.Empty{})
+	}))
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ts.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	ts.conn = conn
+	t.Cleanup(func() { conn.Close() })
+	return ts
+}
+
+func (ts *testServer) call(method string) error {
+	return ts.conn.Invoke(context.Background(), method, &emptypb.Empty{}, &emptypb.Empty{})
+}
+
+// serve runs Serve in the background and returns its result channel.
+func (ts *testServer) serve(ctx context.Context, cfg Config) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, ts.server, ts.listener, cfg) }()
+	return done
+}
+
+func TestServeDrainsThenWaitsForCalls(t *testing.T) {
+	ts := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var shutdownCalled atomic.Bool
+	done := ts.serve(ctx, Config{
+		Drain:      200 * time.Millisecond,
+		Timeout:    10 * time.Second,
+		OnShutdown: func() { shutdownCalled.Store(true) },
+	})
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- ts.call("/test.Test/Block") }()
+	<-ts.started
+
+	start := time.Now()
+	cancel()
+	require.Eventually(t, shutdownCalled.Load, time.Second, time.Millisecond)
+
+	// New calls are still served while draining
+	require.NoError(t, ts.call("/test.Test/Fast"))
+
+	// Once the drain period is over, shutdown waits for the call in flight
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("Serve returned while a call was in flight")
+	default:
+	}
+	close(ts.release)
+
+	require.NoError(t, <-blocked)
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestServeCutsOffCallsAfterTimeout(t *testing.T) {
+	ts := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := ts.serve(ctx, Config{Timeout: 100 * time.Millisecond})
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- ts.call("/test.Test/Block") }()
+	<-ts.started
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "a forced stop is still a shutdown")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not cut off the call in flight")
+	}
+	assert.Error(t, <-blocked)
+}
+
+func TestServeReturnsServerFailure(t *testing.T) {
+	ts := newTestServer(t)
+	require.NoError(t, ts.listener.Close())
+
+	err := Serve(context.Background(), ts.server, ts.listener, Config{})
+	assert.Error(t, err)
+}
+
+func TestJobs(t *testing.T) {
+	jobs := NewJobs()
+	var stopped atomic.Int32
+	for range 3 {
+		jobs.Go(func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			stopped.Add(1)
+		})
+	}
+
+	jobs.Stop()
+	assert.Equal(t, int32(3), stopped.Load())
+}
+
+func TestJobsEvery(t *testing.T) {
+	jobs := NewJobs()
+	var runs atomic.Int32
+	jobs.Every(time.Millisecond, func(ctx context.Context) { runs.Add(1) })
+
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	jobs.Stop()
+	after := runs.Load()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, after, runs.Load())
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SHUTDOWN_DRAIN", "")
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDrain, cfg.Drain)
+	assert.Equal(t, DefaultTimeout, cfg.Timeout)
+
+	// A drain of zero is allowed, a timeout of zero is not
+	t.Setenv("SHUTDOWN_DRAIN", "0s")
+	cfg, err = ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Drain)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "0s")
+	_, err = ConfigFromEnv()
+	assert.EqualError(t, err, `invalid SHUTDOWN_TIMEOUT: "0s"`)
+
+	t.Setenv("SHUTDOWN_DRAIN", "-1s")
+	_, err = ConfigFromEnv()
+	assert.EqualError(t, err, `invalid SHUTDOWN_DRAIN: "-1s"`)
+}
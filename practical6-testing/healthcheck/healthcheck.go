@@ -0,0 +1,168 @@
+// Package healthcheck serves the standard gRPC health protocol
+// (grpc.health.v1) for a cafe service. The service is SERVING while
+// periodic checks of what it depends on, such as its database, pass, and
+// turns NOT_SERVING for good once shutdown begins so that load balancers
+// and probes stop sending it new calls.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"authz"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+const (
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 2 * time.Second
+)
+
+// Check reports whether one dependency is usable.
+type Check func(ctx context.Context) error
+
+// Checker runs the checks of one service and publishes the result through
+// a health server.
+type Checker struct {
+	// Service is the name the status is published under, such as
+	// "user.v1.UserService". The status of the server as a whole, the empty
+	// name, follows it.
+	Service string
+	// Checks are run on every probe and must all pass for the service to
+	// be SERVING. The map key names the dependency in logs.
+	Checks map[string]Check
+	// Interval is how often the checks run.
+	Interval time.Duration
+	// Timeout bounds each check.
+	Timeout time.Duration
+
+	server *health.Server
+
+	mu       sync.Mutex
+	status   healthpb.HealthCheckResponse_ServingStatus
+	shutdown bool
+}
+
+// New returns a Checker for service that reports NOT_SERVING until the
+// first probe passes.
+func New(service string, checks map[string]Check) *Checker {
+	c := &Checker{
+		Service:  service,
+		Checks:   checks,
+		Interval: defaultInterval,
+		Timeout:  defaultTimeout,
+		server:   health.NewServer(),
+	}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Register serves the health protocol on s.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Run probes immediately and then every Interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.Probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Probe runs every check once, updates the published status and returns
+// the failures. Changes of status are logged.
+func (c *Checker) Probe(ctx context.Context) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(c.Checks)) {
+		checkCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+		if err := c.Checks[name](checkCtx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		cancel()
+	}
+	err := errors.Join(errs...)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shutdown {
+		return err
+	}
+	c.server.SetServingStatus("", status)
+	c.server.SetServingStatus(c.Service, status)
+	if status != c.status {
+		if err != nil {
+			log.Printf("Health: %s is %s: %v", c.Service, status, err)
+		} else {
+			log.Printf("Health: %s is %s", c.Service, status)
+		}
+		c.status = status
+	}
+	return err
+}
+
+// Shutdown reports NOT_SERVING from now on, whatever later probes find.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdown = true
+	c.server.Shutdown()
+	log.Printf("Health: %s is shutting down", c.Service)
+}
+
+// Remote returns a Check that passes while service, reached over conn,
+// reports SERVING through its own health server.
+func Remote(conn grpc.ClientConnInterface, service string) Check {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", service, resp.Status)
+		}
+		return nil
+	}
+}
+
+// AllowProbes returns a copy of policy that also lets anyone call the
+// health and reflection services, so probes and tools such as grpcurl work
+// without a token.
+func AllowProbes(policy authz.Policy) authz.Policy {
+	allowed := maps.Clone(policy)
+	for _, method := range []string{
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_List_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
+		reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+		reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	} {
+		allowed[method] = authz.Rule{Access: authz.Public}
+	}
+	return allowed
+}
@@ -0,0 +1,117 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"authz"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testService = "test.v1.TestService"
+
+// startServer serves checker behind an authorizer with an empty policy and
+// returns a connection to it.
+func startServer(t *testing.T, checker *Checker) *grpc.ClientConn {
+	t.Helper()
+
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	authorizer := authz.NewAuthorizer(keys, AllowProbes(authz.Policy{}))
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authorizer.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(authorizer.StreamServerInterceptor()),
+	)
+	checker.Register(server)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return conn
+}
+
+func checkStatus(t *testing.T, conn *grpc.ClientConn, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	var dbErr error
+	checker := New(testService, map[string]Check{
+		"database": func(ctx context.Context) error { return dbErr },
+	})
+	conn := startServer(t, checker)
+	ctx := context.Background()
+
+	// Nothing is known before the first probe
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, conn, testService))
+
+	require.NoError(t, checker.Probe(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, conn, testService))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, conn, ""))
+
+	dbErr = errors.New("connection refused")
+	err := checker.Probe(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database: connection refused")
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, conn, testService))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, conn, ""))
+
+	dbErr = nil
+	require.NoError(t, checker.Probe(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, conn, testService))
+
+	// Once shutting down, passing probes no longer bring it back
+	checker.Shutdown()
+	require.NoError(t, checker.Probe(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, conn, testService))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, conn, ""))
+}
+
+func TestRemote(t *testing.T) {
+	var depErr error
+	dependency := New(testService, map[string]Check{
+		"database": func(ctx context.Context) error { return depErr },
+	})
+	conn := startServer(t, dependency)
+	remote := Remote(conn, testService)
+	ctx := context.Background()
+
+	require.NoError(t, dependency.Probe(ctx))
+	assert.NoError(t, remote(ctx))
+
+	depErr = errors.New("disk full")
+	require.Error(t, dependency.Probe(ctx))
+	assert.EqualError(t, remote(ctx), testService+" is NOT_SERVING")
+
+	assert.Error(t, Remote(conn, "other.v1.OtherService")(ctx), "unknown services are not healthy")
+}
+
+func TestAllowProbesCopiesPolicy(t *testing.T) {
+	policy := authz.Policy{"/test.v1.TestService/Get": {Access: authz.Owner}}
+	allowed := AllowProbes(policy)
+
+	assert.Len(t, policy, 1)
+	assert.Equal(t, authz.Owner, allowed["/test.v1.TestService/Get"].Access)
+	assert.Equal(t, authz.Public, allowed[healthpb.Health_Check_FullMethodName].Access)
+}
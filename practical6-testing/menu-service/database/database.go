@@ -1,26 +0,0 @@
-package database
-
-import (
-	"log"
-	"menu-service/models"
-
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-)
-
-var DB *gorm.DB
-
-func Connect(dsn string) error {
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return err
-	}
-
-	log.Println("Database connected successfully")
-	return nil
-}
-
-func Migrate() error {
-	return DB.AutoMigrate(&models.MenuItem{})
-}
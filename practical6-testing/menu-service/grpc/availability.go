@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+)
+
+// fromProtoAvailability validates availability windows sent by a client.
+func fromProtoAvailability(windows []*menuv1.AvailabilityWindow) ([]models.AvailabilityWindow, error) {
+	var parsed []models.AvailabilityWindow
+	for i, window := range windows {
+		if window.Day < menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY || window.Day > menuv1.DayOfWeek_DAY_OF_WEEK_SUNDAY {
+			return nil, fmt.Errorf("availability window %d: day is required", i)
+		}
+		start, err := parseClock(window.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("availability window %d: start_time: %w", i, err)
+		}
+		end, err := parseClock(window.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("availability window %d: end_time: %w", i, err)
+		}
+		if start >= end {
+			return nil, fmt.Errorf("availability window %d: start_time must be before end_time, split windows that cross midnight", i)
+		}
+
+		parsed = append(parsed, models.AvailabilityWindow{
+			Weekday:     time.Weekday(window.Day % 7), // Sunday is 7 in the API and 0 in Go
+			StartMinute: start,
+			EndMinute:   end,
+		})
+	}
+	return parsed, nil
+}
+
+func toProtoAvailability(windows []models.AvailabilityWindow) []*menuv1.AvailabilityWindow {
+	var pbWindows []*menuv1.AvailabilityWindow
+	for _, window := range windows {
+		day := menuv1.DayOfWeek(window.Weekday)
+		if window.Weekday == time.Sunday {
+			day = menuv1.DayOfWeek_DAY_OF_WEEK_SUNDAY
+		}
+		pbWindows = append(pbWindows, &menuv1.AvailabilityWindow{
+			Day:       day,
+			StartTime: formatClock(window.StartMinute),
+			EndTime:   formatClock(window.EndMinute),
+		})
+	}
+	return pbWindows
+}
+
+// parseClock converts "HH:MM" into minutes since midnight. "24:00" is
+// accepted as the end of the day.
+func parseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return models.MinutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day in HH:MM form", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,246 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"dberr"
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"prototime"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTagLength caps the length of a tag name.
+const maxTagLength = 64
+
+func (s *MenuServer) CreateCategory(ctx context.Context, req *menuv1.CreateCategoryRequest) (*menuv1.CreateCategoryResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "category name is required")
+	}
+
+	category := models.Category{
+		Name:         name,
+		Description:  req.Description,
+		DisplayOrder: req.DisplayOrder,
+	}
+	if err := s.store.CreateCategory(ctx, &category); err != nil {
+		return nil, dberr.Status(err, "category")
+	}
+
+	return &menuv1.CreateCategoryResponse{Category: toProtoCategory(category)}, nil
+}
+
+func (s *MenuServer) GetCategory(ctx context.Context, req *menuv1.GetCategoryRequest) (*menuv1.GetCategoryResponse, error) {
+	category, err := s.store.GetCategory(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "category")
+	}
+
+	return &menuv1.GetCategoryResponse{Category: toProtoCategory(category)}, nil
+}
+
+func (s *MenuServer) GetCategories(ctx context.Context, req *menuv1.GetCategoriesRequest) (*menuv1.GetCategoriesResponse, error) {
+	categories, err := s.store.ListCategories(ctx)
+	if err != nil {
+		return nil, dberr.Status(err, "categories")
+	}
+
+	var pbCategories []*menuv1.Category
+	for _, category := range categories {
+		pbCategories = append(pbCategories, toProtoCategory(category))
+	}
+
+	return &menuv1.GetCategoriesResponse{Categories: pbCategories}, nil
+}
+
+func (s *MenuServer) UpdateCategory(ctx context.Context, req *menuv1.UpdateCategoryRequest) (*menuv1.UpdateCategoryResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "category name is required")
+	}
+
+	category, err := s.store.GetCategory(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "category")
+	}
+
+	category.Name = name
+	category.Description = req.Description
+	category.DisplayOrder = req.DisplayOrder
+
+	if err := s.store.UpdateCategory(ctx, &category); err != nil {
+		return nil, dberr.Status(err, "category")
+	}
+
+	return &menuv1.UpdateCategoryResponse{Category: toProtoCategory(category)}, nil
+}
+
+func (s *MenuServer) DeleteCategory(ctx context.Context, req *menuv1.DeleteCategoryRequest) (*menuv1.DeleteCategoryResponse, error) {
+	deleted, err := s.store.DeleteCategory(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "category")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "category not found")
+	}
+
+	return &menuv1.DeleteCategoryResponse{Success: true}, nil
+}
+
+func (s *MenuServer) CreateTag(ctx context.Context, req *menuv1.CreateTagRequest) (*menuv1.CreateTagResponse, error) {
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTagNameFree(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	tag := models.Tag{Name: name}
+	if err := s.store.CreateTag(ctx, &tag); err != nil {
+		return nil, dberr.Status(err, "tag")
+	}
+
+	return &menuv1.CreateTagResponse{Tag: toProtoTag(tag)}, nil
+}
+
+func (s *MenuServer) GetTags(ctx context.Context, req *menuv1.GetTagsRequest) (*menuv1.GetTagsResponse, error) {
+	tags, err := s.store.ListTags(ctx)
+	if err != nil {
+		return nil, dberr.Status(err, "tags")
+	}
+
+	var pbTags []*menuv1.Tag
+	for _, tag := range tags {
+		pbTags = append(pbTags, toProtoTag(tag))
+	}
+
+	return &menuv1.GetTagsResponse{Tags: pbTags}, nil
+}
+
+func (s *MenuServer) UpdateTag(ctx context.Context, req *menuv1.UpdateTagRequest) (*menuv1.UpdateTagResponse, error) {
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.store.GetTag(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "tag")
+	}
+	if err := s.checkTagNameFree(ctx, name, tag.ID); err != nil {
+		return nil, err
+	}
+
+	tag.Name = name
+	if err := s.store.UpdateTag(ctx, &tag); err != nil {
+		return nil, dberr.Status(err, "tag")
+	}
+
+	return &menuv1.UpdateTagResponse{Tag: toProtoTag(tag)}, nil
+}
+
+func (s *MenuServer) DeleteTag(ctx context.Context, req *menuv1.DeleteTagRequest) (*menuv1.DeleteTagResponse, error) {
+	deleted, err := s.store.DeleteTag(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "tag")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "tag not found")
+	}
+
+	return &menuv1.DeleteTagResponse{Success: true}, nil
+}
+
+// resolveTags finds the tags with the given names, creating any that do
+// not exist yet.
+func resolveTags(ctx context.Context, tx store.MenuStore, names []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	seen := make(map[string]bool)
+	for _, raw := range names {
+		name, err := normalizeTagName(raw)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		tag, err := tx.FindTagByName(ctx, name)
+		if errors.Is(err, store.ErrNotFound) {
+			tag = models.Tag{Name: name}
+			err = tx.CreateTag(ctx, &tag)
+		}
+		if err != nil {
+			return nil, dberr.Status(err, "tag")
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func normalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", status.Errorf(codes.InvalidArgument, "tag name is required")
+	}
+	if len(name) > maxTagLength {
+		return "", status.Errorf(codes.InvalidArgument, "tag name must be at most %d characters", maxTagLength)
+	}
+	return name, nil
+}
+
+// checkTagNameFree reports AlreadyExists when a tag other than id uses name.
+func (s *MenuServer) checkTagNameFree(ctx context.Context, name string, id uint) error {
+	tag, err := s.store.FindTagByName(ctx, name)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return dberr.Status(err, "tag")
+	}
+	if tag.ID != id {
+		return status.Errorf(codes.AlreadyExists, "tag %q already exists", name)
+	}
+	return nil
+}
+
+// checkCategory reports InvalidArgument when a non-zero category ID does not
+// exist, and returns the ID to store on a menu item.
+func (s *MenuServer) checkCategory(ctx context.Context, id uint32) (*uint, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	if _, err := s.store.GetCategory(ctx, uint(id)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.InvalidArgument, "category %d not found", id)
+		}
+		return nil, dberr.Status(err, "category")
+	}
+	categoryID := uint(id)
+	return &categoryID, nil
+}
+
+func toProtoCategory(category models.Category) *menuv1.Category {
+	return &menuv1.Category{
+		Id:           uint32(category.ID),
+		Name:         category.Name,
+		Description:  category.Description,
+		DisplayOrder: category.DisplayOrder,
+		CreatedAt:    prototime.Legacy(category.CreatedAt),
+		UpdatedAt:    prototime.Legacy(category.UpdatedAt),
+		CreateTime:   prototime.Timestamp(category.CreatedAt),
+		UpdateTime:   prototime.Timestamp(category.UpdatedAt),
+	}
+}
+
+func toProtoTag(tag models.Tag) *menuv1.Tag {
+	return &menuv1.Tag{Id: uint32(tag.ID), Name: tag.Name}
+}
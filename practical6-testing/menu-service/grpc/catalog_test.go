@@ -0,0 +1,244 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCategories(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	breakfast, err := server.CreateCategory(ctx, &menuv1.CreateCategoryRequest{Name: "Breakfast", DisplayOrder: 2})
+	require.NoError(t, err)
+	drinks, err := server.CreateCategory(ctx, &menuv1.CreateCategoryRequest{Name: "Drinks", DisplayOrder: 1})
+	require.NoError(t, err)
+
+	_, err = server.CreateCategory(ctx, &menuv1.CreateCategoryRequest{Name: "  "})
+	requireCode(t, err, codes.InvalidArgument)
+
+	t.Run("listed in display order", func(t *testing.T) {
+		resp, err := server.GetCategories(ctx, &menuv1.GetCategoriesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Categories, 2)
+		assert.Equal(t, "Drinks", resp.Categories[0].Name)
+		assert.Equal(t, "Breakfast", resp.Categories[1].Name)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := server.UpdateCategory(ctx, &menuv1.UpdateCategoryRequest{
+			Id:           breakfast.Category.Id,
+			Name:         "All-day breakfast",
+			DisplayOrder: 0,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "All-day breakfast", resp.Category.Name)
+
+		got, err := server.GetCategory(ctx, &menuv1.GetCategoryRequest{Id: breakfast.Category.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), got.Category.DisplayOrder)
+
+		_, err = server.UpdateCategory(ctx, &menuv1.UpdateCategoryRequest{Id: 9999, Name: "Lunch"})
+		requireCode(t, err, codes.NotFound)
+	})
+
+	t.Run("items belong to a category", func(t *testing.T) {
+		item, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+			Name:       "Latte",
+			PriceCents: 350,
+			CategoryId: drinks.Category.Id,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, drinks.Category.Id, item.MenuItem.CategoryId)
+
+		_, err = server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Toast", CategoryId: 9999})
+		requireCode(t, err, codes.InvalidArgument)
+
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{CategoryId: drinks.Category.Id})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 1)
+		assert.Equal(t, "Latte", resp.MenuItems[0].Name)
+
+		// Deleting the category leaves its items uncategorised
+		_, err = server.DeleteCategory(ctx, &menuv1.DeleteCategoryRequest{Id: drinks.Category.Id})
+		require.NoError(t, err)
+		got, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItem.Id})
+		require.NoError(t, err)
+		assert.Zero(t, got.MenuItem.CategoryId)
+
+		_, err = server.DeleteCategory(ctx, &menuv1.DeleteCategoryRequest{Id: drinks.Category.Id})
+		requireCode(t, err, codes.NotFound)
+	})
+}
+
+func TestTags(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	vegan, err := server.CreateTag(ctx, &menuv1.CreateTagRequest{Name: " Vegan "})
+	require.NoError(t, err)
+	assert.Equal(t, "vegan", vegan.Tag.Name)
+
+	_, err = server.CreateTag(ctx, &menuv1.CreateTagRequest{Name: "VEGAN"})
+	requireCode(t, err, codes.AlreadyExists)
+
+	// Unknown tags are created when an item uses them
+	item, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name: "Oat Latte",
+		Tags: []string{"vegan", "Hot", "hot"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hot", "vegan"}, item.MenuItem.Tags)
+	_, err = server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Iced Tea", Tags: []string{"cold"}})
+	require.NoError(t, err)
+
+	tags, err := server.GetTags(ctx, &menuv1.GetTagsRequest{})
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 3)
+	assert.Equal(t, "cold", tags.Tags[0].Name)
+
+	t.Run("filter items by tag", func(t *testing.T) {
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{Tag: "Vegan"})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 1)
+		assert.Equal(t, "Oat Latte", resp.MenuItems[0].Name)
+	})
+
+	t.Run("update replaces item tags", func(t *testing.T) {
+		resp, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:   item.MenuItem.Id,
+			Name: "Oat Latte",
+			Tags: []string{"dairy-free"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dairy-free"}, resp.MenuItem.Tags)
+	})
+
+	t.Run("rename and delete", func(t *testing.T) {
+		renamed, err := server.UpdateTag(ctx, &menuv1.UpdateTagRequest{Id: vegan.Tag.Id, Name: "plant-based"})
+		require.NoError(t, err)
+		assert.Equal(t, "plant-based", renamed.Tag.Name)
+
+		_, err = server.UpdateTag(ctx, &menuv1.UpdateTagRequest{Id: vegan.Tag.Id, Name: "cold"})
+		requireCode(t, err, codes.AlreadyExists)
+
+		_, err = server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:   item.MenuItem.Id,
+			Name: "Oat Latte",
+			Tags: []string{"plant-based"},
+		})
+		require.NoError(t, err)
+
+		_, err = server.DeleteTag(ctx, &menuv1.DeleteTagRequest{Id: vegan.Tag.Id})
+		require.NoError(t, err)
+		got, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItem.Id})
+		require.NoError(t, err)
+		assert.Empty(t, got.MenuItem.Tags)
+
+		_, err = server.DeleteTag(ctx, &menuv1.DeleteTagRequest{Id: vegan.Tag.Id})
+		requireCode(t, err, codes.NotFound)
+	})
+}
+
+func TestAvailabilityWindows(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	server.Location = time.UTC
+	ctx := context.Background()
+
+	// Wednesday 2024-01-03 at 09:30
+	now := time.Date(2024, time.January, 3, 9, 30, 0, 0, time.UTC)
+	server.clock = func() time.Time { return now }
+
+	breakfast, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name: "Pancakes",
+		Availability: []*menuv1.AvailabilityWindow{
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_WEDNESDAY, StartTime: "07:00", EndTime: "11:00"},
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_SUNDAY, StartTime: "08:00", EndTime: "12:00"},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, breakfast.MenuItem.Unavailable)
+	require.Len(t, breakfast.MenuItem.Availability, 2)
+	assert.Equal(t, menuv1.DayOfWeek_DAY_OF_WEEK_SUNDAY, breakfast.MenuItem.Availability[0].Day)
+	assert.Equal(t, "08:00", breakfast.MenuItem.Availability[0].StartTime)
+
+	_, err = server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Coffee"})
+	require.NoError(t, err)
+
+	t.Run("invalid windows", func(t *testing.T) {
+		invalid := []*menuv1.AvailabilityWindow{
+			{StartTime: "07:00", EndTime: "11:00"},
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY, StartTime: "7am", EndTime: "11:00"},
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY, StartTime: "22:00", EndTime: "02:00"},
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY, StartTime: "22:00", EndTime: "24:30"},
+		}
+		for _, window := range invalid {
+			_, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+				Name:         "Late snack",
+				Availability: []*menuv1.AvailabilityWindow{window},
+			})
+			requireCode(t, err, codes.InvalidArgument)
+		}
+	})
+
+	t.Run("inside a window", func(t *testing.T) {
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{AvailableNow: true})
+		require.NoError(t, err)
+		assert.Len(t, resp.MenuItems, 2)
+	})
+
+	t.Run("end of a window is exclusive", func(t *testing.T) {
+		now = time.Date(2024, time.January, 3, 11, 0, 0, 0, time.UTC)
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{AvailableNow: true})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 1)
+		assert.Equal(t, "Coffee", resp.MenuItems[0].Name)
+
+		batch, err := server.BatchGetMenuItems(ctx, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{breakfast.MenuItem.Id}})
+		require.NoError(t, err)
+		assert.True(t, batch.MenuItems[0].Unavailable)
+	})
+
+	t.Run("windows use the cafe's time zone", func(t *testing.T) {
+		// 15:30 UTC on Sunday is 08:30 in Denver
+		denver, err := time.LoadLocation("America/Denver")
+		require.NoError(t, err)
+		server.Location = denver
+		now = time.Date(2024, time.January, 7, 15, 30, 0, 0, time.UTC)
+
+		resp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: breakfast.MenuItem.Id})
+		require.NoError(t, err)
+		assert.False(t, resp.MenuItem.Unavailable)
+	})
+
+	t.Run("update replaces windows", func(t *testing.T) {
+		resp, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:   breakfast.MenuItem.Id,
+			Name: "Pancakes",
+			Availability: []*menuv1.AvailabilityWindow{
+				{Day: menuv1.DayOfWeek_DAY_OF_WEEK_SATURDAY, StartTime: "00:00", EndTime: "24:00"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItem.Availability, 1)
+		assert.Equal(t, "24:00", resp.MenuItem.Availability[0].EndTime)
+		assert.True(t, resp.MenuItem.Unavailable)
+	})
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+	"prototime"
+	"softdelete"
+)
+
+// menuItemEventTypes maps the outbox event types onto their proto enum.
+var menuItemEventTypes = map[string]menuv1.MenuItemEventType{
+	models.EventMenuItemDeleted:  menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED,
+	models.EventMenuItemRestored: menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_RESTORED,
+	models.EventMenuItemPurged:   menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_PURGED,
+}
+
+// ListMenuItemEvents returns the outbox events after req.AfterSequence in
+// sequence order.
+func (s *MenuServer) ListMenuItemEvents(ctx context.Context, req *menuv1.ListMenuItemEventsRequest) (*menuv1.ListMenuItemEventsResponse, error) {
+	events, err := softdelete.ListEvents(ctx, req, "menu item events", s.store.ListEvents, toProtoMenuItemEvent)
+	if err != nil {
+		return nil, err
+	}
+	return &menuv1.ListMenuItemEventsResponse{Events: events}, nil
+}
+
+func toProtoMenuItemEvent(event models.OutboxEvent) *menuv1.MenuItemEvent {
+	return &menuv1.MenuItemEvent{
+		Sequence:   event.Sequence,
+		Type:       menuItemEventTypes[event.Type],
+		MenuItemId: uint32(event.MenuItemID),
+		OccurredAt: prototime.Legacy(event.CreatedAt),
+		EventTime:  prototime.Timestamp(event.CreatedAt),
+	}
+}
@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMenuItemEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	latte, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Latte", PriceCents: 350})
+	require.NoError(t, err)
+	id := latte.MenuItem.Id
+
+	_, err = server.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.RestoreMenuItem(ctx, &menuv1.RestoreMenuItemRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.store.PurgeDeletedMenuItems(ctx, time.Now())
+	require.NoError(t, err)
+
+	resp, err := server.ListMenuItemEvents(ctx, &menuv1.ListMenuItemEventsRequest{})
+	require.NoError(t, err)
+	var types []menuv1.MenuItemEventType
+	for _, event := range resp.Events {
+		assert.Equal(t, id, event.MenuItemId)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []menuv1.MenuItemEventType{
+		menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED,
+		menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_RESTORED,
+		menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED,
+		menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_PURGED,
+	}, types)
+
+	next, err := server.ListMenuItemEvents(ctx, &menuv1.ListMenuItemEventsRequest{AfterSequence: resp.Events[1].Sequence, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, next.Events, 1)
+	assert.Equal(t, resp.Events[2].Sequence, next.Events[0].Sequence)
+}
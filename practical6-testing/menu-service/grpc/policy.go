@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"authz"
+	menuv1 "menu-service/proto/menuv1"
+)
+
+// Policy is the authorization policy of the menu service. Anyone may browse
+// the menu and only cafe owners may change it. Stock is held only by
+// order-service, which reserves and releases it for the orders it places or
+// cancels, and the event feed is only for other cafe services.
+var Policy = authz.Policy{
+	menuv1.MenuService_GetMenuItem_FullMethodName:       {Access: authz.Public},
+	menuv1.MenuService_GetMenuItems_FullMethodName:      {Access: authz.Public},
+	menuv1.MenuService_BatchGetMenuItems_FullMethodName: {Access: authz.Public},
+	menuv1.MenuService_GetCategory_FullMethodName:       {Access: authz.Public},
+	menuv1.MenuService_GetCategories_FullMethodName:     {Access: authz.Public},
+	menuv1.MenuService_GetTags_FullMethodName:           {Access: authz.Public},
+
+	menuv1.MenuService_CreateMenuItem_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_UpdateMenuItem_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_DeleteMenuItem_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_ListDeletedMenuItems_FullMethodName: {Access: authz.Owner},
+	menuv1.MenuService_RestoreMenuItem_FullMethodName:      {Access: authz.Owner},
+	menuv1.MenuService_PurgeMenuItem_FullMethodName:        {Access: authz.Owner},
+	menuv1.MenuService_AdjustStock_FullMethodName:          {Access: authz.Owner},
+	menuv1.MenuService_CreateCategory_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_UpdateCategory_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_DeleteCategory_FullMethodName:       {Access: authz.Owner},
+	menuv1.MenuService_CreateTag_FullMethodName:            {Access: authz.Owner},
+	menuv1.MenuService_UpdateTag_FullMethodName:            {Access: authz.Owner},
+	menuv1.MenuService_DeleteTag_FullMethodName:            {Access: authz.Owner},
+
+	menuv1.MenuService_ReserveStock_FullMethodName:       {Access: authz.Service},
+	menuv1.MenuService_ConfirmStock_FullMethodName:       {Access: authz.Service},
+	menuv1.MenuService_ReleaseStock_FullMethodName:       {Access: authz.Service},
+	menuv1.MenuService_ListMenuItemEvents_FullMethodName: {Access: authz.Service},
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"authz"
+	"context"
+	"testing"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy(t *testing.T) {
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	interceptor := authz.NewAuthorizer(keys, Policy).UnaryServerInterceptor()
+
+	tokenFor := func(userID uint, owner bool) string {
+		token, err := authz.IssueAccessToken(keys, userID, owner, time.Now(), time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+	ownerToken, customerToken := tokenFor(1, true), tokenFor(2, false)
+	serviceToken, err := authz.IssueServiceToken(keys, "order-service", time.Now(), time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		want   codes.Code
+	}{
+		{"anonymous browses menu", "", menuv1.MenuService_GetMenuItems_FullMethodName, codes.OK},
+		{"anonymous lists categories", "", menuv1.MenuService_GetCategories_FullMethodName, codes.OK},
+		{"customer creates item", customerToken, menuv1.MenuService_CreateMenuItem_FullMethodName, codes.PermissionDenied},
+		{"customer deletes item", customerToken, menuv1.MenuService_DeleteMenuItem_FullMethodName, codes.PermissionDenied},
+		{"owner deletes item", ownerToken, menuv1.MenuService_DeleteMenuItem_FullMethodName, codes.OK},
+		{"anonymous adjusts stock", "", menuv1.MenuService_AdjustStock_FullMethodName, codes.Unauthenticated},
+		{"service reserves stock", serviceToken, menuv1.MenuService_ReserveStock_FullMethodName, codes.OK},
+		{"customer reserves stock", customerToken, menuv1.MenuService_ReserveStock_FullMethodName, codes.PermissionDenied},
+		{"owner releases stock", ownerToken, menuv1.MenuService_ReleaseStock_FullMethodName, codes.PermissionDenied},
+		{"anonymous reserves stock", "", menuv1.MenuService_ReserveStock_FullMethodName, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+			handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
+
+func TestPolicy_CoversEveryMethod(t *testing.T) {
+	for _, method := range menuv1.MenuService_ServiceDesc.Methods {
+		fullMethod := "/" + menuv1.MenuService_ServiceDesc.ServiceName + "/" + method.MethodName
+		assert.Contains(t, Policy, fullMethod)
+	}
+}
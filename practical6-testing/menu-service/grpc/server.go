@@ -2,119 +2,310 @@ package grpc
 
 import (
 	"context"
-	"menu-service/database"
+	"database/sql"
+	"dberr"
+	"fieldmask"
 	"menu-service/models"
 	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"pagination"
+	"prototime"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxBatchSize caps how many menu items BatchGetMenuItems returns at once.
+const maxBatchSize = 100
+
 type MenuServer struct {
 	menuv1.UnimplementedMenuServiceServer
+	store store.MenuStore
+	// Location is the cafe's time zone, used for availability windows.
+	Location *time.Location
+	// ReservationTTL is how long stock reservations are held unless they
+	// are confirmed.
+	ReservationTTL time.Duration
+
+	// clock replaces time.Now in tests.
+	clock func() time.Time
 }
 
-func NewMenuServer() *MenuServer {
-	return &MenuServer{}
+// updatableMenuItemFields are the UpdateMenuItemRequest fields an
+// update_mask may list. price and price_cents both set the price.
+var updatableMenuItemFields = []string{"name", "description", "price", "price_cents", "currency", "category_id", "tags", "availability"}
+
+func NewMenuServer(store store.MenuStore) *MenuServer {
+	return &MenuServer{Location: time.Local, ReservationTTL: DefaultReservationTTL, store: store}
+}
+
+// now returns the current time in the cafe's time zone.
+func (s *MenuServer) now() time.Time {
+	now := time.Now()
+	if s.clock != nil {
+		now = s.clock()
+	}
+	return now.In(s.Location)
 }
 
 func (s *MenuServer) CreateMenuItem(ctx context.Context, req *menuv1.CreateMenuItemRequest) (*menuv1.CreateMenuItemResponse, error) {
+	if req.Stock != nil && *req.Stock < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "stock must not be negative")
+	}
+	availability, err := fromProtoAvailability(req.Availability)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	categoryID, err := s.checkCategory(ctx, req.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
 	menuItem := models.MenuItem{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
+		Name:         req.Name,
+		Description:  req.Description,
+		PriceCents:   priceCents(req.PriceCents, req.Price),
+		Currency:     currencyOrDefault(req.Currency, models.DefaultCurrency),
+		Stock:        req.Stock,
+		CategoryID:   categoryID,
+		Availability: availability,
 	}
 
-	if err := database.DB.Create(&menuItem).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create menu item: %v", err)
+	err = s.store.Transaction(ctx, func(tx store.MenuStore) error {
+		if menuItem.Tags, err = resolveTags(ctx, tx, req.Tags); err != nil {
+			return err
+		}
+		return tx.CreateMenuItem(ctx, &menuItem)
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
 	}
 
 	return &menuv1.CreateMenuItemResponse{
-		MenuItem: &menuv1.MenuItem{
-			Id:          uint32(menuItem.ID),
-			Name:        menuItem.Name,
-			Description: menuItem.Description,
-			Price:       menuItem.Price,
-			CreatedAt:   menuItem.CreatedAt.String(),
-			UpdatedAt:   menuItem.UpdatedAt.String(),
-		},
+		MenuItem: toProtoMenuItem(menuItem, s.now()),
 	}, nil
 }
 
 func (s *MenuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
-	var menuItem models.MenuItem
-	if err := database.DB.First(&menuItem, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "menu item not found")
+	menuItem, err := s.store.GetMenuItem(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
 	}
 
 	return &menuv1.GetMenuItemResponse{
-		MenuItem: &menuv1.MenuItem{
-			Id:          uint32(menuItem.ID),
-			Name:        menuItem.Name,
-			Description: menuItem.Description,
-			Price:       menuItem.Price,
-			CreatedAt:   menuItem.CreatedAt.String(),
-			UpdatedAt:   menuItem.UpdatedAt.String(),
-		},
+		MenuItem: toProtoMenuItem(menuItem, s.now()),
 	}, nil
 }
 
 func (s *MenuServer) GetMenuItems(ctx context.Context, req *menuv1.GetMenuItemsRequest) (*menuv1.GetMenuItemsResponse, error) {
-	var menuItems []models.MenuItem
-	if err := database.DB.Find(&menuItems).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get menu items: %v", err)
+	filter := store.MenuItemFilter{
+		MinPriceCents: req.MinPriceCents,
+		MaxPriceCents: req.MaxPriceCents,
+		NameContains:  req.NameContains,
+		CategoryID:    uint(req.CategoryId),
+		Tag:           strings.ToLower(strings.TrimSpace(req.Tag)),
+	}
+	now := s.now()
+	if req.AvailableNow {
+		filter.AvailableAt = &now
+	}
+
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
 	}
 
+	menuItems, err := s.store.ListMenuItems(ctx, filter, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "menu items")
+	}
+	menuItems, nextToken := pagination.Trim(page, menuItems, func(m models.MenuItem) uint { return m.ID })
+
 	var pbMenuItems []*menuv1.MenuItem
 	for _, item := range menuItems {
-		pbMenuItems = append(pbMenuItems, &menuv1.MenuItem{
-			Id:          uint32(item.ID),
-			Name:        item.Name,
-			Description: item.Description,
-			Price:       item.Price,
-			CreatedAt:   item.CreatedAt.String(),
-			UpdatedAt:   item.UpdatedAt.String(),
-		})
+		pbMenuItems = append(pbMenuItems, toProtoMenuItem(item, now))
+	}
+
+	return &menuv1.GetMenuItemsResponse{MenuItems: pbMenuItems, NextPageToken: nextToken}, nil
+}
+
+func (s *MenuServer) BatchGetMenuItems(ctx context.Context, req *menuv1.BatchGetMenuItemsRequest) (*menuv1.BatchGetMenuItemsResponse, error) {
+	var ids []uint32
+	var storeIDs []uint
+	seen := make(map[uint32]bool)
+	for _, id := range req.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+			storeIDs = append(storeIDs, uint(id))
+		}
+	}
+
+	if len(ids) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids may be requested at once", maxBatchSize)
+	}
+	if len(ids) == 0 {
+		return &menuv1.BatchGetMenuItemsResponse{}, nil
+	}
+
+	menuItems, err := s.store.GetMenuItems(ctx, storeIDs)
+	if err != nil {
+		return nil, dberr.Status(err, "menu items")
+	}
+
+	found := make(map[uint32]models.MenuItem, len(menuItems))
+	for _, item := range menuItems {
+		found[uint32(item.ID)] = item
+	}
+
+	now := s.now()
+	resp := &menuv1.BatchGetMenuItemsResponse{}
+	for _, id := range ids {
+		item, ok := found[id]
+		if !ok {
+			resp.MissingIds = append(resp.MissingIds, id)
+			continue
+		}
+		resp.MenuItems = append(resp.MenuItems, toProtoMenuItem(item, now))
 	}
 
-	return &menuv1.GetMenuItemsResponse{MenuItems: pbMenuItems}, nil
+	return resp, nil
 }
 
 func (s *MenuServer) UpdateMenuItem(ctx context.Context, req *menuv1.UpdateMenuItemRequest) (*menuv1.UpdateMenuItemResponse, error) {
-	var menuItem models.MenuItem
-	if err := database.DB.First(&menuItem, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "menu item not found")
+	fields, err := fieldmask.UpdatePaths(req.UpdateMask, updatableMenuItemFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var availability []models.AvailabilityWindow
+	if fields["availability"] {
+		if availability, err = fromProtoAvailability(req.Availability); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	menuItem, err := s.store.GetMenuItem(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
+	}
+
+	if fields["name"] {
+		menuItem.Name = req.Name
+	}
+	if fields["description"] {
+		menuItem.Description = req.Description
+	}
+	if fields["price"] || fields["price_cents"] {
+		menuItem.PriceCents = priceCents(req.PriceCents, req.Price)
+	}
+	if fields["currency"] {
+		menuItem.Currency = currencyOrDefault(req.Currency, menuItem.Currency)
+	}
+	if fields["category_id"] {
+		if menuItem.CategoryID, err = s.checkCategory(ctx, req.CategoryId); err != nil {
+			return nil, err
+		}
 	}
 
-	menuItem.Name = req.Name
-	menuItem.Description = req.Description
-	menuItem.Price = req.Price
+	err = s.store.Transaction(ctx, func(tx store.MenuStore) error {
+		if err := tx.UpdateMenuItem(ctx, &menuItem); err != nil {
+			return err
+		}
 
-	if err := database.DB.Save(&menuItem).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update menu item: %v", err)
+		if fields["tags"] {
+			tags, err := resolveTags(ctx, tx, req.Tags)
+			if err != nil {
+				return err
+			}
+			if err := tx.SetMenuItemTags(ctx, menuItem.ID, tags); err != nil {
+				return err
+			}
+		}
+
+		if fields["availability"] {
+			// Windows are replaced wholesale
+			if err := tx.SetAvailability(ctx, menuItem.ID, availability); err != nil {
+				return err
+			}
+		}
+		menuItem, err = tx.GetMenuItem(ctx, menuItem.ID)
+		return err
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
 	}
 
 	return &menuv1.UpdateMenuItemResponse{
-		MenuItem: &menuv1.MenuItem{
-			Id:          uint32(menuItem.ID),
-			Name:        menuItem.Name,
-			Description: menuItem.Description,
-			Price:       menuItem.Price,
-			CreatedAt:   menuItem.CreatedAt.String(),
-			UpdatedAt:   menuItem.UpdatedAt.String(),
-		},
+		MenuItem: toProtoMenuItem(menuItem, s.now()),
 	}, nil
 }
 
 func (s *MenuServer) DeleteMenuItem(ctx context.Context, req *menuv1.DeleteMenuItemRequest) (*menuv1.DeleteMenuItemResponse, error) {
-	result := database.DB.Delete(&models.MenuItem{}, req.Id)
-	if result.Error != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete menu item: %v", result.Error)
+	err := s.store.Transaction(ctx, func(tx store.MenuStore) error {
+		deleted, err := tx.DeleteMenuItem(ctx, uint(req.Id))
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return status.Errorf(codes.NotFound, "menu item not found")
+		}
+		return tx.RecordEvent(ctx, models.EventMenuItemDeleted, uint(req.Id))
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
 	}
 
-	if result.RowsAffected == 0 {
-		return nil, status.Errorf(codes.NotFound, "menu item not found")
+	return &menuv1.DeleteMenuItemResponse{Success: true}, nil
+}
+
+// toProtoMenuItem converts a menu item loaded from the store. now, in the
+// cafe's time zone, decides whether the item is currently available.
+func toProtoMenuItem(item models.MenuItem, now time.Time) *menuv1.MenuItem {
+	pbItem := &menuv1.MenuItem{
+		Id:           uint32(item.ID),
+		Name:         item.Name,
+		Description:  item.Description,
+		Price:        models.FloatFromCents(item.PriceCents),
+		PriceCents:   item.PriceCents,
+		Currency:     item.Currency,
+		Stock:        item.Stock,
+		SoldOut:      item.SoldOut(),
+		Availability: toProtoAvailability(item.Availability),
+		Unavailable:  !item.AvailableAt(now),
+		CreatedAt:    prototime.Legacy(item.CreatedAt),
+		UpdatedAt:    prototime.Legacy(item.UpdatedAt),
+		CreateTime:   prototime.Timestamp(item.CreatedAt),
+		UpdateTime:   prototime.Timestamp(item.UpdatedAt),
+		DeleteTime:   prototime.Null(sql.NullTime(item.DeletedAt)),
+	}
+	if item.CategoryID != nil {
+		pbItem.CategoryId = uint32(*item.CategoryID)
+	}
+	for _, tag := range item.Tags {
+		pbItem.Tags = append(pbItem.Tags, tag.Name)
+	}
+	if item.DeletedAt.Valid {
+		pbItem.DeletedAt = prototime.Legacy(item.DeletedAt.Time)
 	}
+	return pbItem
+}
 
-	return &menuv1.DeleteMenuItemResponse{Success: true}, nil
+// priceCents prefers the integer price and falls back to the deprecated
+// floating point price sent by older clients.
+func priceCents(cents int64, legacy float64) int64 {
+	if cents == 0 && legacy != 0 {
+		return models.CentsFromFloat(legacy)
+	}
+	return cents
+}
+
+func currencyOrDefault(currency, fallback string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return fallback
+	}
+	return currency
 }
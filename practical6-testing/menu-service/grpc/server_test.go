@@ -2,15 +2,16 @@ package grpc
 
 import (
 	"context"
-	"testing"
-	"menu-service/database"
 	"menu-service/models"
 	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -19,7 +20,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&models.MenuItem{})
+	err = store.Migrate(db)
 	require.NoError(t, err)
 
 	return db
@@ -34,9 +35,8 @@ func teardownTestDB(t *testing.T, db *gorm.DB) {
 func TestCreateMenuItem(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewMenuServer()
+	server := NewMenuServer(store.NewGormStore(db))
 
 	tests := []struct {
 		name    string
@@ -82,9 +82,8 @@ func TestCreateMenuItem(t *testing.T) {
 func TestGetMenuItem(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewMenuServer()
+	server := NewMenuServer(store.NewGormStore(db))
 	ctx := context.Background()
 
 	// Create a test menu item
@@ -134,9 +133,8 @@ func TestGetMenuItem(t *testing.T) {
 func TestGetMenuItems(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewMenuServer()
+	server := NewMenuServer(store.NewGormStore(db))
 	ctx := context.Background()
 
 	// Create multiple menu items
@@ -166,9 +164,8 @@ func TestGetMenuItems(t *testing.T) {
 func TestPriceHandling(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewMenuServer()
+	server := NewMenuServer(store.NewGormStore(db))
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -192,3 +189,211 @@ func TestPriceHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestMoneyRepresentation(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	testCases := []struct {
+		name             string
+		request          *menuv1.CreateMenuItemRequest
+		expectedCents    int64
+		expectedCurrency string
+	}{
+		{
+			name:             "integer cents with currency",
+			request:          &menuv1.CreateMenuItemRequest{Name: "Latte", PriceCents: 350, Currency: "eur"},
+			expectedCents:    350,
+			expectedCurrency: "EUR",
+		},
+		{
+			name:             "legacy float price",
+			request:          &menuv1.CreateMenuItemRequest{Name: "Muffin", Price: 0.29},
+			expectedCents:    29,
+			expectedCurrency: models.DefaultCurrency,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			createResp, err := server.CreateMenuItem(ctx, tc.request)
+			require.NoError(t, err)
+
+			resp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: createResp.MenuItem.Id})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCents, resp.MenuItem.PriceCents)
+			assert.Equal(t, tc.expectedCurrency, resp.MenuItem.Currency)
+		})
+	}
+}
+
+func TestGetMenuItems_PaginationAndFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	items := []struct {
+		name       string
+		priceCents int64
+	}{
+		{"Flat White", 350},
+		{"Iced Coffee", 400},
+		{"Tea", 200},
+		{"Coffee Cake", 450},
+		{"Water", 0},
+	}
+
+	for _, item := range items {
+		_, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+			Name:       item.name,
+			PriceCents: item.priceCents,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("pages cover every item once", func(t *testing.T) {
+		first, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{PageSize: 3})
+		require.NoError(t, err)
+		require.Len(t, first.MenuItems, 3)
+		require.NotEmpty(t, first.NextPageToken)
+
+		second, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{PageSize: 3, PageToken: first.NextPageToken})
+		require.NoError(t, err)
+		assert.Len(t, second.MenuItems, 2)
+		assert.Empty(t, second.NextPageToken)
+		assert.Greater(t, second.MenuItems[0].Id, first.MenuItems[2].Id)
+	})
+
+	t.Run("filter by price range", func(t *testing.T) {
+		minPrice, maxPrice := int64(300), int64(400)
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{
+			MinPriceCents: &minPrice,
+			MaxPriceCents: &maxPrice,
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.MenuItems, 2)
+	})
+
+	t.Run("free items with zero max price", func(t *testing.T) {
+		maxPrice := int64(0)
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{MaxPriceCents: &maxPrice})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 1)
+		assert.Equal(t, "Water", resp.MenuItems[0].Name)
+	})
+
+	t.Run("filter by name substring", func(t *testing.T) {
+		resp, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{NameContains: "coffee"})
+		require.NoError(t, err)
+		assert.Len(t, resp.MenuItems, 2)
+	})
+
+	t.Run("negative page size", func(t *testing.T) {
+		_, err := server.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{PageSize: -1})
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestBatchGetMenuItems(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	var ids []uint32
+	for _, name := range []string{"Coffee", "Tea", "Muffin"} {
+		resp, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: name, PriceCents: 200})
+		require.NoError(t, err)
+		ids = append(ids, resp.MenuItem.Id)
+	}
+
+	t.Run("found and missing items", func(t *testing.T) {
+		resp, err := server.BatchGetMenuItems(ctx, &menuv1.BatchGetMenuItemsRequest{
+			Ids: []uint32{ids[2], 9998, ids[0], ids[2], 9999},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 2)
+		assert.Equal(t, ids[2], resp.MenuItems[0].Id)
+		assert.Equal(t, ids[0], resp.MenuItems[1].Id)
+		assert.Equal(t, []uint32{9998, 9999}, resp.MissingIds)
+	})
+
+	t.Run("too many ids", func(t *testing.T) {
+		tooMany := make([]uint32, maxBatchSize+1)
+		for i := range tooMany {
+			tooMany[i] = uint32(i + 1)
+		}
+		_, err := server.BatchGetMenuItems(ctx, &menuv1.BatchGetMenuItemsRequest{Ids: tooMany})
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestUpdateMenuItem_FieldMask(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	created, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:        "Latte",
+		Description: "Espresso with steamed milk",
+		PriceCents:  350,
+		Currency:    "EUR",
+		Tags:        []string{"hot"},
+	})
+	require.NoError(t, err)
+	id := created.MenuItem.Id
+
+	t.Run("price only", func(t *testing.T) {
+		resp, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:         id,
+			PriceCents: 400,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price_cents"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), resp.MenuItem.PriceCents)
+		assert.Equal(t, "Latte", resp.MenuItem.Name)
+		assert.Equal(t, "Espresso with steamed milk", resp.MenuItem.Description)
+		assert.Equal(t, "EUR", resp.MenuItem.Currency)
+		assert.Equal(t, []string{"hot"}, resp.MenuItem.Tags)
+	})
+
+	t.Run("clear tags", func(t *testing.T) {
+		resp, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:         id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.MenuItem.Tags)
+		assert.Equal(t, int64(400), resp.MenuItem.PriceCents)
+	})
+
+	t.Run("empty mask replaces every field", func(t *testing.T) {
+		resp, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{Id: id, Name: "Flat white", PriceCents: 380})
+		require.NoError(t, err)
+		assert.Equal(t, "Flat white", resp.MenuItem.Name)
+		assert.Empty(t, resp.MenuItem.Description)
+		assert.Equal(t, int64(380), resp.MenuItem.PriceCents)
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		_, err := server.UpdateMenuItem(ctx, &menuv1.UpdateMenuItemRequest{
+			Id:         id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"stock"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"dberr"
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"pagination"
+	"softdelete"
+)
+
+// ListDeletedMenuItems lists soft-deleted menu items that have not been
+// purged yet.
+func (s *MenuServer) ListDeletedMenuItems(ctx context.Context, req *menuv1.ListDeletedMenuItemsRequest) (*menuv1.ListDeletedMenuItemsResponse, error) {
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := s.store.ListDeletedMenuItems(ctx, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "menu items")
+	}
+	menuItems, nextToken := pagination.Trim(page, menuItems, func(m models.MenuItem) uint { return m.ID })
+
+	now := s.now()
+	var pbMenuItems []*menuv1.MenuItem
+	for _, item := range menuItems {
+		pbMenuItems = append(pbMenuItems, toProtoMenuItem(item, now))
+	}
+
+	return &menuv1.ListDeletedMenuItemsResponse{MenuItems: pbMenuItems, NextPageToken: nextToken}, nil
+}
+
+func (s *MenuServer) RestoreMenuItem(ctx context.Context, req *menuv1.RestoreMenuItemRequest) (*menuv1.RestoreMenuItemResponse, error) {
+	if err := s.deletedMenuItems().Restore(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	menuItem, err := s.store.GetMenuItem(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
+	}
+	return &menuv1.RestoreMenuItemResponse{MenuItem: toProtoMenuItem(menuItem, s.now())}, nil
+}
+
+func (s *MenuServer) PurgeMenuItem(ctx context.Context, req *menuv1.PurgeMenuItemRequest) (*menuv1.PurgeMenuItemResponse, error) {
+	if err := s.deletedMenuItems().Purge(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	return &menuv1.PurgeMenuItemResponse{Success: true}, nil
+}
+
+// deletedMenuItems describes soft-deleted menu items to the softdelete
+// package.
+func (s *MenuServer) deletedMenuItems() softdelete.Resource {
+	return softdelete.Resource{
+		Name: "menu item",
+		IsDeleted: func(ctx context.Context, id uint) (bool, error) {
+			menuItem, err := s.store.GetMenuItemIncludingDeleted(ctx, id)
+			return menuItem.DeletedAt.Valid, err
+		},
+		Undelete: func(ctx context.Context, id uint) (restored bool, err error) {
+			err = s.store.Transaction(ctx, func(tx store.MenuStore) error {
+				restored, err = tx.RestoreMenuItem(ctx, id)
+				if err != nil || !restored {
+					return err
+				}
+				return tx.RecordEvent(ctx, models.EventMenuItemRestored, id)
+			})
+			return restored, err
+		},
+		Remove: s.store.PurgeMenuItem,
+	}
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSoftDeleteRecovery(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	latte, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:       "Latte",
+		PriceCents: 350,
+		Tags:       []string{"hot"},
+		Availability: []*menuv1.AvailabilityWindow{
+			{Day: menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY, StartTime: "07:00", EndTime: "11:00"},
+		},
+	})
+	require.NoError(t, err)
+	id := latte.MenuItem.Id
+	_, err = server.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: id})
+	require.NoError(t, err)
+
+	t.Run("deleted items are listed", func(t *testing.T) {
+		resp, err := server.ListDeletedMenuItems(ctx, &menuv1.ListDeletedMenuItemsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.MenuItems, 1)
+		assert.Equal(t, id, resp.MenuItems[0].Id)
+		assert.NotNil(t, resp.MenuItems[0].DeleteTime)
+		assert.Equal(t, []string{"hot"}, resp.MenuItems[0].Tags)
+	})
+
+	t.Run("restore keeps tags and availability", func(t *testing.T) {
+		resp, err := server.RestoreMenuItem(ctx, &menuv1.RestoreMenuItemRequest{Id: id})
+		require.NoError(t, err)
+		assert.Nil(t, resp.MenuItem.DeleteTime)
+		assert.Equal(t, []string{"hot"}, resp.MenuItem.Tags)
+		assert.Len(t, resp.MenuItem.Availability, 1)
+
+		_, err = server.RestoreMenuItem(ctx, &menuv1.RestoreMenuItemRequest{Id: id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		_, err := server.PurgeMenuItem(ctx, &menuv1.PurgeMenuItemRequest{Id: id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "only deleted items can be purged")
+
+		_, err = server.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: id})
+		require.NoError(t, err)
+		_, err = server.PurgeMenuItem(ctx, &menuv1.PurgeMenuItemRequest{Id: id})
+		require.NoError(t, err)
+
+		var windows, tagLinks int64
+		require.NoError(t, db.Model(&models.AvailabilityWindow{}).Where("menu_item_id = ?", id).Count(&windows).Error)
+		require.NoError(t, db.Table("menu_item_tags").Where("menu_item_id = ?", id).Count(&tagLinks).Error)
+		assert.Zero(t, windows)
+		assert.Zero(t, tagLinks)
+
+		_, err = server.RestoreMenuItem(ctx, &menuv1.RestoreMenuItemRequest{Id: id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		muffin, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Muffin"})
+		require.NoError(t, err)
+		_, err = server.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: muffin.MenuItem.Id})
+		require.NoError(t, err)
+
+		purged, err := server.store.PurgeDeletedMenuItems(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, purged, "recently deleted items are kept")
+
+		purged, err = server.store.PurgeDeletedMenuItems(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+}
@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"prototime"
+	"strings"
+	"time"
+
+	"dberr"
+	"menu-service/models"
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultReservationTTL is how long a reservation is held for an order that
+// has not been confirmed, long enough for order-service to store the order.
+const DefaultReservationTTL = 10 * time.Minute
+
+func (s *MenuServer) AdjustStock(ctx context.Context, req *menuv1.AdjustStockRequest) (*menuv1.AdjustStockResponse, error) {
+	var applied bool
+	var err error
+	switch change := req.Change.(type) {
+	case *menuv1.AdjustStockRequest_Delta:
+		// The store applies the delta atomically so concurrent reservations are not lost
+		applied, err = s.store.AdjustStock(ctx, uint(req.Id), change.Delta)
+	case *menuv1.AdjustStockRequest_SetTo:
+		if change.SetTo < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "stock must not be negative")
+		}
+		applied, err = s.store.SetStock(ctx, uint(req.Id), &change.SetTo)
+	case *menuv1.AdjustStockRequest_StopTracking:
+		applied, err = s.store.SetStock(ctx, uint(req.Id), nil)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "one of delta, set_to or stop_tracking is required")
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
+	}
+
+	menuItem, err := s.store.GetMenuItem(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "menu item")
+	}
+
+	if !applied {
+		if menuItem.Stock == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "menu item %d does not track stock, use set_to", req.Id)
+		}
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"only %d of menu item %d in stock, cannot remove %d", *menuItem.Stock, req.Id, -req.GetDelta())
+	}
+
+	return &menuv1.AdjustStockResponse{MenuItem: toProtoMenuItem(menuItem, s.now())}, nil
+}
+
+func (s *MenuServer) ReserveStock(ctx context.Context, req *menuv1.ReserveStockRequest) (*menuv1.ReserveStockResponse, error) {
+	if len(req.Lines) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one line is required")
+	}
+
+	// Merge lines for the same item so each item is checked once
+	var ids []uint32
+	quantities := make(map[uint32]int64)
+	for _, line := range req.Lines {
+		if line.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity for menu item %d must be positive", line.MenuItemId)
+		}
+		if _, ok := quantities[line.MenuItemId]; !ok {
+			ids = append(ids, line.MenuItemId)
+		}
+		quantities[line.MenuItemId] += line.Quantity
+	}
+
+	reservationID, err := newReservationID()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create reservation id: %v", err)
+	}
+	expiresAt := time.Now().Add(s.ReservationTTL)
+	reservation := models.StockReservation{ID: reservationID, ExpiresAt: &expiresAt}
+
+	var missing, insufficient []string
+	err = s.store.Transaction(ctx, func(tx store.MenuStore) error {
+		for _, id := range ids {
+			menuItem, err := tx.GetMenuItem(ctx, uint(id))
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					missing = append(missing, fmt.Sprint(id))
+					continue
+				}
+				return err
+			}
+			if menuItem.Stock == nil {
+				continue
+			}
+
+			// AdjustStock is atomic with respect to other reservations
+			reserved, err := tx.AdjustStock(ctx, uint(id), -quantities[id])
+			if err != nil {
+				return err
+			}
+			if !reserved {
+				insufficient = append(insufficient, fmt.Sprint(id))
+				continue
+			}
+			reservation.Lines = append(reservation.Lines, models.StockReservationLine{
+				MenuItemID: uint(id),
+				Quantity:   quantities[id],
+			})
+		}
+
+		if len(missing) > 0 || len(insufficient) > 0 {
+			return errReservationFailed
+		}
+		return tx.CreateReservation(ctx, &reservation)
+	})
+
+	switch {
+	case len(missing) > 0:
+		return nil, status.Errorf(codes.NotFound, "menu items not found: %s", strings.Join(missing, ", "))
+	case len(insufficient) > 0:
+		return nil, status.Errorf(codes.FailedPrecondition, "not enough stock for menu items: %s", strings.Join(insufficient, ", "))
+	case err != nil:
+		return nil, dberr.Status(err, "stock reservation")
+	}
+
+	return &menuv1.ReserveStockResponse{
+		ReservationId: reservation.ID,
+		ExpireTime:    prototime.Timestamp(expiresAt),
+	}, nil
+}
+
+func (s *MenuServer) ConfirmStock(ctx context.Context, req *menuv1.ConfirmStockRequest) (*menuv1.ConfirmStockResponse, error) {
+	reservation, err := s.store.GetReservation(ctx, req.ReservationId)
+	if err != nil {
+		return nil, dberr.Status(err, "reservation")
+	}
+	if reservation.ReleasedAt == nil && reservation.ExpiresAt == nil {
+		return &menuv1.ConfirmStockResponse{}, nil
+	}
+
+	confirmed, err := s.store.ConfirmReservation(ctx, reservation.ID)
+	if err != nil {
+		return nil, dberr.Status(err, "reservation")
+	}
+	if !confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation %s was already released", reservation.ID)
+	}
+	return &menuv1.ConfirmStockResponse{}, nil
+}
+
+func (s *MenuServer) ReleaseStock(ctx context.Context, req *menuv1.ReleaseStockRequest) (*menuv1.ReleaseStockResponse, error) {
+	_, err := s.releaseReservation(ctx, req.ReservationId, func(tx store.MenuStore) (bool, error) {
+		return tx.ReleaseReservation(ctx, req.ReservationId, time.Now())
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "reservation")
+	}
+
+	return &menuv1.ReleaseStockResponse{}, nil
+}
+
+// ReleaseExpiredReservations returns the stock of every reservation that
+// expired without being confirmed, and reports how many it released.
+func (s *MenuServer) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	now := time.Now()
+	ids, err := s.store.ExpiredReservations(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, id := range ids {
+		released, err := s.releaseReservation(ctx, id, func(tx store.MenuStore) (bool, error) {
+			return tx.ExpireReservation(ctx, id, now)
+		})
+		if err != nil {
+			return count, err
+		}
+		if released {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// releaseReservation gives a reservation's stock back if release, run in
+// the same transaction, marks it released. Only the call that releases the
+// reservation gives the stock back.
+func (s *MenuServer) releaseReservation(ctx context.Context, id string, release func(tx store.MenuStore) (bool, error)) (bool, error) {
+	var released bool
+	err := s.store.Transaction(ctx, func(tx store.MenuStore) error {
+		reservation, err := tx.GetReservation(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		released, err = release(tx)
+		if err != nil || !released {
+			return err
+		}
+
+		for _, line := range reservation.Lines {
+			if _, err := tx.AdjustStock(ctx, line.MenuItemID, line.Quantity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return released && err == nil, err
+}
+
+// errReservationFailed rolls back a reservation that could not be filled.
+var errReservationFailed = errors.New("reservation failed")
+
+func newReservationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, code, st.Code())
+}
+
+func TestAdjustStock(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	tracked, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Muffin", PriceCents: 250, Stock: int64Ptr(2)})
+	require.NoError(t, err)
+	untracked, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Coffee", PriceCents: 300})
+	require.NoError(t, err)
+	assert.Nil(t, untracked.MenuItem.Stock)
+	assert.False(t, untracked.MenuItem.SoldOut)
+
+	resp, err := server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     tracked.MenuItem.Id,
+		Change: &menuv1.AdjustStockRequest_Delta{Delta: -2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), resp.MenuItem.GetStock())
+	assert.True(t, resp.MenuItem.SoldOut)
+
+	getResp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: tracked.MenuItem.Id})
+	require.NoError(t, err)
+	assert.True(t, getResp.MenuItem.SoldOut)
+
+	_, err = server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     tracked.MenuItem.Id,
+		Change: &menuv1.AdjustStockRequest_Delta{Delta: -1},
+	})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	_, err = server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     untracked.MenuItem.Id,
+		Change: &menuv1.AdjustStockRequest_Delta{Delta: 5},
+	})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	resp, err = server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     untracked.MenuItem.Id,
+		Change: &menuv1.AdjustStockRequest_SetTo{SetTo: 30},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), resp.MenuItem.GetStock())
+
+	resp, err = server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     tracked.MenuItem.Id,
+		Change: &menuv1.AdjustStockRequest_StopTracking{StopTracking: true},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.MenuItem.Stock)
+	assert.False(t, resp.MenuItem.SoldOut)
+
+	_, err = server.AdjustStock(ctx, &menuv1.AdjustStockRequest{
+		Id:     9999,
+		Change: &menuv1.AdjustStockRequest_SetTo{SetTo: 1},
+	})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestReserveAndReleaseStock(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	muffin, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Muffin", PriceCents: 250, Stock: int64Ptr(3)})
+	require.NoError(t, err)
+	coffee, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Coffee", PriceCents: 300})
+	require.NoError(t, err)
+
+	stockOf := func(id uint32) int64 {
+		resp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: id})
+		require.NoError(t, err)
+		return resp.MenuItem.GetStock()
+	}
+
+	reserved, err := server.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{
+		{MenuItemId: muffin.MenuItem.Id, Quantity: 1},
+		{MenuItemId: coffee.MenuItem.Id, Quantity: 10},
+		{MenuItemId: muffin.MenuItem.Id, Quantity: 1},
+	}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, reserved.ReservationId)
+	assert.Equal(t, int64(1), stockOf(muffin.MenuItem.Id))
+
+	// All-or-nothing: a failing line leaves earlier lines untouched
+	_, err = server.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{
+		{MenuItemId: muffin.MenuItem.Id, Quantity: 2},
+	}})
+	requireCode(t, err, codes.FailedPrecondition)
+	assert.Equal(t, int64(1), stockOf(muffin.MenuItem.Id))
+
+	_, err = server.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{
+		{MenuItemId: muffin.MenuItem.Id, Quantity: 1},
+		{MenuItemId: 9999, Quantity: 1},
+	}})
+	requireCode(t, err, codes.NotFound)
+	assert.Equal(t, int64(1), stockOf(muffin.MenuItem.Id))
+
+	_, err = server.ReleaseStock(ctx, &menuv1.ReleaseStockRequest{ReservationId: reserved.ReservationId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stockOf(muffin.MenuItem.Id))
+
+	// Releasing again must not hand the stock back twice
+	_, err = server.ReleaseStock(ctx, &menuv1.ReleaseStockRequest{ReservationId: reserved.ReservationId})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stockOf(muffin.MenuItem.Id))
+
+	_, err = server.ReleaseStock(ctx, &menuv1.ReleaseStockRequest{ReservationId: "unknown"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestReserveStock_ExpiresUnlessConfirmed(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	// Reservations expire as soon as they are made
+	server.ReservationTTL = -time.Minute
+	ctx := context.Background()
+
+	muffin, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Muffin", PriceCents: 250, Stock: int64Ptr(5)})
+	require.NoError(t, err)
+	reserve := func() string {
+		resp, err := server.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{
+			{MenuItemId: muffin.MenuItem.Id, Quantity: 2},
+		}})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.ExpireTime)
+		return resp.ReservationId
+	}
+	placed, abandoned := reserve(), reserve()
+
+	_, err = server.ConfirmStock(ctx, &menuv1.ConfirmStockRequest{ReservationId: placed})
+	require.NoError(t, err)
+	_, err = server.ConfirmStock(ctx, &menuv1.ConfirmStockRequest{ReservationId: placed})
+	require.NoError(t, err, "confirming twice is a no-op")
+
+	released, err := server.ReleaseExpiredReservations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	resp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: muffin.MenuItem.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.MenuItem.GetStock(), "only the abandoned reservation is returned")
+
+	_, err = server.ConfirmStock(ctx, &menuv1.ConfirmStockRequest{ReservationId: abandoned})
+	requireCode(t, err, codes.FailedPrecondition)
+	_, err = server.ConfirmStock(ctx, &menuv1.ConfirmStockRequest{ReservationId: "unknown"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestReserveStock_ConcurrentOrdersCannotOversell(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewMenuServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	muffin, err := server.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Muffin", PriceCents: 250, Stock: int64Ptr(30)})
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := server.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{
+				{MenuItemId: muffin.MenuItem.Id, Quantity: 1},
+			}})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, succeeded, 30)
+	resp, err := server.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: muffin.MenuItem.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(30-succeeded), resp.MenuItem.GetStock())
+	assert.GreaterOrEqual(t, resp.MenuItem.GetStock(), int64(0))
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	menuv1 "menu-service/proto/menuv1"
+	"testing"
+	"validation"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestRequestValidation(t *testing.T) {
+	stock := int64(-1)
+	tests := []struct {
+		name       string
+		request    proto.Message
+		wantFields []string
+	}{
+		{
+			name:    "valid create",
+			request: &menuv1.CreateMenuItemRequest{Name: "Latte", PriceCents: 350, Currency: "eur"},
+		},
+		{
+			name:       "negative prices",
+			request:    &menuv1.CreateMenuItemRequest{Name: "Latte", Price: -1, PriceCents: -350},
+			wantFields: []string{"price", "price_cents"},
+		},
+		{
+			name:       "missing name, bad currency and negative stock",
+			request:    &menuv1.CreateMenuItemRequest{Currency: "euro", Stock: &stock},
+			wantFields: []string{"name", "currency", "stock"},
+		},
+		{
+			name: "malformed availability window",
+			request: &menuv1.UpdateMenuItemRequest{Id: 1, Name: "Latte", Availability: []*menuv1.AvailabilityWindow{
+				{Day: menuv1.DayOfWeek_DAY_OF_WEEK_MONDAY, StartTime: "07:00", EndTime: "24:00"},
+				{StartTime: "7am", EndTime: "25:00"},
+			}},
+			wantFields: []string{"availability[1].day", "availability[1].start_time", "availability[1].end_time"},
+		},
+		{
+			name:    "masked update skips unlisted fields",
+			request: &menuv1.UpdateMenuItemRequest{Id: 1, PriceCents: 400, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price_cents"}}},
+		},
+		{
+			name:       "masked update checks listed fields and id",
+			request:    &menuv1.UpdateMenuItemRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}}},
+			wantFields: []string{"id", "name"},
+		},
+		{
+			name:       "empty tag",
+			request:    &menuv1.CreateMenuItemRequest{Name: "Latte", Tags: []string{"hot", ""}},
+			wantFields: []string{"tags[1]"},
+		},
+		{
+			name:       "stock change required",
+			request:    &menuv1.AdjustStockRequest{Id: 1},
+			wantFields: []string{"change"},
+		},
+		{
+			name:       "empty reservation",
+			request:    &menuv1.ReserveStockRequest{},
+			wantFields: []string{"lines"},
+		},
+		{
+			name:       "zero quantity line",
+			request:    &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{{MenuItemId: 1}}},
+			wantFields: []string{"lines[0].quantity"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Check(tt.request)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			var fields []string
+			for _, v := range validation.FieldViolations(err) {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -1,57 +1,148 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // CAFE_TIMEZONE must resolve in minimal containers
 
-	"menu-service/database"
+	"authz"
+	"envconfig"
+	"graceful"
+	"healthcheck"
 	menugrpc "menu-service/grpc"
 	menuv1 "menu-service/proto/menuv1"
+	"menu-service/store"
+	"storedriver"
+	"validation"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Get database connection string from environment
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "5432")
-	dbUser := getEnv("DB_USER", "postgres")
-	dbPassword := getEnv("DB_PASSWORD", "postgres")
-	dbName := getEnv("DB_NAME", "menudb")
+	// Connect to the store selected by STORE_DRIVER and run migrations
+	storeConfig := storedriver.FromEnv("menudb", "menu.db")
+	menuStore, err := store.Open(storeConfig)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	log.Printf("Using %s store", storeConfig.Driver)
 
-	dsn := "host=" + dbHost + " port=" + dbPort + " user=" + dbUser + 
-		" password=" + dbPassword + " dbname=" + dbName + " sslmode=disable"
+	// Create gRPC server
+	grpcPort := envconfig.String("GRPC_PORT", "50052")
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
 
-	// Connect to database
-	if err := database.Connect(dsn); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	// Time zone that availability windows are written in
+	location, err := time.LoadLocation(envconfig.String("CAFE_TIMEZONE", "UTC"))
+	if err != nil {
+		log.Fatalf("Invalid CAFE_TIMEZONE: %v", err)
 	}
 
-	// Run migrations
-	if err := database.Migrate(); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	// Deleted menu items can be restored until they are purged
+	retention, err := envconfig.Duration("DELETED_RETENTION", store.DefaultDeletedRetention)
+	if err != nil {
+		log.Fatal(err)
 	}
+	jobs := graceful.NewJobs()
+	jobs.Every(time.Hour, func(ctx context.Context) { purgeDeletedMenuItems(ctx, menuStore, retention) })
 
-	// Create gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50052")
-	lis, err := net.Listen("tcp", ":"+grpcPort)
+	// Stock held for orders that were never placed goes back on sale
+	reservationTTL, err := envconfig.Duration("RESERVATION_TTL", menugrpc.DefaultReservationTTL)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatal(err)
+	}
+
+	menuServer := menugrpc.NewMenuServer(menuStore)
+	menuServer.Location = location
+	menuServer.ReservationTTL = reservationTTL
+	jobs.Every(time.Minute, func(ctx context.Context) { releaseExpiredReservations(ctx, menuServer) })
+
+	// Verify access tokens issued by the user service
+	keys, err := authz.LoadKeySet(os.Getenv("JWT_KEYS_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load JWT_KEYS_FILE: %v", err)
 	}
+	keys.ReloadOnSignal(syscall.SIGHUP)
+	authorizer := authz.NewAuthorizer(keys, healthcheck.AllowProbes(menugrpc.Policy))
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor(), validation.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(authorizer.StreamServerInterceptor(), validation.StreamServerInterceptor),
+	)
+	menuv1.RegisterMenuServiceServer(s, menuServer)
 
-	s := grpc.NewServer()
-	menuv1.RegisterMenuServiceServer(s, menugrpc.NewMenuServer())
+	// Report health from a periodic database ping
+	checker := healthcheck.New(menuv1.MenuService_ServiceDesc.ServiceName, map[string]healthcheck.Check{
+		"database": menuStore.Ping,
+	})
+	checker.Register(s)
+	jobs.Go(checker.Run)
+
+	// Let tools such as grpcurl list the API
+	reflectionEnabled, err := envconfig.Bool("GRPC_REFLECTION", false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if reflectionEnabled {
+		reflection.Register(s)
+	}
+
+	// Serve until SIGINT or SIGTERM, then report NOT_SERVING, drain and
+	// wait for the calls in flight
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	shutdown, err := graceful.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	shutdown.OnShutdown = checker.Shutdown
 
 	log.Printf("Menu service listening on port %s", grpcPort)
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	exitCode := 0
+	if err := graceful.Serve(ctx, s, lis, shutdown); err != nil {
+		log.Printf("Failed to serve: %v", err)
+		exitCode = 1
+	}
+	stop()
+
+	// Stop the background jobs before closing what they use
+	jobs.Stop()
+	if err := menuStore.Close(); err != nil {
+		log.Printf("Failed to close store: %v", err)
+		exitCode = 1
+	}
+	log.Println("Menu service stopped")
+	os.Exit(exitCode)
+}
+
+// purgeDeletedMenuItems removes menu items deleted longer than retention
+// ago.
+func purgeDeletedMenuItems(ctx context.Context, menuStore store.MenuStore, retention time.Duration) {
+	purged, err := menuStore.PurgeDeletedMenuItems(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.Printf("Failed to purge deleted menu items: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted menu items", purged)
 	}
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// releaseExpiredReservations returns the stock of reservations that expired
+// without being confirmed.
+func releaseExpiredReservations(ctx context.Context, menuServer *menugrpc.MenuServer) {
+	released, err := menuServer.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		log.Printf("Failed to release expired stock reservations: %v", err)
+	}
+	if released > 0 {
+		log.Printf("Released %d expired stock reservations", released)
 	}
-	return defaultValue
 }
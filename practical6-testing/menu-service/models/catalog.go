@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Category groups menu items, such as drinks or breakfast.
+type Category struct {
+	gorm.Model
+	Name         string `gorm:"not null"`
+	Description  string `gorm:"type:text"`
+	DisplayOrder int32  `gorm:"not null;default:0"`
+}
+
+// Tag is a free-form label shared between menu items. Names are stored in
+// lower case.
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:64;uniqueIndex;not null"`
+	CreatedAt time.Time
+}
+
+// AvailabilityWindow is a span of one weekday during which a menu item can
+// be ordered, in minutes since midnight in the cafe's time zone. The start
+// is inclusive and the end exclusive.
+type AvailabilityWindow struct {
+	ID          uint         `gorm:"primaryKey"`
+	MenuItemID  uint         `gorm:"index;not null"`
+	Weekday     time.Weekday `gorm:"not null"`
+	StartMinute int          `gorm:"not null"`
+	EndMinute   int          `gorm:"not null"`
+}
+
+// MinutesPerDay is the end minute of a window that runs to midnight.
+const MinutesPerDay = 24 * 60
+
+// Covers reports whether the window includes t, which must already be in
+// the cafe's time zone.
+func (w AvailabilityWindow) Covers(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	return t.Weekday() == w.Weekday && minute >= w.StartMinute && minute < w.EndMinute
+}
+
+// AvailableAt reports whether the item can be ordered at t. Items without
+// availability windows are always available.
+func (m MenuItem) AvailableAt(t time.Time) bool {
+	if len(m.Availability) == 0 {
+		return true
+	}
+	for _, window := range m.Availability {
+		if window.Covers(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Event types recorded in the outbox.
+const (
+	EventMenuItemDeleted  = "MenuItemDeleted"
+	EventMenuItemRestored = "MenuItemRestored"
+	EventMenuItemPurged   = "MenuItemPurged"
+)
+
+// OutboxEvent is a menu item lifecycle event written in the same transaction
+// as the change it describes, for other services to read with
+// ListMenuItemEvents.
+type OutboxEvent struct {
+	ID uint `gorm:"primaryKey"`
+	// Sequence numbers events in the order their transactions committed,
+	// which their IDs do not guarantee. Readers page through the feed by it.
+	Sequence   uint64 `gorm:"uniqueIndex"`
+	Type       string `gorm:"size:64;not null"`
+	MenuItemID uint   `gorm:"index;not null"`
+	CreatedAt  time.Time
+}
@@ -1,10 +1,39 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// DefaultCurrency is used for menu items created without a currency code.
+const DefaultCurrency = "USD"
 
 type MenuItem struct {
 	gorm.Model
-	Name        string  `gorm:"not null"`
-	Description string  `gorm:"type:text"`
-	Price       float64 `gorm:"not null"`
+	Name        string `gorm:"not null"`
+	Description string `gorm:"type:text"`
+	PriceCents  int64  `gorm:"not null;default:0"`
+	Currency    string `gorm:"size:3;not null;default:'USD'"`
+	// Stock is the number of units left to sell, nil when not tracked.
+	Stock *int64
+
+	CategoryID   *uint `gorm:"index"`
+	Tags         []Tag `gorm:"many2many:menu_item_tags"`
+	Availability []AvailabilityWindow
+}
+
+// SoldOut reports whether the item tracks stock and has none left.
+func (m MenuItem) SoldOut() bool {
+	return m.Stock != nil && *m.Stock <= 0
+}
+
+// CentsFromFloat converts a decimal price such as 2.50 into minor units.
+func CentsFromFloat(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// FloatFromCents converts minor units back into a decimal price.
+func FloatFromCents(cents int64) float64 {
+	return float64(cents) / 100
 }
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// StockReservation holds stock taken by ReserveStock until it is released.
+type StockReservation struct {
+	ID        string `gorm:"primaryKey;size:32"`
+	CreatedAt time.Time
+	// ExpiresAt is when an unconfirmed reservation is released. ConfirmStock
+	// clears it so the stock stays taken.
+	ExpiresAt  *time.Time `gorm:"index"`
+	ReleasedAt *time.Time
+	Lines      []StockReservationLine `gorm:"foreignKey:ReservationID"`
+}
+
+// StockReservationLine is the quantity of one stock-tracked item reserved.
+type StockReservationLine struct {
+	ID            uint   `gorm:"primaryKey"`
+	ReservationID string `gorm:"size:32;index;not null"`
+	MenuItemID    uint   `gorm:"not null"`
+	Quantity      int64  `gorm:"not null"`
+}
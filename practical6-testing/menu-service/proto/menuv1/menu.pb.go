@@ -7,8 +7,11 @@
 package menuv1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,33 +24,2482 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DayOfWeek int32
+
+const (
+	DayOfWeek_DAY_OF_WEEK_UNSPECIFIED DayOfWeek = 0
+	DayOfWeek_DAY_OF_WEEK_MONDAY      DayOfWeek = 1
+	DayOfWeek_DAY_OF_WEEK_TUESDAY     DayOfWeek = 2
+	DayOfWeek_DAY_OF_WEEK_WEDNESDAY   DayOfWeek = 3
+	DayOfWeek_DAY_OF_WEEK_THURSDAY    DayOfWeek = 4
+	DayOfWeek_DAY_OF_WEEK_FRIDAY      DayOfWeek = 5
+	DayOfWeek_DAY_OF_WEEK_SATURDAY    DayOfWeek = 6
+	DayOfWeek_DAY_OF_WEEK_SUNDAY      DayOfWeek = 7
+)
+
+// Enum value maps for DayOfWeek.
+var (
+	DayOfWeek_name = map[int32]string{
+		0: "DAY_OF_WEEK_UNSPECIFIED",
+		1: "DAY_OF_WEEK_MONDAY",
+		2: "DAY_OF_WEEK_TUESDAY",
+		3: "DAY_OF_WEEK_WEDNESDAY",
+		4: "DAY_OF_WEEK_THURSDAY",
+		5: "DAY_OF_WEEK_FRIDAY",
+		6: "DAY_OF_WEEK_SATURDAY",
+		7: "DAY_OF_WEEK_SUNDAY",
+	}
+	DayOfWeek_value = map[string]int32{
+		"DAY_OF_WEEK_UNSPECIFIED": 0,
+		"DAY_OF_WEEK_MONDAY":      1,
+		"DAY_OF_WEEK_TUESDAY":     2,
+		"DAY_OF_WEEK_WEDNESDAY":   3,
+		"DAY_OF_WEEK_THURSDAY":    4,
+		"DAY_OF_WEEK_FRIDAY":      5,
+		"DAY_OF_WEEK_SATURDAY":    6,
+		"DAY_OF_WEEK_SUNDAY":      7,
+	}
+)
+
+func (x DayOfWeek) Enum() *DayOfWeek {
+	p := new(DayOfWeek)
+	*p = x
+	return p
+}
+
+func (x DayOfWeek) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayOfWeek) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_menu_proto_enumTypes[0].Descriptor()
+}
+
+func (DayOfWeek) Type() protoreflect.EnumType {
+	return &file_proto_menu_proto_enumTypes[0]
+}
+
+func (x DayOfWeek) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayOfWeek.Descriptor instead.
+func (DayOfWeek) EnumDescriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{0}
+}
+
+type MenuItemEventType int32
+
+const (
+	MenuItemEventType_MENU_ITEM_EVENT_TYPE_UNSPECIFIED MenuItemEventType = 0
+	MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED     MenuItemEventType = 1
+	MenuItemEventType_MENU_ITEM_EVENT_TYPE_RESTORED    MenuItemEventType = 2
+	// The item was removed permanently and will not come back.
+	MenuItemEventType_MENU_ITEM_EVENT_TYPE_PURGED MenuItemEventType = 3
+)
+
+// Enum value maps for MenuItemEventType.
+var (
+	MenuItemEventType_name = map[int32]string{
+		0: "MENU_ITEM_EVENT_TYPE_UNSPECIFIED",
+		1: "MENU_ITEM_EVENT_TYPE_DELETED",
+		2: "MENU_ITEM_EVENT_TYPE_RESTORED",
+		3: "MENU_ITEM_EVENT_TYPE_PURGED",
+	}
+	MenuItemEventType_value = map[string]int32{
+		"MENU_ITEM_EVENT_TYPE_UNSPECIFIED": 0,
+		"MENU_ITEM_EVENT_TYPE_DELETED":     1,
+		"MENU_ITEM_EVENT_TYPE_RESTORED":    2,
+		"MENU_ITEM_EVENT_TYPE_PURGED":      3,
+	}
+)
+
+func (x MenuItemEventType) Enum() *MenuItemEventType {
+	p := new(MenuItemEventType)
+	*p = x
+	return p
+}
+
+func (x MenuItemEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuItemEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_menu_proto_enumTypes[1].Descriptor()
+}
+
+func (MenuItemEventType) Type() protoreflect.EnumType {
+	return &file_proto_menu_proto_enumTypes[1]
+}
+
+func (x MenuItemEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuItemEventType.Descriptor instead.
+func (MenuItemEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{1}
+}
+
+// AvailabilityWindow is a time of day, in the cafe's time zone, during which
+// an item can be ordered on one day of the week.
+type AvailabilityWindow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Day   DayOfWeek              `protobuf:"varint,1,opt,name=day,proto3,enum=menu.v1.DayOfWeek" json:"day,omitempty"`
+	// Start of the window as "HH:MM", inclusive.
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End of the window as "HH:MM", exclusive; "24:00" runs to midnight.
+	EndTime       string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityWindow) Reset() {
+	*x = AvailabilityWindow{}
+	mi := &file_proto_menu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityWindow) ProtoMessage() {}
+
+func (x *AvailabilityWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityWindow.ProtoReflect.Descriptor instead.
+func (*AvailabilityWindow) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AvailabilityWindow) GetDay() DayOfWeek {
+	if x != nil {
+		return x.Day
+	}
+	return DayOfWeek_DAY_OF_WEEK_UNSPECIFIED
+}
+
+func (x *AvailabilityWindow) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *AvailabilityWindow) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
 type MenuItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: use price_cents and currency.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	Price float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Deprecated: use create_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Deprecated: use update_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	UpdatedAt string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Price in the currency's minor unit, e.g. 250 for 2.50.
+	PriceCents int64 `protobuf:"varint,7,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	// ISO 4217 currency code.
+	Currency string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Units left to sell; unset when the item does not track stock.
+	Stock *int64 `protobuf:"varint,9,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	// True when the item tracks stock and none is left.
+	SoldOut bool `protobuf:"varint,10,opt,name=sold_out,json=soldOut,proto3" json:"sold_out,omitempty"`
+	// Zero when the item is not in a category.
+	CategoryId uint32   `protobuf:"varint,11,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tags       []string `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	// When the item can be ordered; an item without windows is always available.
+	Availability []*AvailabilityWindow `protobuf:"bytes,13,rep,name=availability,proto3" json:"availability,omitempty"`
+	// True when the item has availability windows and none of them covers the
+	// time the response was built.
+	Unavailable bool `protobuf:"varint,14,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+	// Deprecated: use delete_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	DeletedAt  string                 `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Unset unless the item has been deleted.
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuItem) Reset() {
+	*x = MenuItem{}
+	mi := &file_proto_menu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuItem) ProtoMessage() {}
+
+func (x *MenuItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
+func (*MenuItem) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MenuItem) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *MenuItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *MenuItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *MenuItem) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *MenuItem) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *MenuItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MenuItem) GetStock() int64 {
+	if x != nil && x.Stock != nil {
+		return *x.Stock
+	}
+	return 0
+}
+
+func (x *MenuItem) GetSoldOut() bool {
+	if x != nil {
+		return x.SoldOut
+	}
+	return false
+}
+
+func (x *MenuItem) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *MenuItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MenuItem) GetAvailability() []*AvailabilityWindow {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *MenuItem) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *MenuItem) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *MenuItem) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *MenuItem) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *MenuItem) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+type CreateMenuItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: used only when price_cents is not set.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	Price      float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	PriceCents int64   `protobuf:"varint,4,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	Currency   string  `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Initial stock; leave unset for items that are never sold out.
+	Stock      *int64 `protobuf:"varint,6,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	CategoryId uint32 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Tag names; tags that do not exist yet are created.
+	Tags          []string              `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Availability  []*AvailabilityWindow `protobuf:"bytes,9,rep,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemRequest) Reset() {
+	*x = CreateMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemRequest) ProtoMessage() {}
+
+func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateMenuItemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *CreateMenuItemRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetStock() int64 {
+	if x != nil && x.Stock != nil {
+		return *x.Stock
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateMenuItemRequest) GetAvailability() []*AvailabilityWindow {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+type CreateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemResponse) Reset() {
+	*x = CreateMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemResponse) ProtoMessage() {}
+
+func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type GetMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemRequest) Reset() {
+	*x = GetMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemRequest) ProtoMessage() {}
+
+func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemResponse) Reset() {
+	*x = GetMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemResponse) ProtoMessage() {}
+
+func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type GetMenuItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of menu items to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Inclusive price bounds in minor units.
+	MinPriceCents *int64 `protobuf:"varint,3,opt,name=min_price_cents,json=minPriceCents,proto3,oneof" json:"min_price_cents,omitempty"`
+	MaxPriceCents *int64 `protobuf:"varint,4,opt,name=max_price_cents,json=maxPriceCents,proto3,oneof" json:"max_price_cents,omitempty"`
+	// Case-insensitive substring of the item name.
+	NameContains string `protobuf:"bytes,5,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`
+	// Only items in this category.
+	CategoryId uint32 `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Only items with this tag.
+	Tag string `protobuf:"bytes,7,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Only items that can be ordered right now.
+	AvailableNow  bool `protobuf:"varint,8,opt,name=available_now,json=availableNow,proto3" json:"available_now,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemsRequest) Reset() {
+	*x = GetMenuItemsRequest{}
+	mi := &file_proto_menu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemsRequest) ProtoMessage() {}
+
+func (x *GetMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMenuItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetMenuItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetMenuItemsRequest) GetMinPriceCents() int64 {
+	if x != nil && x.MinPriceCents != nil {
+		return *x.MinPriceCents
+	}
+	return 0
+}
+
+func (x *GetMenuItemsRequest) GetMaxPriceCents() int64 {
+	if x != nil && x.MaxPriceCents != nil {
+		return *x.MaxPriceCents
+	}
+	return 0
+}
+
+func (x *GetMenuItemsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *GetMenuItemsRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *GetMenuItemsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetMenuItemsRequest) GetAvailableNow() bool {
+	if x != nil {
+		return x.AvailableNow
+	}
+	return false
+}
+
+type GetMenuItemsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MenuItems []*MenuItem            `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	// Empty when there are no more menu items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemsResponse) Reset() {
+	*x = GetMenuItemsResponse{}
+	mi := &file_proto_menu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemsResponse) ProtoMessage() {}
+
+func (x *GetMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMenuItemsResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *GetMenuItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type BatchGetMenuItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 100 IDs; duplicates are ignored.
+	Ids           []uint32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMenuItemsRequest) Reset() {
+	*x = BatchGetMenuItemsRequest{}
+	mi := &file_proto_menu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMenuItemsRequest) ProtoMessage() {}
+
+func (x *BatchGetMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchGetMenuItemsRequest) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetMenuItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Found items in the order their IDs were first requested.
+	MenuItems     []*MenuItem `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	MissingIds    []uint32    `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMenuItemsResponse) Reset() {
+	*x = BatchGetMenuItemsResponse{}
+	mi := &file_proto_menu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMenuItemsResponse) ProtoMessage() {}
+
+func (x *BatchGetMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetMenuItemsResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *BatchGetMenuItemsResponse) GetMissingIds() []uint32 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type UpdateMenuItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: used only when price_cents is not set.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	Price        float64               `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	PriceCents   int64                 `protobuf:"varint,5,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	Currency     string                `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CategoryId   uint32                `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tags         []string              `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Availability []*AvailabilityWindow `protobuf:"bytes,9,rep,name=availability,proto3" json:"availability,omitempty"`
+	// Fields to change, e.g. "price_cents" or "tags"; the others are left as
+	// they are. Every field is replaced when the mask is empty.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,10,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemRequest) Reset() {
+	*x = UpdateMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemRequest) ProtoMessage() {}
+
+func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *UpdateMenuItemRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateMenuItemRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *UpdateMenuItemRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateMenuItemRequest) GetAvailability() []*AvailabilityWindow {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *UpdateMenuItemRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemResponse) Reset() {
+	*x = UpdateMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemResponse) ProtoMessage() {}
+
+func (x *UpdateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type DeleteMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemRequest) Reset() {
+	*x = DeleteMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemRequest) ProtoMessage() {}
+
+func (x *DeleteMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemResponse) Reset() {
+	*x = DeleteMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemResponse) ProtoMessage() {}
+
+func (x *DeleteMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteMenuItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type MenuItemEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases with every event in the order they were committed, so no
+	// event appears later with a lower sequence; pass the last one seen as
+	// after_sequence.
+	Sequence   uint64            `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type       MenuItemEventType `protobuf:"varint,2,opt,name=type,proto3,enum=menu.v1.MenuItemEventType" json:"type,omitempty"`
+	MenuItemId uint32            `protobuf:"varint,3,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	// Deprecated: use event_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	OccurredAt    string                 `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuItemEvent) Reset() {
+	*x = MenuItemEvent{}
+	mi := &file_proto_menu_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuItemEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuItemEvent) ProtoMessage() {}
+
+func (x *MenuItemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuItemEvent.ProtoReflect.Descriptor instead.
+func (*MenuItemEvent) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MenuItemEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MenuItemEvent) GetType() MenuItemEventType {
+	if x != nil {
+		return x.Type
+	}
+	return MenuItemEventType_MENU_ITEM_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *MenuItemEvent) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *MenuItemEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *MenuItemEvent) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+type ListMenuItemEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterSequence uint64                 `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// Maximum number of events to return; defaults to 50, capped at 100.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenuItemEventsRequest) Reset() {
+	*x = ListMenuItemEventsRequest{}
+	mi := &file_proto_menu_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenuItemEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenuItemEventsRequest) ProtoMessage() {}
+
+func (x *ListMenuItemEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenuItemEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListMenuItemEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMenuItemEventsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *ListMenuItemEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListMenuItemEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when the caller has seen every event.
+	Events        []*MenuItemEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenuItemEventsResponse) Reset() {
+	*x = ListMenuItemEventsResponse{}
+	mi := &file_proto_menu_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenuItemEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenuItemEventsResponse) ProtoMessage() {}
+
+func (x *ListMenuItemEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenuItemEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListMenuItemEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMenuItemEventsResponse) GetEvents() []*MenuItemEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ListDeletedMenuItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of menu items to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedMenuItemsRequest) Reset() {
+	*x = ListDeletedMenuItemsRequest{}
+	mi := &file_proto_menu_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedMenuItemsRequest) ProtoMessage() {}
+
+func (x *ListDeletedMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDeletedMenuItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedMenuItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeletedMenuItemsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MenuItems []*MenuItem            `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	// Empty when there are no more menu items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedMenuItemsResponse) Reset() {
+	*x = ListDeletedMenuItemsResponse{}
+	mi := &file_proto_menu_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedMenuItemsResponse) ProtoMessage() {}
+
+func (x *ListDeletedMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDeletedMenuItemsResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *ListDeletedMenuItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RestoreMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreMenuItemRequest) Reset() {
+	*x = RestoreMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreMenuItemRequest) ProtoMessage() {}
+
+func (x *RestoreMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*RestoreMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreMenuItemResponse) Reset() {
+	*x = RestoreMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreMenuItemResponse) ProtoMessage() {}
+
+func (x *RestoreMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*RestoreMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type PurgeMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeMenuItemRequest) Reset() {
+	*x = PurgeMenuItemRequest{}
+	mi := &file_proto_menu_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeMenuItemRequest) ProtoMessage() {}
+
+func (x *PurgeMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*PurgeMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeMenuItemResponse) Reset() {
+	*x = PurgeMenuItemResponse{}
+	mi := &file_proto_menu_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeMenuItemResponse) ProtoMessage() {}
+
+func (x *PurgeMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*PurgeMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PurgeMenuItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Change:
+	//
+	//	*AdjustStockRequest_Delta
+	//	*AdjustStockRequest_SetTo
+	//	*AdjustStockRequest_StopTracking
+	Change        isAdjustStockRequest_Change `protobuf_oneof:"change"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_proto_menu_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AdjustStockRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetChange() isAdjustStockRequest_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *AdjustStockRequest) GetDelta() int64 {
+	if x != nil {
+		if x, ok := x.Change.(*AdjustStockRequest_Delta); ok {
+			return x.Delta
+		}
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetSetTo() int64 {
+	if x != nil {
+		if x, ok := x.Change.(*AdjustStockRequest_SetTo); ok {
+			return x.SetTo
+		}
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetStopTracking() bool {
+	if x != nil {
+		if x, ok := x.Change.(*AdjustStockRequest_StopTracking); ok {
+			return x.StopTracking
+		}
+	}
+	return false
+}
+
+type isAdjustStockRequest_Change interface {
+	isAdjustStockRequest_Change()
+}
+
+type AdjustStockRequest_Delta struct {
+	// Added to the current stock; negative values remove stock.
+	Delta int64 `protobuf:"varint,2,opt,name=delta,proto3,oneof"`
+}
+
+type AdjustStockRequest_SetTo struct {
+	// Replaces the current stock, starting to track it if needed.
+	SetTo int64 `protobuf:"varint,3,opt,name=set_to,json=setTo,proto3,oneof"`
+}
+
+type AdjustStockRequest_StopTracking struct {
+	// Stops tracking stock so the item is never sold out.
+	StopTracking bool `protobuf:"varint,4,opt,name=stop_tracking,json=stopTracking,proto3,oneof"`
+}
+
+func (*AdjustStockRequest_Delta) isAdjustStockRequest_Change() {}
+
+func (*AdjustStockRequest_SetTo) isAdjustStockRequest_Change() {}
+
+func (*AdjustStockRequest_StopTracking) isAdjustStockRequest_Change() {}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_proto_menu_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AdjustStockResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+type StockLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItemId    uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLine) Reset() {
+	*x = StockLine{}
+	mi := &file_proto_menu_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLine) ProtoMessage() {}
+
+func (x *StockLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLine.ProtoReflect.Descriptor instead.
+func (*StockLine) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StockLine) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *StockLine) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*StockLine           `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_menu_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReserveStockRequest) GetLines() []*StockLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	// When the reservation is released unless it has been confirmed.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_menu_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReserveStockResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type ConfirmStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmStockRequest) Reset() {
+	*x = ConfirmStockRequest{}
+	mi := &file_proto_menu_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmStockRequest) ProtoMessage() {}
+
+func (x *ConfirmStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmStockRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConfirmStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ConfirmStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmStockResponse) Reset() {
+	*x = ConfirmStockResponse{}
+	mi := &file_proto_menu_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmStockResponse) ProtoMessage() {}
+
+func (x *ConfirmStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmStockResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{29}
+}
+
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_proto_menu_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReleaseStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_proto_menu_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{31}
+}
+
+type Category struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Categories are shown in ascending display order.
+	DisplayOrder int32 `protobuf:"varint,4,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
+	// Deprecated: use create_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Deprecated: use update_time.
+	//
+	// Deprecated: Marked as deprecated in proto/menu.proto.
+	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_proto_menu_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Category) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetDisplayOrder() int32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *Category) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/menu.proto.
+func (x *Category) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Category) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DisplayOrder  int32                  `protobuf:"varint,3,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_proto_menu_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetDisplayOrder() int32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_proto_menu_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_proto_menu_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCategoryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryResponse) Reset() {
+	*x = GetCategoryResponse{}
+	mi := &file_proto_menu_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryResponse) ProtoMessage() {}
+
+func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesRequest) Reset() {
+	*x = GetCategoriesRequest{}
+	mi := &file_proto_menu_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesRequest) ProtoMessage() {}
+
+func (x *GetCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{37}
+}
+
+type GetCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesResponse) Reset() {
+	*x = GetCategoriesResponse{}
+	mi := &file_proto_menu_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesResponse) ProtoMessage() {}
+
+func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type UpdateCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DisplayOrder  int32                  `protobuf:"varint,4,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MenuItem) Reset() {
-	*x = MenuItem{}
-	mi := &file_proto_menu_proto_msgTypes[0]
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_proto_menu_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MenuItem) String() string {
+func (x *UpdateCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MenuItem) ProtoMessage() {}
+func (*UpdateCategoryRequest) ProtoMessage() {}
 
-func (x *MenuItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[0]
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,77 +2510,105 @@ func (x *MenuItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
-func (*MenuItem) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *MenuItem) GetId() uint32 {
+func (x *UpdateCategoryRequest) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *MenuItem) GetName() string {
+func (x *UpdateCategoryRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *MenuItem) GetDescription() string {
+func (x *UpdateCategoryRequest) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *MenuItem) GetPrice() float64 {
+func (x *UpdateCategoryRequest) GetDisplayOrder() int32 {
 	if x != nil {
-		return x.Price
+		return x.DisplayOrder
 	}
 	return 0
 }
 
-func (x *MenuItem) GetCreatedAt() string {
+type UpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryResponse) Reset() {
+	*x = UpdateCategoryResponse{}
+	mi := &file_proto_menu_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryResponse) ProtoMessage() {}
+
+func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[40]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *MenuItem) GetUpdatedAt() string {
+// Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateCategoryResponse) GetCategory() *Category {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Category
 	}
-	return ""
+	return nil
 }
 
-type CreateMenuItemRequest struct {
+type DeleteCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMenuItemRequest) Reset() {
-	*x = CreateMenuItemRequest{}
-	mi := &file_proto_menu_proto_msgTypes[1]
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_proto_menu_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMenuItemRequest) String() string {
+func (x *DeleteCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMenuItemRequest) ProtoMessage() {}
+func (*DeleteCategoryRequest) ProtoMessage() {}
 
-func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[1]
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,54 +2619,85 @@ func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*CreateMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *CreateMenuItemRequest) GetName() string {
+func (x *DeleteCategoryRequest) GetId() uint32 {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateMenuItemRequest) GetDescription() string {
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_proto_menu_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[42]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateMenuItemRequest) GetPrice() float64 {
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteCategoryResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Price
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-type CreateMenuItemResponse struct {
+type Tag struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMenuItemResponse) Reset() {
-	*x = CreateMenuItemResponse{}
-	mi := &file_proto_menu_proto_msgTypes[2]
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_proto_menu_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMenuItemResponse) String() string {
+func (x *Tag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMenuItemResponse) ProtoMessage() {}
+func (*Tag) ProtoMessage() {}
 
-func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[2]
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,40 +2708,47 @@ func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*CreateMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *CreateMenuItemResponse) GetMenuItem() *MenuItem {
+func (x *Tag) GetId() uint32 {
 	if x != nil {
-		return x.MenuItem
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type GetMenuItemRequest struct {
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemRequest) Reset() {
-	*x = GetMenuItemRequest{}
-	mi := &file_proto_menu_proto_msgTypes[3]
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_proto_menu_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemRequest) String() string {
+func (x *CreateTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemRequest) ProtoMessage() {}
+func (*CreateTagRequest) ProtoMessage() {}
 
-func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[3]
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,40 +2759,40 @@ func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*GetMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *GetMenuItemRequest) GetId() uint32 {
+func (x *CreateTagRequest) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-type GetMenuItemResponse struct {
+type CreateTagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemResponse) Reset() {
-	*x = GetMenuItemResponse{}
-	mi := &file_proto_menu_proto_msgTypes[4]
+func (x *CreateTagResponse) Reset() {
+	*x = CreateTagResponse{}
+	mi := &file_proto_menu_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemResponse) String() string {
+func (x *CreateTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemResponse) ProtoMessage() {}
+func (*CreateTagResponse) ProtoMessage() {}
 
-func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[4]
+func (x *CreateTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -285,39 +2803,39 @@ func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*GetMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateTagResponse.ProtoReflect.Descriptor instead.
+func (*CreateTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetMenuItemResponse) GetMenuItem() *MenuItem {
+func (x *CreateTagResponse) GetTag() *Tag {
 	if x != nil {
-		return x.MenuItem
+		return x.Tag
 	}
 	return nil
 }
 
-type GetMenuItemsRequest struct {
+type GetTagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemsRequest) Reset() {
-	*x = GetMenuItemsRequest{}
-	mi := &file_proto_menu_proto_msgTypes[5]
+func (x *GetTagsRequest) Reset() {
+	*x = GetTagsRequest{}
+	mi := &file_proto_menu_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemsRequest) String() string {
+func (x *GetTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemsRequest) ProtoMessage() {}
+func (*GetTagsRequest) ProtoMessage() {}
 
-func (x *GetMenuItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[5]
+func (x *GetTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,33 +2846,33 @@ func (x *GetMenuItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemsRequest.ProtoReflect.Descriptor instead.
-func (*GetMenuItemsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetTagsRequest.ProtoReflect.Descriptor instead.
+func (*GetTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{46}
 }
 
-type GetMenuItemsResponse struct {
+type GetTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItems     []*MenuItem            `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemsResponse) Reset() {
-	*x = GetMenuItemsResponse{}
-	mi := &file_proto_menu_proto_msgTypes[6]
+func (x *GetTagsResponse) Reset() {
+	*x = GetTagsResponse{}
+	mi := &file_proto_menu_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemsResponse) String() string {
+func (x *GetTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemsResponse) ProtoMessage() {}
+func (*GetTagsResponse) ProtoMessage() {}
 
-func (x *GetMenuItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[6]
+func (x *GetTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,43 +2883,41 @@ func (x *GetMenuItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemsResponse.ProtoReflect.Descriptor instead.
-func (*GetMenuItemsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetTagsResponse.ProtoReflect.Descriptor instead.
+func (*GetTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetMenuItemsResponse) GetMenuItems() []*MenuItem {
+func (x *GetTagsResponse) GetTags() []*Tag {
 	if x != nil {
-		return x.MenuItems
+		return x.Tags
 	}
 	return nil
 }
 
-type UpdateMenuItemRequest struct {
+type UpdateTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMenuItemRequest) Reset() {
-	*x = UpdateMenuItemRequest{}
-	mi := &file_proto_menu_proto_msgTypes[7]
+func (x *UpdateTagRequest) Reset() {
+	*x = UpdateTagRequest{}
+	mi := &file_proto_menu_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMenuItemRequest) String() string {
+func (x *UpdateTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMenuItemRequest) ProtoMessage() {}
+func (*UpdateTagRequest) ProtoMessage() {}
 
-func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[7]
+func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,61 +2928,47 @@ func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*UpdateMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateTagRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *UpdateMenuItemRequest) GetId() uint32 {
+func (x *UpdateTagRequest) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *UpdateMenuItemRequest) GetName() string {
+func (x *UpdateTagRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateMenuItemRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *UpdateMenuItemRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-type UpdateMenuItemResponse struct {
+type UpdateTagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMenuItemResponse) Reset() {
-	*x = UpdateMenuItemResponse{}
-	mi := &file_proto_menu_proto_msgTypes[8]
+func (x *UpdateTagResponse) Reset() {
+	*x = UpdateTagResponse{}
+	mi := &file_proto_menu_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMenuItemResponse) String() string {
+func (x *UpdateTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMenuItemResponse) ProtoMessage() {}
+func (*UpdateTagResponse) ProtoMessage() {}
 
-func (x *UpdateMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[8]
+func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,40 +2979,40 @@ func (x *UpdateMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*UpdateMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UpdateTagResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *UpdateMenuItemResponse) GetMenuItem() *MenuItem {
+func (x *UpdateTagResponse) GetTag() *Tag {
 	if x != nil {
-		return x.MenuItem
+		return x.Tag
 	}
 	return nil
 }
 
-type DeleteMenuItemRequest struct {
+type DeleteTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMenuItemRequest) Reset() {
-	*x = DeleteMenuItemRequest{}
-	mi := &file_proto_menu_proto_msgTypes[9]
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_proto_menu_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMenuItemRequest) String() string {
+func (x *DeleteTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMenuItemRequest) ProtoMessage() {}
+func (*DeleteTagRequest) ProtoMessage() {}
 
-func (x *DeleteMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[9]
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,40 +3023,40 @@ func (x *DeleteMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *DeleteMenuItemRequest) GetId() uint32 {
+func (x *DeleteTagRequest) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type DeleteMenuItemResponse struct {
+type DeleteTagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMenuItemResponse) Reset() {
-	*x = DeleteMenuItemResponse{}
-	mi := &file_proto_menu_proto_msgTypes[10]
+func (x *DeleteTagResponse) Reset() {
+	*x = DeleteTagResponse{}
+	mi := &file_proto_menu_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMenuItemResponse) String() string {
+func (x *DeleteTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMenuItemResponse) ProtoMessage() {}
+func (*DeleteTagResponse) ProtoMessage() {}
 
-func (x *DeleteMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_menu_proto_msgTypes[10]
+func (x *DeleteTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_menu_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,12 +3067,12 @@ func (x *DeleteMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*DeleteMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_menu_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeleteTagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_menu_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DeleteMenuItemResponse) GetSuccess() bool {
+func (x *DeleteTagResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
@@ -581,47 +3083,256 @@ var File_proto_menu_proto protoreflect.FileDescriptor
 
 const file_proto_menu_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/menu.proto\x12\amenu.v1\"\xa4\x01\n" +
+	"\x10proto/menu.proto\x12\amenu.v1\x1a\x1bbuf/validate/validate.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x01\n" +
+	"\x12AvailabilityWindow\x120\n" +
+	"\x03day\x18\x01 \x01(\x0e2\x12.menu.v1.DayOfWeekB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x03day\x12F\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\tB'\xbaH$r\"2 ^([01]?[0-9]|2[0-3]):[0-5][0-9]$R\tstartTime\x12J\n" +
+	"\bend_time\x18\x03 \x01(\tB/\xbaH,r*2(^(([01]?[0-9]|2[0-3]):[0-5][0-9]|24:00)$R\aendTime\"\x9f\x05\n" +
 	"\bMenuItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1d\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\x05price\x18\x04 \x01(\x01B\x02\x18\x01R\x05price\x12!\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tB\x02\x18\x01R\tcreatedAt\x12!\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"updated_at\x18\x06 \x01(\tB\x02\x18\x01R\tupdatedAt\x12\x1f\n" +
+	"\vprice_cents\x18\a \x01(\x03R\n" +
+	"priceCents\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x19\n" +
+	"\x05stock\x18\t \x01(\x03H\x00R\x05stock\x88\x01\x01\x12\x19\n" +
+	"\bsold_out\x18\n" +
+	" \x01(\bR\asoldOut\x12\x1f\n" +
+	"\vcategory_id\x18\v \x01(\rR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12?\n" +
+	"\favailability\x18\r \x03(\v2\x1b.menu.v1.AvailabilityWindowR\favailability\x12 \n" +
+	"\vunavailable\x18\x0e \x01(\bR\vunavailable\x12!\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"c\n" +
-	"\x15CreateMenuItemRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"H\n" +
+	"deleted_at\x18\x0f \x01(\tB\x02\x18\x01R\tdeletedAt\x12;\n" +
+	"\vcreate_time\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12;\n" +
+	"\vdelete_time\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTimeB\b\n" +
+	"\x06_stock\"\x9f\x03\n" +
+	"\x15CreateMenuItemRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\vdescription\x12(\n" +
+	"\x05price\x18\x03 \x01(\x01B\x12\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00\x18\x01R\x05price\x12(\n" +
+	"\vprice_cents\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\n" +
+	"priceCents\x123\n" +
+	"\bcurrency\x18\x05 \x01(\tB\x17\xbaH\x14\xd8\x01\x01r\x0f2\r^[A-Za-z]{3}$R\bcurrency\x12\"\n" +
+	"\x05stock\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\x05stock\x88\x01\x01\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\rR\n" +
+	"categoryId\x12\"\n" +
+	"\x04tags\x18\b \x03(\tB\x0e\xbaH\v\x92\x01\b\"\x06r\x04\x10\x01\x18@R\x04tags\x12?\n" +
+	"\favailability\x18\t \x03(\v2\x1b.menu.v1.AvailabilityWindowR\favailabilityB\b\n" +
+	"\x06_stock\"H\n" +
 	"\x16CreateMenuItemResponse\x12.\n" +
-	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"$\n" +
-	"\x12GetMenuItemRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"E\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"-\n" +
+	"\x12GetMenuItemRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"E\n" +
 	"\x13GetMenuItemResponse\x12.\n" +
-	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"\x15\n" +
-	"\x13GetMenuItemsRequest\"H\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"\xfd\x02\n" +
+	"\x13GetMenuItemsRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x124\n" +
+	"\x0fmin_price_cents\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\rminPriceCents\x88\x01\x01\x124\n" +
+	"\x0fmax_price_cents\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x01R\rmaxPriceCents\x88\x01\x01\x12,\n" +
+	"\rname_contains\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x18dR\fnameContains\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\rR\n" +
+	"categoryId\x12\x19\n" +
+	"\x03tag\x18\a \x01(\tB\a\xbaH\x04r\x02\x18@R\x03tag\x12#\n" +
+	"\ravailable_now\x18\b \x01(\bR\favailableNowB\x12\n" +
+	"\x10_min_price_centsB\x12\n" +
+	"\x10_max_price_cents\"p\n" +
 	"\x14GetMenuItemsResponse\x120\n" +
 	"\n" +
-	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\"s\n" +
-	"\x15UpdateMenuItemRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\"H\n" +
+	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"<\n" +
+	"\x18BatchGetMenuItemsRequest\x12 \n" +
+	"\x03ids\x18\x01 \x03(\rB\x0e\xbaH\v\x92\x01\b\x10d\"\x04*\x02 \x00R\x03ids\"n\n" +
+	"\x19BatchGetMenuItemsResponse\x120\n" +
+	"\n" +
+	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\rR\n" +
+	"missingIds\"\xc6\x03\n" +
+	"\x15UpdateMenuItemRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\rB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12*\n" +
+	"\vdescription\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\vdescription\x12(\n" +
+	"\x05price\x18\x04 \x01(\x01B\x12\xbaH\r\x12\v@\x01)\x00\x00\x00\x00\x00\x00\x00\x00\x18\x01R\x05price\x12(\n" +
+	"\vprice_cents\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\n" +
+	"priceCents\x123\n" +
+	"\bcurrency\x18\x06 \x01(\tB\x17\xbaH\x14\xd8\x01\x01r\x0f2\r^[A-Za-z]{3}$R\bcurrency\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\rR\n" +
+	"categoryId\x12\"\n" +
+	"\x04tags\x18\b \x03(\tB\x0e\xbaH\v\x92\x01\b\"\x06r\x04\x10\x01\x18@R\x04tags\x12?\n" +
+	"\favailability\x18\t \x03(\v2\x1b.menu.v1.AvailabilityWindowR\favailability\x12;\n" +
+	"\vupdate_mask\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"H\n" +
 	"\x16UpdateMenuItemResponse\x12.\n" +
-	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"'\n" +
-	"\x15DeleteMenuItemRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"2\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"0\n" +
+	"\x15DeleteMenuItemRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"2\n" +
 	"\x16DeleteMenuItemResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\x9d\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xdd\x01\n" +
+	"\rMenuItemEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12.\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1a.menu.v1.MenuItemEventTypeR\x04type\x12 \n" +
+	"\fmenu_item_id\x18\x03 \x01(\rR\n" +
+	"menuItemId\x12#\n" +
+	"\voccurred_at\x18\x04 \x01(\tB\x02\x18\x01R\n" +
+	"occurredAt\x129\n" +
+	"\n" +
+	"event_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\"h\n" +
+	"\x19ListMenuItemEventsRequest\x12%\n" +
+	"\x0eafter_sequence\x18\x01 \x01(\x04R\rafterSequence\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\"L\n" +
+	"\x1aListMenuItemEventsResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.menu.v1.MenuItemEventR\x06events\"b\n" +
+	"\x1bListDeletedMenuItemsRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"x\n" +
+	"\x1cListDeletedMenuItemsResponse\x120\n" +
+	"\n" +
+	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"1\n" +
+	"\x16RestoreMenuItemRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"I\n" +
+	"\x17RestoreMenuItemResponse\x12.\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"/\n" +
+	"\x14PurgeMenuItemRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"1\n" +
+	"\x15PurgeMenuItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x9f\x01\n" +
+	"\x12AdjustStockRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\x12\x16\n" +
+	"\x05delta\x18\x02 \x01(\x03H\x00R\x05delta\x12 \n" +
+	"\x06set_to\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\x05setTo\x12%\n" +
+	"\rstop_tracking\x18\x04 \x01(\bH\x00R\fstopTrackingB\x0f\n" +
+	"\x06change\x12\x05\xbaH\x02\b\x01\"E\n" +
+	"\x13AdjustStockResponse\x12.\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"[\n" +
+	"\tStockLine\x12)\n" +
+	"\fmenu_item_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\n" +
+	"menuItemId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bquantity\"I\n" +
+	"\x13ReserveStockRequest\x122\n" +
+	"\x05lines\x18\x01 \x03(\v2\x12.menu.v1.StockLineB\b\xbaH\x05\x92\x01\x02\b\x01R\x05lines\"z\n" +
+	"\x14ReserveStockResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12;\n" +
+	"\vexpire_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"D\n" +
+	"\x13ConfirmStockRequest\x12-\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\rreservationId\"\x16\n" +
+	"\x14ConfirmStockResponse\"D\n" +
+	"\x13ReleaseStockRequest\x12-\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\rreservationId\"\x16\n" +
+	"\x14ReleaseStockResponse\"\xb5\x02\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12#\n" +
+	"\rdisplay_order\x18\x04 \x01(\x05R\fdisplayOrder\x12!\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tB\x02\x18\x01R\tcreatedAt\x12!\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tB\x02\x18\x01R\tupdatedAt\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\x87\x01\n" +
+	"\x15CreateCategoryRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12*\n" +
+	"\vdescription\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\vdescription\x12#\n" +
+	"\rdisplay_order\x18\x03 \x01(\x05R\fdisplayOrder\"G\n" +
+	"\x16CreateCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"-\n" +
+	"\x12GetCategoryRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"D\n" +
+	"\x13GetCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"\x16\n" +
+	"\x14GetCategoriesRequest\"J\n" +
+	"\x15GetCategoriesResponse\x121\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x11.menu.v1.CategoryR\n" +
+	"categories\"\xa0\x01\n" +
+	"\x15UpdateCategoryRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12*\n" +
+	"\vdescription\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\vdescription\x12#\n" +
+	"\rdisplay_order\x18\x04 \x01(\x05R\fdisplayOrder\"G\n" +
+	"\x16UpdateCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"0\n" +
+	"\x15DeleteCategoryRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"2\n" +
+	"\x16DeleteCategoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\")\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"1\n" +
+	"\x10CreateTagRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x04name\"3\n" +
+	"\x11CreateTagResponse\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.menu.v1.TagR\x03tag\"\x10\n" +
+	"\x0eGetTagsRequest\"3\n" +
+	"\x0fGetTagsResponse\x12 \n" +
+	"\x04tags\x18\x01 \x03(\v2\f.menu.v1.TagR\x04tags\"J\n" +
+	"\x10UpdateTagRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x04name\"3\n" +
+	"\x11UpdateTagResponse\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.menu.v1.TagR\x03tag\"+\n" +
+	"\x10DeleteTagRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"-\n" +
+	"\x11DeleteTagResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess*\xd8\x01\n" +
+	"\tDayOfWeek\x12\x1b\n" +
+	"\x17DAY_OF_WEEK_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DAY_OF_WEEK_MONDAY\x10\x01\x12\x17\n" +
+	"\x13DAY_OF_WEEK_TUESDAY\x10\x02\x12\x19\n" +
+	"\x15DAY_OF_WEEK_WEDNESDAY\x10\x03\x12\x18\n" +
+	"\x14DAY_OF_WEEK_THURSDAY\x10\x04\x12\x16\n" +
+	"\x12DAY_OF_WEEK_FRIDAY\x10\x05\x12\x18\n" +
+	"\x14DAY_OF_WEEK_SATURDAY\x10\x06\x12\x16\n" +
+	"\x12DAY_OF_WEEK_SUNDAY\x10\a*\x9f\x01\n" +
+	"\x11MenuItemEventType\x12$\n" +
+	" MENU_ITEM_EVENT_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cMENU_ITEM_EVENT_TYPE_DELETED\x10\x01\x12!\n" +
+	"\x1dMENU_ITEM_EVENT_TYPE_RESTORED\x10\x02\x12\x1f\n" +
+	"\x1bMENU_ITEM_EVENT_TYPE_PURGED\x10\x032\xb1\x0e\n" +
 	"\vMenuService\x12Q\n" +
 	"\x0eCreateMenuItem\x12\x1e.menu.v1.CreateMenuItemRequest\x1a\x1f.menu.v1.CreateMenuItemResponse\x12H\n" +
 	"\vGetMenuItem\x12\x1b.menu.v1.GetMenuItemRequest\x1a\x1c.menu.v1.GetMenuItemResponse\x12K\n" +
-	"\fGetMenuItems\x12\x1c.menu.v1.GetMenuItemsRequest\x1a\x1d.menu.v1.GetMenuItemsResponse\x12Q\n" +
+	"\fGetMenuItems\x12\x1c.menu.v1.GetMenuItemsRequest\x1a\x1d.menu.v1.GetMenuItemsResponse\x12Z\n" +
+	"\x11BatchGetMenuItems\x12!.menu.v1.BatchGetMenuItemsRequest\x1a\".menu.v1.BatchGetMenuItemsResponse\x12Q\n" +
 	"\x0eUpdateMenuItem\x12\x1e.menu.v1.UpdateMenuItemRequest\x1a\x1f.menu.v1.UpdateMenuItemResponse\x12Q\n" +
-	"\x0eDeleteMenuItem\x12\x1e.menu.v1.DeleteMenuItemRequest\x1a\x1f.menu.v1.DeleteMenuItemResponseB\x1bZ\x19menu-service/proto/menuv1b\x06proto3"
+	"\x0eDeleteMenuItem\x12\x1e.menu.v1.DeleteMenuItemRequest\x1a\x1f.menu.v1.DeleteMenuItemResponse\x12c\n" +
+	"\x14ListDeletedMenuItems\x12$.menu.v1.ListDeletedMenuItemsRequest\x1a%.menu.v1.ListDeletedMenuItemsResponse\x12T\n" +
+	"\x0fRestoreMenuItem\x12\x1f.menu.v1.RestoreMenuItemRequest\x1a .menu.v1.RestoreMenuItemResponse\x12N\n" +
+	"\rPurgeMenuItem\x12\x1d.menu.v1.PurgeMenuItemRequest\x1a\x1e.menu.v1.PurgeMenuItemResponse\x12]\n" +
+	"\x12ListMenuItemEvents\x12\".menu.v1.ListMenuItemEventsRequest\x1a#.menu.v1.ListMenuItemEventsResponse\x12H\n" +
+	"\vAdjustStock\x12\x1b.menu.v1.AdjustStockRequest\x1a\x1c.menu.v1.AdjustStockResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.menu.v1.ReserveStockRequest\x1a\x1d.menu.v1.ReserveStockResponse\x12K\n" +
+	"\fConfirmStock\x12\x1c.menu.v1.ConfirmStockRequest\x1a\x1d.menu.v1.ConfirmStockResponse\x12K\n" +
+	"\fReleaseStock\x12\x1c.menu.v1.ReleaseStockRequest\x1a\x1d.menu.v1.ReleaseStockResponse\x12Q\n" +
+	"\x0eCreateCategory\x12\x1e.menu.v1.CreateCategoryRequest\x1a\x1f.menu.v1.CreateCategoryResponse\x12H\n" +
+	"\vGetCategory\x12\x1b.menu.v1.GetCategoryRequest\x1a\x1c.menu.v1.GetCategoryResponse\x12N\n" +
+	"\rGetCategories\x12\x1d.menu.v1.GetCategoriesRequest\x1a\x1e.menu.v1.GetCategoriesResponse\x12Q\n" +
+	"\x0eUpdateCategory\x12\x1e.menu.v1.UpdateCategoryRequest\x1a\x1f.menu.v1.UpdateCategoryResponse\x12Q\n" +
+	"\x0eDeleteCategory\x12\x1e.menu.v1.DeleteCategoryRequest\x1a\x1f.menu.v1.DeleteCategoryResponse\x12B\n" +
+	"\tCreateTag\x12\x19.menu.v1.CreateTagRequest\x1a\x1a.menu.v1.CreateTagResponse\x12<\n" +
+	"\aGetTags\x12\x17.menu.v1.GetTagsRequest\x1a\x18.menu.v1.GetTagsResponse\x12B\n" +
+	"\tUpdateTag\x12\x19.menu.v1.UpdateTagRequest\x1a\x1a.menu.v1.UpdateTagResponse\x12B\n" +
+	"\tDeleteTag\x12\x19.menu.v1.DeleteTagRequest\x1a\x1a.menu.v1.DeleteTagResponseB\x1bZ\x19menu-service/proto/menuv1b\x06proto3"
 
 var (
 	file_proto_menu_proto_rawDescOnce sync.Once
@@ -635,40 +3346,148 @@ func file_proto_menu_proto_rawDescGZIP() []byte {
 	return file_proto_menu_proto_rawDescData
 }
 
-var file_proto_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_menu_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_proto_menu_proto_goTypes = []any{
-	(*MenuItem)(nil),               // 0: menu.v1.MenuItem
-	(*CreateMenuItemRequest)(nil),  // 1: menu.v1.CreateMenuItemRequest
-	(*CreateMenuItemResponse)(nil), // 2: menu.v1.CreateMenuItemResponse
-	(*GetMenuItemRequest)(nil),     // 3: menu.v1.GetMenuItemRequest
-	(*GetMenuItemResponse)(nil),    // 4: menu.v1.GetMenuItemResponse
-	(*GetMenuItemsRequest)(nil),    // 5: menu.v1.GetMenuItemsRequest
-	(*GetMenuItemsResponse)(nil),   // 6: menu.v1.GetMenuItemsResponse
-	(*UpdateMenuItemRequest)(nil),  // 7: menu.v1.UpdateMenuItemRequest
-	(*UpdateMenuItemResponse)(nil), // 8: menu.v1.UpdateMenuItemResponse
-	(*DeleteMenuItemRequest)(nil),  // 9: menu.v1.DeleteMenuItemRequest
-	(*DeleteMenuItemResponse)(nil), // 10: menu.v1.DeleteMenuItemResponse
+	(DayOfWeek)(0),                       // 0: menu.v1.DayOfWeek
+	(MenuItemEventType)(0),               // 1: menu.v1.MenuItemEventType
+	(*AvailabilityWindow)(nil),           // 2: menu.v1.AvailabilityWindow
+	(*MenuItem)(nil),                     // 3: menu.v1.MenuItem
+	(*CreateMenuItemRequest)(nil),        // 4: menu.v1.CreateMenuItemRequest
+	(*CreateMenuItemResponse)(nil),       // 5: menu.v1.CreateMenuItemResponse
+	(*GetMenuItemRequest)(nil),           // 6: menu.v1.GetMenuItemRequest
+	(*GetMenuItemResponse)(nil),          // 7: menu.v1.GetMenuItemResponse
+	(*GetMenuItemsRequest)(nil),          // 8: menu.v1.GetMenuItemsRequest
+	(*GetMenuItemsResponse)(nil),         // 9: menu.v1.GetMenuItemsResponse
+	(*BatchGetMenuItemsRequest)(nil),     // 10: menu.v1.BatchGetMenuItemsRequest
+	(*BatchGetMenuItemsResponse)(nil),    // 11: menu.v1.BatchGetMenuItemsResponse
+	(*UpdateMenuItemRequest)(nil),        // 12: menu.v1.UpdateMenuItemRequest
+	(*UpdateMenuItemResponse)(nil),       // 13: menu.v1.UpdateMenuItemResponse
+	(*DeleteMenuItemRequest)(nil),        // 14: menu.v1.DeleteMenuItemRequest
+	(*DeleteMenuItemResponse)(nil),       // 15: menu.v1.DeleteMenuItemResponse
+	(*MenuItemEvent)(nil),                // 16: menu.v1.MenuItemEvent
+	(*ListMenuItemEventsRequest)(nil),    // 17: menu.v1.ListMenuItemEventsRequest
+	(*ListMenuItemEventsResponse)(nil),   // 18: menu.v1.ListMenuItemEventsResponse
+	(*ListDeletedMenuItemsRequest)(nil),  // 19: menu.v1.ListDeletedMenuItemsRequest
+	(*ListDeletedMenuItemsResponse)(nil), // 20: menu.v1.ListDeletedMenuItemsResponse
+	(*RestoreMenuItemRequest)(nil),       // 21: menu.v1.RestoreMenuItemRequest
+	(*RestoreMenuItemResponse)(nil),      // 22: menu.v1.RestoreMenuItemResponse
+	(*PurgeMenuItemRequest)(nil),         // 23: menu.v1.PurgeMenuItemRequest
+	(*PurgeMenuItemResponse)(nil),        // 24: menu.v1.PurgeMenuItemResponse
+	(*AdjustStockRequest)(nil),           // 25: menu.v1.AdjustStockRequest
+	(*AdjustStockResponse)(nil),          // 26: menu.v1.AdjustStockResponse
+	(*StockLine)(nil),                    // 27: menu.v1.StockLine
+	(*ReserveStockRequest)(nil),          // 28: menu.v1.ReserveStockRequest
+	(*ReserveStockResponse)(nil),         // 29: menu.v1.ReserveStockResponse
+	(*ConfirmStockRequest)(nil),          // 30: menu.v1.ConfirmStockRequest
+	(*ConfirmStockResponse)(nil),         // 31: menu.v1.ConfirmStockResponse
+	(*ReleaseStockRequest)(nil),          // 32: menu.v1.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),         // 33: menu.v1.ReleaseStockResponse
+	(*Category)(nil),                     // 34: menu.v1.Category
+	(*CreateCategoryRequest)(nil),        // 35: menu.v1.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),       // 36: menu.v1.CreateCategoryResponse
+	(*GetCategoryRequest)(nil),           // 37: menu.v1.GetCategoryRequest
+	(*GetCategoryResponse)(nil),          // 38: menu.v1.GetCategoryResponse
+	(*GetCategoriesRequest)(nil),         // 39: menu.v1.GetCategoriesRequest
+	(*GetCategoriesResponse)(nil),        // 40: menu.v1.GetCategoriesResponse
+	(*UpdateCategoryRequest)(nil),        // 41: menu.v1.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),       // 42: menu.v1.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),        // 43: menu.v1.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),       // 44: menu.v1.DeleteCategoryResponse
+	(*Tag)(nil),                          // 45: menu.v1.Tag
+	(*CreateTagRequest)(nil),             // 46: menu.v1.CreateTagRequest
+	(*CreateTagResponse)(nil),            // 47: menu.v1.CreateTagResponse
+	(*GetTagsRequest)(nil),               // 48: menu.v1.GetTagsRequest
+	(*GetTagsResponse)(nil),              // 49: menu.v1.GetTagsResponse
+	(*UpdateTagRequest)(nil),             // 50: menu.v1.UpdateTagRequest
+	(*UpdateTagResponse)(nil),            // 51: menu.v1.UpdateTagResponse
+	(*DeleteTagRequest)(nil),             // 52: menu.v1.DeleteTagRequest
+	(*DeleteTagResponse)(nil),            // 53: menu.v1.DeleteTagResponse
+	(*timestamppb.Timestamp)(nil),        // 54: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 55: google.protobuf.FieldMask
 }
 var file_proto_menu_proto_depIdxs = []int32{
-	0,  // 0: menu.v1.CreateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
-	0,  // 1: menu.v1.GetMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
-	0,  // 2: menu.v1.GetMenuItemsResponse.menu_items:type_name -> menu.v1.MenuItem
-	0,  // 3: menu.v1.UpdateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
-	1,  // 4: menu.v1.MenuService.CreateMenuItem:input_type -> menu.v1.CreateMenuItemRequest
-	3,  // 5: menu.v1.MenuService.GetMenuItem:input_type -> menu.v1.GetMenuItemRequest
-	5,  // 6: menu.v1.MenuService.GetMenuItems:input_type -> menu.v1.GetMenuItemsRequest
-	7,  // 7: menu.v1.MenuService.UpdateMenuItem:input_type -> menu.v1.UpdateMenuItemRequest
-	9,  // 8: menu.v1.MenuService.DeleteMenuItem:input_type -> menu.v1.DeleteMenuItemRequest
-	2,  // 9: menu.v1.MenuService.CreateMenuItem:output_type -> menu.v1.CreateMenuItemResponse
-	4,  // 10: menu.v1.MenuService.GetMenuItem:output_type -> menu.v1.GetMenuItemResponse
-	6,  // 11: menu.v1.MenuService.GetMenuItems:output_type -> menu.v1.GetMenuItemsResponse
-	8,  // 12: menu.v1.MenuService.UpdateMenuItem:output_type -> menu.v1.UpdateMenuItemResponse
-	10, // 13: menu.v1.MenuService.DeleteMenuItem:output_type -> menu.v1.DeleteMenuItemResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	0,  // 0: menu.v1.AvailabilityWindow.day:type_name -> menu.v1.DayOfWeek
+	2,  // 1: menu.v1.MenuItem.availability:type_name -> menu.v1.AvailabilityWindow
+	54, // 2: menu.v1.MenuItem.create_time:type_name -> google.protobuf.Timestamp
+	54, // 3: menu.v1.MenuItem.update_time:type_name -> google.protobuf.Timestamp
+	54, // 4: menu.v1.MenuItem.delete_time:type_name -> google.protobuf.Timestamp
+	2,  // 5: menu.v1.CreateMenuItemRequest.availability:type_name -> menu.v1.AvailabilityWindow
+	3,  // 6: menu.v1.CreateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	3,  // 7: menu.v1.GetMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	3,  // 8: menu.v1.GetMenuItemsResponse.menu_items:type_name -> menu.v1.MenuItem
+	3,  // 9: menu.v1.BatchGetMenuItemsResponse.menu_items:type_name -> menu.v1.MenuItem
+	2,  // 10: menu.v1.UpdateMenuItemRequest.availability:type_name -> menu.v1.AvailabilityWindow
+	55, // 11: menu.v1.UpdateMenuItemRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 12: menu.v1.UpdateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	1,  // 13: menu.v1.MenuItemEvent.type:type_name -> menu.v1.MenuItemEventType
+	54, // 14: menu.v1.MenuItemEvent.event_time:type_name -> google.protobuf.Timestamp
+	16, // 15: menu.v1.ListMenuItemEventsResponse.events:type_name -> menu.v1.MenuItemEvent
+	3,  // 16: menu.v1.ListDeletedMenuItemsResponse.menu_items:type_name -> menu.v1.MenuItem
+	3,  // 17: menu.v1.RestoreMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	3,  // 18: menu.v1.AdjustStockResponse.menu_item:type_name -> menu.v1.MenuItem
+	27, // 19: menu.v1.ReserveStockRequest.lines:type_name -> menu.v1.StockLine
+	54, // 20: menu.v1.ReserveStockResponse.expire_time:type_name -> google.protobuf.Timestamp
+	54, // 21: menu.v1.Category.create_time:type_name -> google.protobuf.Timestamp
+	54, // 22: menu.v1.Category.update_time:type_name -> google.protobuf.Timestamp
+	34, // 23: menu.v1.CreateCategoryResponse.category:type_name -> menu.v1.Category
+	34, // 24: menu.v1.GetCategoryResponse.category:type_name -> menu.v1.Category
+	34, // 25: menu.v1.GetCategoriesResponse.categories:type_name -> menu.v1.Category
+	34, // 26: menu.v1.UpdateCategoryResponse.category:type_name -> menu.v1.Category
+	45, // 27: menu.v1.CreateTagResponse.tag:type_name -> menu.v1.Tag
+	45, // 28: menu.v1.GetTagsResponse.tags:type_name -> menu.v1.Tag
+	45, // 29: menu.v1.UpdateTagResponse.tag:type_name -> menu.v1.Tag
+	4,  // 30: menu.v1.MenuService.CreateMenuItem:input_type -> menu.v1.CreateMenuItemRequest
+	6,  // 31: menu.v1.MenuService.GetMenuItem:input_type -> menu.v1.GetMenuItemRequest
+	8,  // 32: menu.v1.MenuService.GetMenuItems:input_type -> menu.v1.GetMenuItemsRequest
+	10, // 33: menu.v1.MenuService.BatchGetMenuItems:input_type -> menu.v1.BatchGetMenuItemsRequest
+	12, // 34: menu.v1.MenuService.UpdateMenuItem:input_type -> menu.v1.UpdateMenuItemRequest
+	14, // 35: menu.v1.MenuService.DeleteMenuItem:input_type -> menu.v1.DeleteMenuItemRequest
+	19, // 36: menu.v1.MenuService.ListDeletedMenuItems:input_type -> menu.v1.ListDeletedMenuItemsRequest
+	21, // 37: menu.v1.MenuService.RestoreMenuItem:input_type -> menu.v1.RestoreMenuItemRequest
+	23, // 38: menu.v1.MenuService.PurgeMenuItem:input_type -> menu.v1.PurgeMenuItemRequest
+	17, // 39: menu.v1.MenuService.ListMenuItemEvents:input_type -> menu.v1.ListMenuItemEventsRequest
+	25, // 40: menu.v1.MenuService.AdjustStock:input_type -> menu.v1.AdjustStockRequest
+	28, // 41: menu.v1.MenuService.ReserveStock:input_type -> menu.v1.ReserveStockRequest
+	30, // 42: menu.v1.MenuService.ConfirmStock:input_type -> menu.v1.ConfirmStockRequest
+	32, // 43: menu.v1.MenuService.ReleaseStock:input_type -> menu.v1.ReleaseStockRequest
+	35, // 44: menu.v1.MenuService.CreateCategory:input_type -> menu.v1.CreateCategoryRequest
+	37, // 45: menu.v1.MenuService.GetCategory:input_type -> menu.v1.GetCategoryRequest
+	39, // 46: menu.v1.MenuService.GetCategories:input_type -> menu.v1.GetCategoriesRequest
+	41, // 47: menu.v1.MenuService.UpdateCategory:input_type -> menu.v1.UpdateCategoryRequest
+	43, // 48: menu.v1.MenuService.DeleteCategory:input_type -> menu.v1.DeleteCategoryRequest
+	46, // 49: menu.v1.MenuService.CreateTag:input_type -> menu.v1.CreateTagRequest
+	48, // 50: menu.v1.MenuService.GetTags:input_type -> menu.v1.GetTagsRequest
+	50, // 51: menu.v1.MenuService.UpdateTag:input_type -> menu.v1.UpdateTagRequest
+	52, // 52: menu.v1.MenuService.DeleteTag:input_type -> menu.v1.DeleteTagRequest
+	5,  // 53: menu.v1.MenuService.CreateMenuItem:output_type -> menu.v1.CreateMenuItemResponse
+	7,  // 54: menu.v1.MenuService.GetMenuItem:output_type -> menu.v1.GetMenuItemResponse
+	9,  // 55: menu.v1.MenuService.GetMenuItems:output_type -> menu.v1.GetMenuItemsResponse
+	11, // 56: menu.v1.MenuService.BatchGetMenuItems:output_type -> menu.v1.BatchGetMenuItemsResponse
+	13, // 57: menu.v1.MenuService.UpdateMenuItem:output_type -> menu.v1.UpdateMenuItemResponse
+	15, // 58: menu.v1.MenuService.DeleteMenuItem:output_type -> menu.v1.DeleteMenuItemResponse
+	20, // 59: menu.v1.MenuService.ListDeletedMenuItems:output_type -> menu.v1.ListDeletedMenuItemsResponse
+	22, // 60: menu.v1.MenuService.RestoreMenuItem:output_type -> menu.v1.RestoreMenuItemResponse
+	24, // 61: menu.v1.MenuService.PurgeMenuItem:output_type -> menu.v1.PurgeMenuItemResponse
+	18, // 62: menu.v1.MenuService.ListMenuItemEvents:output_type -> menu.v1.ListMenuItemEventsResponse
+	26, // 63: menu.v1.MenuService.AdjustStock:output_type -> menu.v1.AdjustStockResponse
+	29, // 64: menu.v1.MenuService.ReserveStock:output_type -> menu.v1.ReserveStockResponse
+	31, // 65: menu.v1.MenuService.ConfirmStock:output_type -> menu.v1.ConfirmStockResponse
+	33, // 66: menu.v1.MenuService.ReleaseStock:output_type -> menu.v1.ReleaseStockResponse
+	36, // 67: menu.v1.MenuService.CreateCategory:output_type -> menu.v1.CreateCategoryResponse
+	38, // 68: menu.v1.MenuService.GetCategory:output_type -> menu.v1.GetCategoryResponse
+	40, // 69: menu.v1.MenuService.GetCategories:output_type -> menu.v1.GetCategoriesResponse
+	42, // 70: menu.v1.MenuService.UpdateCategory:output_type -> menu.v1.UpdateCategoryResponse
+	44, // 71: menu.v1.MenuService.DeleteCategory:output_type -> menu.v1.DeleteCategoryResponse
+	47, // 72: menu.v1.MenuService.CreateTag:output_type -> menu.v1.CreateTagResponse
+	49, // 73: menu.v1.MenuService.GetTags:output_type -> menu.v1.GetTagsResponse
+	51, // 74: menu.v1.MenuService.UpdateTag:output_type -> menu.v1.UpdateTagResponse
+	53, // 75: menu.v1.MenuService.DeleteTag:output_type -> menu.v1.DeleteTagResponse
+	53, // [53:76] is the sub-list for method output_type
+	30, // [30:53] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_proto_menu_proto_init() }
@@ -676,18 +3495,27 @@ func file_proto_menu_proto_init() {
 	if File_proto_menu_proto != nil {
 		return
 	}
+	file_proto_menu_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_menu_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_menu_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_menu_proto_msgTypes[23].OneofWrappers = []any{
+		(*AdjustStockRequest_Delta)(nil),
+		(*AdjustStockRequest_SetTo)(nil),
+		(*AdjustStockRequest_StopTracking)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_menu_proto_rawDesc), len(file_proto_menu_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_menu_proto_goTypes,
 		DependencyIndexes: file_proto_menu_proto_depIdxs,
+		EnumInfos:         file_proto_menu_proto_enumTypes,
 		MessageInfos:      file_proto_menu_proto_msgTypes,
 	}.Build()
 	File_proto_menu_proto = out.File
@@ -19,11 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MenuService_CreateMenuItem_FullMethodName = "/menu.v1.MenuService/CreateMenuItem"
-	MenuService_GetMenuItem_FullMethodName    = "/menu.v1.MenuService/GetMenuItem"
-	MenuService_GetMenuItems_FullMethodName   = "/menu.v1.MenuService/GetMenuItems"
-	MenuService_UpdateMenuItem_FullMethodName = "/menu.v1.MenuService/UpdateMenuItem"
-	MenuService_DeleteMenuItem_FullMethodName = "/menu.v1.MenuService/DeleteMenuItem"
+	MenuService_CreateMenuItem_FullMethodName       = "/menu.v1.MenuService/CreateMenuItem"
+	MenuService_GetMenuItem_FullMethodName          = "/menu.v1.MenuService/GetMenuItem"
+	MenuService_GetMenuItems_FullMethodName         = "/menu.v1.MenuService/GetMenuItems"
+	MenuService_BatchGetMenuItems_FullMethodName    = "/menu.v1.MenuService/BatchGetMenuItems"
+	MenuService_UpdateMenuItem_FullMethodName       = "/menu.v1.MenuService/UpdateMenuItem"
+	MenuService_DeleteMenuItem_FullMethodName       = "/menu.v1.MenuService/DeleteMenuItem"
+	MenuService_ListDeletedMenuItems_FullMethodName = "/menu.v1.MenuService/ListDeletedMenuItems"
+	MenuService_RestoreMenuItem_FullMethodName      = "/menu.v1.MenuService/RestoreMenuItem"
+	MenuService_PurgeMenuItem_FullMethodName        = "/menu.v1.MenuService/PurgeMenuItem"
+	MenuService_ListMenuItemEvents_FullMethodName   = "/menu.v1.MenuService/ListMenuItemEvents"
+	MenuService_AdjustStock_FullMethodName          = "/menu.v1.MenuService/AdjustStock"
+	MenuService_ReserveStock_FullMethodName         = "/menu.v1.MenuService/ReserveStock"
+	MenuService_ConfirmStock_FullMethodName         = "/menu.v1.MenuService/ConfirmStock"
+	MenuService_ReleaseStock_FullMethodName         = "/menu.v1.MenuService/ReleaseStock"
+	MenuService_CreateCategory_FullMethodName       = "/menu.v1.MenuService/CreateCategory"
+	MenuService_GetCategory_FullMethodName          = "/menu.v1.MenuService/GetCategory"
+	MenuService_GetCategories_FullMethodName        = "/menu.v1.MenuService/GetCategories"
+	MenuService_UpdateCategory_FullMethodName       = "/menu.v1.MenuService/UpdateCategory"
+	MenuService_DeleteCategory_FullMethodName       = "/menu.v1.MenuService/DeleteCategory"
+	MenuService_CreateTag_FullMethodName            = "/menu.v1.MenuService/CreateTag"
+	MenuService_GetTags_FullMethodName              = "/menu.v1.MenuService/GetTags"
+	MenuService_UpdateTag_FullMethodName            = "/menu.v1.MenuService/UpdateTag"
+	MenuService_DeleteTag_FullMethodName            = "/menu.v1.MenuService/DeleteTag"
 )
 
 // MenuServiceClient is the client API for MenuService service.
@@ -33,8 +51,48 @@ type MenuServiceClient interface {
 	CreateMenuItem(ctx context.Context, in *CreateMenuItemRequest, opts ...grpc.CallOption) (*CreateMenuItemResponse, error)
 	GetMenuItem(ctx context.Context, in *GetMenuItemRequest, opts ...grpc.CallOption) (*GetMenuItemResponse, error)
 	GetMenuItems(ctx context.Context, in *GetMenuItemsRequest, opts ...grpc.CallOption) (*GetMenuItemsResponse, error)
+	// BatchGetMenuItems looks up several menu items in one call. IDs that do
+	// not exist are reported in missing_ids instead of failing the call.
+	BatchGetMenuItems(ctx context.Context, in *BatchGetMenuItemsRequest, opts ...grpc.CallOption) (*BatchGetMenuItemsResponse, error)
 	UpdateMenuItem(ctx context.Context, in *UpdateMenuItemRequest, opts ...grpc.CallOption) (*UpdateMenuItemResponse, error)
 	DeleteMenuItem(ctx context.Context, in *DeleteMenuItemRequest, opts ...grpc.CallOption) (*DeleteMenuItemResponse, error)
+	// ListDeletedMenuItems lists menu items that have been deleted but not yet
+	// purged.
+	ListDeletedMenuItems(ctx context.Context, in *ListDeletedMenuItemsRequest, opts ...grpc.CallOption) (*ListDeletedMenuItemsResponse, error)
+	// RestoreMenuItem undoes DeleteMenuItem.
+	RestoreMenuItem(ctx context.Context, in *RestoreMenuItemRequest, opts ...grpc.CallOption) (*RestoreMenuItemResponse, error)
+	// PurgeMenuItem permanently removes a deleted menu item. Deleted items are
+	// also purged automatically once the retention period has passed.
+	PurgeMenuItem(ctx context.Context, in *PurgeMenuItemRequest, opts ...grpc.CallOption) (*PurgeMenuItemResponse, error)
+	// ListMenuItemEvents returns menu item lifecycle events after
+	// after_sequence, oldest first, so other services can follow deletions.
+	// Only cafe services may call it.
+	ListMenuItemEvents(ctx context.Context, in *ListMenuItemEventsRequest, opts ...grpc.CallOption) (*ListMenuItemEventsResponse, error)
+	// AdjustStock changes how many of an item are available.
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	// ReserveStock atomically takes stock for every line or for none of them.
+	// Items that do not track stock are always available. The reservation is
+	// released at its expire_time unless ConfirmStock is called first.
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	// ConfirmStock keeps a reservation's stock taken once the order holding it
+	// is placed, so the reservation no longer expires. Confirming twice is a
+	// no-op.
+	ConfirmStock(ctx context.Context, in *ConfirmStockRequest, opts ...grpc.CallOption) (*ConfirmStockResponse, error)
+	// ReleaseStock returns a reservation's stock. Releasing twice is a no-op.
+	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error)
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error)
+	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
+	// GetCategories lists every category in display order.
+	GetCategories(ctx context.Context, in *GetCategoriesRequest, opts ...grpc.CallOption) (*GetCategoriesResponse, error)
+	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
+	// DeleteCategory removes a category; its items are left uncategorised.
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	// GetTags lists every tag by name.
+	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error)
+	// DeleteTag removes a tag from every item that has it.
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
 }
 
 type menuServiceClient struct {
@@ -75,6 +133,16 @@ func (c *menuServiceClient) GetMenuItems(ctx context.Context, in *GetMenuItemsRe
 	return out, nil
 }
 
+func (c *menuServiceClient) BatchGetMenuItems(ctx context.Context, in *BatchGetMenuItemsRequest, opts ...grpc.CallOption) (*BatchGetMenuItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetMenuItemsResponse)
+	err := c.cc.Invoke(ctx, MenuService_BatchGetMenuItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *menuServiceClient) UpdateMenuItem(ctx context.Context, in *UpdateMenuItemRequest, opts ...grpc.CallOption) (*UpdateMenuItemResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateMenuItemResponse)
@@ -95,6 +163,176 @@ func (c *menuServiceClient) DeleteMenuItem(ctx context.Context, in *DeleteMenuIt
 	return out, nil
 }
 
+func (c *menuServiceClient) ListDeletedMenuItems(ctx context.Context, in *ListDeletedMenuItemsRequest, opts ...grpc.CallOption) (*ListDeletedMenuItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedMenuItemsResponse)
+	err := c.cc.Invoke(ctx, MenuService_ListDeletedMenuItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) RestoreMenuItem(ctx context.Context, in *RestoreMenuItemRequest, opts ...grpc.CallOption) (*RestoreMenuItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreMenuItemResponse)
+	err := c.cc.Invoke(ctx, MenuService_RestoreMenuItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) PurgeMenuItem(ctx context.Context, in *PurgeMenuItemRequest, opts ...grpc.CallOption) (*PurgeMenuItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeMenuItemResponse)
+	err := c.cc.Invoke(ctx, MenuService_PurgeMenuItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) ListMenuItemEvents(ctx context.Context, in *ListMenuItemEventsRequest, opts ...grpc.CallOption) (*ListMenuItemEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMenuItemEventsResponse)
+	err := c.cc.Invoke(ctx, MenuService_ListMenuItemEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) ConfirmStock(ctx context.Context, in *ConfirmStockRequest, opts ...grpc.CallOption) (*ConfirmStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_ConfirmStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_ReleaseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCategoryResponse)
+	err := c.cc.Invoke(ctx, MenuService_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetCategories(ctx context.Context, in *GetCategoriesRequest, opts ...grpc.CallOption) (*GetCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoriesResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCategoryResponse)
+	err := c.cc.Invoke(ctx, MenuService_UpdateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCategoryResponse)
+	err := c.cc.Invoke(ctx, MenuService_DeleteCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, MenuService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTagResponse)
+	err := c.cc.Invoke(ctx, MenuService_UpdateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, MenuService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MenuServiceServer is the server API for MenuService service.
 // All implementations must embed UnimplementedMenuServiceServer
 // for forward compatibility.
@@ -102,8 +340,48 @@ type MenuServiceServer interface {
 	CreateMenuItem(context.Context, *CreateMenuItemRequest) (*CreateMenuItemResponse, error)
 	GetMenuItem(context.Context, *GetMenuItemRequest) (*GetMenuItemResponse, error)
 	GetMenuItems(context.Context, *GetMenuItemsRequest) (*GetMenuItemsResponse, error)
+	// BatchGetMenuItems looks up several menu items in one call. IDs that do
+	// not exist are reported in missing_ids instead of failing the call.
+	BatchGetMenuItems(context.Context, *BatchGetMenuItemsRequest) (*BatchGetMenuItemsResponse, error)
 	UpdateMenuItem(context.Context, *UpdateMenuItemRequest) (*UpdateMenuItemResponse, error)
 	DeleteMenuItem(context.Context, *DeleteMenuItemRequest) (*DeleteMenuItemResponse, error)
+	// ListDeletedMenuItems lists menu items that have been deleted but not yet
+	// purged.
+	ListDeletedMenuItems(context.Context, *ListDeletedMenuItemsRequest) (*ListDeletedMenuItemsResponse, error)
+	// RestoreMenuItem undoes DeleteMenuItem.
+	RestoreMenuItem(context.Context, *RestoreMenuItemRequest) (*RestoreMenuItemResponse, error)
+	// PurgeMenuItem permanently removes a deleted menu item. Deleted items are
+	// also purged automatically once the retention period has passed.
+	PurgeMenuItem(context.Context, *PurgeMenuItemRequest) (*PurgeMenuItemResponse, error)
+	// ListMenuItemEvents returns menu item lifecycle events after
+	// after_sequence, oldest first, so other services can follow deletions.
+	// Only cafe services may call it.
+	ListMenuItemEvents(context.Context, *ListMenuItemEventsRequest) (*ListMenuItemEventsResponse, error)
+	// AdjustStock changes how many of an item are available.
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	// ReserveStock atomically takes stock for every line or for none of them.
+	// Items that do not track stock are always available. The reservation is
+	// released at its expire_time unless ConfirmStock is called first.
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	// ConfirmStock keeps a reservation's stock taken once the order holding it
+	// is placed, so the reservation no longer expires. Confirming twice is a
+	// no-op.
+	ConfirmStock(context.Context, *ConfirmStockRequest) (*ConfirmStockResponse, error)
+	// ReleaseStock returns a reservation's stock. Releasing twice is a no-op.
+	ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error)
+	CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error)
+	GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error)
+	// GetCategories lists every category in display order.
+	GetCategories(context.Context, *GetCategoriesRequest) (*GetCategoriesResponse, error)
+	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
+	// DeleteCategory removes a category; its items are left uncategorised.
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	// GetTags lists every tag by name.
+	GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error)
+	// DeleteTag removes a tag from every item that has it.
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
 	mustEmbedUnimplementedMenuServiceServer()
 }
 
@@ -123,12 +401,66 @@ func (UnimplementedMenuServiceServer) GetMenuItem(context.Context, *GetMenuItemR
 func (UnimplementedMenuServiceServer) GetMenuItems(context.Context, *GetMenuItemsRequest) (*GetMenuItemsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMenuItems not implemented")
 }
+func (UnimplementedMenuServiceServer) BatchGetMenuItems(context.Context, *BatchGetMenuItemsRequest) (*BatchGetMenuItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMenuItems not implemented")
+}
 func (UnimplementedMenuServiceServer) UpdateMenuItem(context.Context, *UpdateMenuItemRequest) (*UpdateMenuItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMenuItem not implemented")
 }
 func (UnimplementedMenuServiceServer) DeleteMenuItem(context.Context, *DeleteMenuItemRequest) (*DeleteMenuItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMenuItem not implemented")
 }
+func (UnimplementedMenuServiceServer) ListDeletedMenuItems(context.Context, *ListDeletedMenuItemsRequest) (*ListDeletedMenuItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedMenuItems not implemented")
+}
+func (UnimplementedMenuServiceServer) RestoreMenuItem(context.Context, *RestoreMenuItemRequest) (*RestoreMenuItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMenuItem not implemented")
+}
+func (UnimplementedMenuServiceServer) PurgeMenuItem(context.Context, *PurgeMenuItemRequest) (*PurgeMenuItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeMenuItem not implemented")
+}
+func (UnimplementedMenuServiceServer) ListMenuItemEvents(context.Context, *ListMenuItemEventsRequest) (*ListMenuItemEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMenuItemEvents not implemented")
+}
+func (UnimplementedMenuServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedMenuServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedMenuServiceServer) ConfirmStock(context.Context, *ConfirmStockRequest) (*ConfirmStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmStock not implemented")
+}
+func (UnimplementedMenuServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedMenuServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedMenuServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedMenuServiceServer) GetCategories(context.Context, *GetCategoriesRequest) (*GetCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategories not implemented")
+}
+func (UnimplementedMenuServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedMenuServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedMenuServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedMenuServiceServer) GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTags not implemented")
+}
+func (UnimplementedMenuServiceServer) UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
+}
+func (UnimplementedMenuServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
 func (UnimplementedMenuServiceServer) mustEmbedUnimplementedMenuServiceServer() {}
 func (UnimplementedMenuServiceServer) testEmbeddedByValue()                     {}
 
@@ -204,6 +536,24 @@ func _MenuService_GetMenuItems_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MenuService_BatchGetMenuItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMenuItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).BatchGetMenuItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_BatchGetMenuItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).BatchGetMenuItems(ctx, req.(*BatchGetMenuItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MenuService_UpdateMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateMenuItemRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +590,312 @@ func _MenuService_DeleteMenuItem_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MenuService_ListDeletedMenuItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedMenuItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).ListDeletedMenuItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_ListDeletedMenuItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).ListDeletedMenuItems(ctx, req.(*ListDeletedMenuItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_RestoreMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMenuItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).RestoreMenuItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_RestoreMenuItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).RestoreMenuItem(ctx, req.(*RestoreMenuItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_PurgeMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeMenuItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).PurgeMenuItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_PurgeMenuItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).PurgeMenuItem(ctx, req.(*PurgeMenuItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_ListMenuItemEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMenuItemEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).ListMenuItemEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_ListMenuItemEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).ListMenuItemEvents(ctx, req.(*ListMenuItemEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_ConfirmStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).ConfirmStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_ConfirmStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).ConfirmStock(ctx, req.(*ConfirmStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).ReleaseStock(ctx, req.(*ReleaseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetCategory(ctx, req.(*GetCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_GetCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetCategories(ctx, req.(*GetCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_UpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_DeleteCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_UpdateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).UpdateTag(ctx, req.(*UpdateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MenuService_ServiceDesc is the grpc.ServiceDesc for MenuService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +915,10 @@ var MenuService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMenuItems",
 			Handler:    _MenuService_GetMenuItems_Handler,
 		},
+		{
+			MethodName: "BatchGetMenuItems",
+			Handler:    _MenuService_BatchGetMenuItems_Handler,
+		},
 		{
 			MethodName: "UpdateMenuItem",
 			Handler:    _MenuService_UpdateMenuItem_Handler,
@@ -267,6 +927,74 @@ var MenuService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMenuItem",
 			Handler:    _MenuService_DeleteMenuItem_Handler,
 		},
+		{
+			MethodName: "ListDeletedMenuItems",
+			Handler:    _MenuService_ListDeletedMenuItems_Handler,
+		},
+		{
+			MethodName: "RestoreMenuItem",
+			Handler:    _MenuService_RestoreMenuItem_Handler,
+		},
+		{
+			MethodName: "PurgeMenuItem",
+			Handler:    _MenuService_PurgeMenuItem_Handler,
+		},
+		{
+			MethodName: "ListMenuItemEvents",
+			Handler:    _MenuService_ListMenuItemEvents_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _MenuService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _MenuService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ConfirmStock",
+			Handler:    _MenuService_ConfirmStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _MenuService_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _MenuService_CreateCategory_Handler,
+		},
+		{
+			MethodName: "GetCategory",
+			Handler:    _MenuService_GetCategory_Handler,
+		},
+		{
+			MethodName: "GetCategories",
+			Handler:    _MenuService_GetCategories_Handler,
+		},
+		{
+			MethodName: "UpdateCategory",
+			Handler:    _MenuService_UpdateCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _MenuService_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _MenuService_CreateTag_Handler,
+		},
+		{
+			MethodName: "GetTags",
+			Handler:    _MenuService_GetTags_Handler,
+		},
+		{
+			MethodName: "UpdateTag",
+			Handler:    _MenuService_UpdateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _MenuService_DeleteTag_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/menu.proto",
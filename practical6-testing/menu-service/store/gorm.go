@@ -0,0 +1,381 @@
+package store
+
+import (
+	"context"
+	"eventseq"
+	"log"
+	"menu-service/models"
+	"pagination"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxFeed numbers the outbox events.
+const outboxFeed = "menu_item_events"
+
+// GormStore keeps the menu in a SQL database through GORM. It works on both
+// Postgres and SQLite.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore wraps db, which must already be migrated.
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// Migrate creates or updates the tables used by GormStore.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Category{}, &models.Tag{}, &models.MenuItem{}, &models.AvailabilityWindow{},
+		&models.StockReservation{}, &models.StockReservationLine{}, &models.OutboxEvent{}); err != nil {
+		return err
+	}
+	if err := eventseq.Migrate(db, outboxFeed, &models.OutboxEvent{}); err != nil {
+		return err
+	}
+	return migrateFloatPrices(db)
+}
+
+// migrateFloatPrices moves prices from the legacy floating point price column
+// into price_cents and drops the old column.
+func migrateFloatPrices(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.MenuItem{}, "price") {
+		return nil
+	}
+
+	if err := db.Exec("UPDATE menu_items SET price_cents = ROUND(price * 100) WHERE price IS NOT NULL").Error; err != nil {
+		return err
+	}
+	log.Println("Migrated menu item prices to integer cents")
+
+	return db.Migrator().DropColumn(&models.MenuItem{}, "price")
+}
+
+func (s *GormStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *GormStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (s *GormStore) Transaction(ctx context.Context, fn func(tx MenuStore) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormStore{db: tx})
+	})
+}
+
+func (s *GormStore) CreateCategory(ctx context.Context, category *models.Category) error {
+	return s.db.WithContext(ctx).Create(category).Error
+}
+
+func (s *GormStore) GetCategory(ctx context.Context, id uint) (models.Category, error) {
+	var category models.Category
+	err := s.db.WithContext(ctx).First(&category, id).Error
+	return category, err
+}
+
+func (s *GormStore) ListCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := s.db.WithContext(ctx).Order("display_order, name, id").Find(&categories).Error
+	return categories, err
+}
+
+func (s *GormStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	return s.db.WithContext(ctx).Save(category).Error
+}
+
+func (s *GormStore) DeleteCategory(ctx context.Context, id uint) (bool, error) {
+	var deleted bool
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Category{}, id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = true
+		return tx.Model(&models.MenuItem{}).Where("category_id = ?", id).Update("category_id", nil).Error
+	})
+	return deleted, err
+}
+
+func (s *GormStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	return s.db.WithContext(ctx).Create(tag).Error
+}
+
+func (s *GormStore) GetTag(ctx context.Context, id uint) (models.Tag, error) {
+	var tag models.Tag
+	err := s.db.WithContext(ctx).First(&tag, id).Error
+	return tag, err
+}
+
+func (s *GormStore) FindTagByName(ctx context.Context, name string) (models.Tag, error) {
+	var tag models.Tag
+	err := s.db.WithContext(ctx).Where("name = ?", name).First(&tag).Error
+	return tag, err
+}
+
+func (s *GormStore) ListTags(ctx context.Context) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := s.db.WithContext(ctx).Order("name").Find(&tags).Error
+	return tags, err
+}
+
+func (s *GormStore) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	return s.db.WithContext(ctx).Save(tag).Error
+}
+
+func (s *GormStore) DeleteTag(ctx context.Context, id uint) (bool, error) {
+	var deleted bool
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM menu_item_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Tag{}, id)
+		deleted = result.RowsAffected > 0
+		return result.Error
+	})
+	return deleted, err
+}
+
+func (s *GormStore) CreateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return withDetails(tx).First(item, item.ID).Error
+	})
+}
+
+func (s *GormStore) GetMenuItem(ctx context.Context, id uint) (models.MenuItem, error) {
+	var item models.MenuItem
+	err := withDetails(s.db.WithContext(ctx)).First(&item, id).Error
+	return item, err
+}
+
+func (s *GormStore) GetMenuItemIncludingDeleted(ctx context.Context, id uint) (models.MenuItem, error) {
+	var item models.MenuItem
+	err := withDetails(s.db.WithContext(ctx).Unscoped()).First(&item, id).Error
+	return item, err
+}
+
+func (s *GormStore) GetMenuItems(ctx context.Context, ids []uint) ([]models.MenuItem, error) {
+	var items []models.MenuItem
+	err := withDetails(s.db.WithContext(ctx)).Where("id IN ?", ids).Find(&items).Error
+	return items, err
+}
+
+func (s *GormStore) ListMenuItems(ctx context.Context, filter MenuItemFilter, page Page) ([]models.MenuItem, error) {
+	query := s.db.WithContext(ctx).Model(&models.MenuItem{})
+	if filter.MinPriceCents != nil {
+		query = query.Where("price_cents >= ?", *filter.MinPriceCents)
+	}
+	if filter.MaxPriceCents != nil {
+		query = query.Where("price_cents <= ?", *filter.MaxPriceCents)
+	}
+	if filter.NameContains != "" {
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\'`, "%"+strings.ToLower(pagination.EscapeLike(filter.NameContains))+"%")
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.Tag != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM menu_item_tags JOIN tags ON tags.id = menu_item_tags.tag_id
+			WHERE menu_item_tags.menu_item_id = menu_items.id AND tags.name = ?)`, filter.Tag)
+	}
+	if at := filter.AvailableAt; at != nil {
+		minute := at.Hour()*60 + at.Minute()
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM availability_windows w WHERE w.menu_item_id = menu_items.id)
+			OR EXISTS (SELECT 1 FROM availability_windows w WHERE w.menu_item_id = menu_items.id
+				AND w.weekday = ? AND w.start_minute <= ? AND w.end_minute > ?)`, at.Weekday(), minute, minute)
+	}
+
+	var items []models.MenuItem
+	err := withDetails(pagination.Apply(query, page)).Find(&items).Error
+	return items, err
+}
+
+func (s *GormStore) ListDeletedMenuItems(ctx context.Context, page Page) ([]models.MenuItem, error) {
+	query := s.db.WithContext(ctx).Unscoped().Model(&models.MenuItem{}).Where("deleted_at IS NOT NULL")
+
+	var items []models.MenuItem
+	err := withDetails(pagination.Apply(query, page)).Find(&items).Error
+	return items, err
+}
+
+func (s *GormStore) UpdateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	return s.db.WithContext(ctx).Omit(clause.Associations).Save(item).Error
+}
+
+func (s *GormStore) SetMenuItemTags(ctx context.Context, id uint, tags []models.Tag) error {
+	item := models.MenuItem{Model: gorm.Model{ID: id}}
+	return s.db.WithContext(ctx).Model(&item).Association("Tags").Replace(tags)
+}
+
+func (s *GormStore) SetAvailability(ctx context.Context, id uint, windows []models.AvailabilityWindow) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("menu_item_id = ?", id).Delete(&models.AvailabilityWindow{}).Error; err != nil {
+			return err
+		}
+		if len(windows) == 0 {
+			return nil
+		}
+		windows = append([]models.AvailabilityWindow(nil), windows...)
+		for i := range windows {
+			windows[i].ID = 0
+			windows[i].MenuItemID = id
+		}
+		return tx.Create(&windows).Error
+	})
+}
+
+func (s *GormStore) DeleteMenuItem(ctx context.Context, id uint) (bool, error) {
+	result := s.db.WithContext(ctx).Delete(&models.MenuItem{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) RestoreMenuItem(ctx context.Context, id uint) (bool, error) {
+	// Guarded on deleted_at so a concurrent restore is not applied twice
+	result := s.db.WithContext(ctx).Unscoped().Model(&models.MenuItem{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) PurgeDeletedMenuItems(ctx context.Context, cutoff time.Time) (int64, error) {
+	return s.purgeMenuItems(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at <= ?", cutoff)
+	})
+}
+
+func (s *GormStore) PurgeMenuItem(ctx context.Context, id uint) (int64, error) {
+	return s.purgeMenuItems(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+}
+
+// purgeMenuItems hard-deletes the soft-deleted menu items selected by scope.
+func (s *GormStore) purgeMenuItems(ctx context.Context, scope func(*gorm.DB) *gorm.DB) (int64, error) {
+	var purged int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Unscoped().Model(&models.MenuItem{}).Scopes(scope).
+			Where("deleted_at IS NOT NULL").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Exec("DELETE FROM menu_item_tags WHERE menu_item_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("menu_item_id IN ?", ids).Delete(&models.AvailabilityWindow{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&models.MenuItem{}, ids)
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		for _, id := range ids {
+			if err := recordEvent(tx, models.EventMenuItemPurged, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+func (s *GormStore) RecordEvent(ctx context.Context, eventType string, menuItemID uint) error {
+	return recordEvent(s.db.WithContext(ctx), eventType, menuItemID)
+}
+
+// recordEvent numbers the event from the outbox feed's counter, whose row
+// stays locked until tx commits. Events therefore become visible in sequence
+// order and a reader paging by sequence never skips one still committing.
+func recordEvent(tx *gorm.DB, eventType string, menuItemID uint) error {
+	return tx.Transaction(func(tx *gorm.DB) error {
+		sequence, err := eventseq.Next(tx, outboxFeed)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.OutboxEvent{Sequence: sequence, Type: eventType, MenuItemID: menuItemID}).Error
+	})
+}
+
+func (s *GormStore) ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.WithContext(ctx).Where("sequence > ?", afterSequence).Order("sequence").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (s *GormStore) AdjustStock(ctx context.Context, id uint, delta int64) (bool, error) {
+	// Applied in the database so concurrent adjustments are not lost
+	result := s.db.WithContext(ctx).Model(&models.MenuItem{}).
+		Where("id = ? AND stock IS NOT NULL AND stock + ? >= 0", id, delta).
+		Update("stock", gorm.Expr("stock + ?", delta))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) SetStock(ctx context.Context, id uint, stock *int64) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&models.MenuItem{}).Where("id = ?", id).Update("stock", stock)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) CreateReservation(ctx context.Context, reservation *models.StockReservation) error {
+	return s.db.WithContext(ctx).Create(reservation).Error
+}
+
+func (s *GormStore) GetReservation(ctx context.Context, id string) (models.StockReservation, error) {
+	var reservation models.StockReservation
+	err := s.db.WithContext(ctx).Preload("Lines").First(&reservation, "id = ?", id).Error
+	return reservation, err
+}
+
+func (s *GormStore) ReleaseReservation(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&models.StockReservation{}).
+		Where("id = ? AND released_at IS NULL", id).
+		Update("released_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) ConfirmReservation(ctx context.Context, id string) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&models.StockReservation{}).
+		Where("id = ? AND released_at IS NULL", id).
+		Update("expires_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) ExpiredReservations(ctx context.Context, at time.Time) ([]string, error) {
+	var ids []string
+	err := s.db.WithContext(ctx).Model(&models.StockReservation{}).
+		Where("released_at IS NULL AND expires_at <= ?", at).
+		Order("expires_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (s *GormStore) ExpireReservation(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&models.StockReservation{}).
+		Where("id = ? AND released_at IS NULL AND expires_at <= ?", id, at).
+		Update("released_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// withDetails loads the tags and availability windows of queried items.
+func withDetails(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		Preload("Availability", func(db *gorm.DB) *gorm.DB { return db.Order("weekday, start_minute") })
+}
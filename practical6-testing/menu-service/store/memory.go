@@ -0,0 +1,697 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"dberr"
+	"maps"
+	"menu-service/models"
+	"slices"
+	"storedriver"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStore keeps the menu in process memory for development and tests.
+type MemoryStore struct {
+	storedriver.Memory
+	data *memoryData
+}
+
+// memoryData is the content of a MemoryStore. Records are stored by value,
+// without their associations, and replaced rather than modified, so a
+// shallow copy of the maps is a snapshot.
+type memoryData struct {
+	categories   map[uint]models.Category
+	tags         map[uint]models.Tag
+	items        map[uint]models.MenuItem
+	itemTags     map[uint][]uint
+	availability map[uint][]models.AvailabilityWindow
+	reservations map[string]models.StockReservation
+	events       []models.OutboxEvent
+	ids          storedriver.MemoryIDs
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Memory: storedriver.NewMemory(),
+		data: &memoryData{
+			categories:   make(map[uint]models.Category),
+			tags:         make(map[uint]models.Tag),
+			items:        make(map[uint]models.MenuItem),
+			itemTags:     make(map[uint][]uint),
+			availability: make(map[uint][]models.AvailabilityWindow),
+			reservations: make(map[string]models.StockReservation),
+			ids:          storedriver.MemoryIDs{},
+		},
+	}
+}
+
+func (d memoryData) Clone() memoryData {
+	return memoryData{
+		categories:   maps.Clone(d.categories),
+		tags:         maps.Clone(d.tags),
+		items:        maps.Clone(d.items),
+		itemTags:     maps.Clone(d.itemTags),
+		availability: maps.Clone(d.availability),
+		reservations: maps.Clone(d.reservations),
+		events:       slices.Clone(d.events),
+		ids:          maps.Clone(d.ids),
+	}
+}
+
+func (s *MemoryStore) Transaction(ctx context.Context, fn func(tx MenuStore) error) error {
+	return storedriver.MemoryTransaction(ctx, s.Memory, s.data, func(tx storedriver.Memory) error {
+		return fn(&MemoryStore{Memory: tx, data: s.data})
+	})
+}
+
+func (s *MemoryStore) CreateCategory(ctx context.Context, category *models.Category) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	category.ID = s.data.ids.Next("categories")
+	category.CreatedAt, category.UpdatedAt = now, now
+	s.data.categories[category.ID] = *category
+	return nil
+}
+
+func (s *MemoryStore) GetCategory(ctx context.Context, id uint) (models.Category, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.Category{}, err
+	}
+	defer unlock()
+
+	category, ok := s.data.categories[id]
+	if !ok {
+		return models.Category{}, ErrNotFound
+	}
+	return category, nil
+}
+
+func (s *MemoryStore) ListCategories(ctx context.Context) ([]models.Category, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	categories := slices.Collect(maps.Values(s.data.categories))
+	slices.SortFunc(categories, func(a, b models.Category) int {
+		return cmp.Or(cmp.Compare(a.DisplayOrder, b.DisplayOrder), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return categories, nil
+}
+
+func (s *MemoryStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.categories[category.ID]; !ok {
+		return ErrNotFound
+	}
+	category.UpdatedAt = time.Now()
+	s.data.categories[category.ID] = *category
+	return nil
+}
+
+func (s *MemoryStore) DeleteCategory(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if _, ok := s.data.categories[id]; !ok {
+		return false, nil
+	}
+	delete(s.data.categories, id)
+	for itemID, item := range s.data.items {
+		if item.CategoryID != nil && *item.CategoryID == id {
+			item.CategoryID = nil
+			s.data.items[itemID] = item
+		}
+	}
+	return true, nil
+}
+
+func (s *MemoryStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.tagNameTaken(tag.Name, 0) {
+		return &dberr.UniqueViolation{Field: "name"}
+	}
+	tag.ID = s.data.ids.Next("tags")
+	tag.CreatedAt = time.Now()
+	s.data.tags[tag.ID] = *tag
+	return nil
+}
+
+// tagNameTaken reports whether a tag other than id uses name.
+func (s *MemoryStore) tagNameTaken(name string, id uint) bool {
+	for _, tag := range s.data.tags {
+		if tag.ID != id && tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryStore) GetTag(ctx context.Context, id uint) (models.Tag, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.Tag{}, err
+	}
+	defer unlock()
+
+	tag, ok := s.data.tags[id]
+	if !ok {
+		return models.Tag{}, ErrNotFound
+	}
+	return tag, nil
+}
+
+func (s *MemoryStore) FindTagByName(ctx context.Context, name string) (models.Tag, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.Tag{}, err
+	}
+	defer unlock()
+
+	for _, tag := range s.data.tags {
+		if tag.Name == name {
+			return tag, nil
+		}
+	}
+	return models.Tag{}, ErrNotFound
+}
+
+func (s *MemoryStore) ListTags(ctx context.Context) ([]models.Tag, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.sortedTags(slices.Collect(maps.Keys(s.data.tags))), nil
+}
+
+// sortedTags returns the tags with the given IDs by name.
+func (s *MemoryStore) sortedTags(ids []uint) []models.Tag {
+	var tags []models.Tag
+	for _, id := range ids {
+		tags = append(tags, s.data.tags[id])
+	}
+	slices.SortFunc(tags, func(a, b models.Tag) int { return cmp.Compare(a.Name, b.Name) })
+	return tags
+}
+
+func (s *MemoryStore) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.tags[tag.ID]; !ok {
+		return ErrNotFound
+	}
+	if s.tagNameTaken(tag.Name, tag.ID) {
+		return &dberr.UniqueViolation{Field: "name"}
+	}
+	s.data.tags[tag.ID] = *tag
+	return nil
+}
+
+func (s *MemoryStore) DeleteTag(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if _, ok := s.data.tags[id]; !ok {
+		return false, nil
+	}
+	delete(s.data.tags, id)
+	for itemID, tagIDs := range s.data.itemTags {
+		if slices.Contains(tagIDs, id) {
+			s.data.itemTags[itemID] = slices.DeleteFunc(slices.Clone(tagIDs), func(tagID uint) bool { return tagID == id })
+		}
+	}
+	return true, nil
+}
+
+func (s *MemoryStore) CreateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, tag := range item.Tags {
+		if _, ok := s.data.tags[tag.ID]; !ok {
+			return ErrNotFound
+		}
+	}
+	now := time.Now()
+	item.ID = s.data.ids.Next("menu_items")
+	item.CreatedAt, item.UpdatedAt = now, now
+	s.data.items[item.ID] = stripDetails(*item)
+
+	var tagIDs []uint
+	for _, tag := range item.Tags {
+		if !slices.Contains(tagIDs, tag.ID) {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	s.data.itemTags[item.ID] = tagIDs
+	s.setAvailability(item.ID, item.Availability)
+
+	*item = s.withDetails(s.data.items[item.ID])
+	return nil
+}
+
+// stripDetails copies item for storage without its associations or shared
+// pointers.
+func stripDetails(item models.MenuItem) models.MenuItem {
+	item.Tags, item.Availability = nil, nil
+	if item.Stock != nil {
+		stock := *item.Stock
+		item.Stock = &stock
+	}
+	if item.CategoryID != nil {
+		categoryID := *item.CategoryID
+		item.CategoryID = &categoryID
+	}
+	return item
+}
+
+// withDetails returns a copy of a stored item with its tags and
+// availability windows.
+func (s *MemoryStore) withDetails(item models.MenuItem) models.MenuItem {
+	item = stripDetails(item)
+	item.Tags = s.sortedTags(s.data.itemTags[item.ID])
+	item.Availability = slices.Clone(s.data.availability[item.ID])
+	return item
+}
+
+func (s *MemoryStore) GetMenuItem(ctx context.Context, id uint) (models.MenuItem, error) {
+	item, err := s.GetMenuItemIncludingDeleted(ctx, id)
+	if err == nil && item.DeletedAt.Valid {
+		return models.MenuItem{}, ErrNotFound
+	}
+	return item, err
+}
+
+func (s *MemoryStore) GetMenuItemIncludingDeleted(ctx context.Context, id uint) (models.MenuItem, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.MenuItem{}, err
+	}
+	defer unlock()
+
+	item, ok := s.data.items[id]
+	if !ok {
+		return models.MenuItem{}, ErrNotFound
+	}
+	return s.withDetails(item), nil
+}
+
+func (s *MemoryStore) GetMenuItems(ctx context.Context, ids []uint) ([]models.MenuItem, error) {
+	return s.listMenuItems(ctx, Page{}, func(item models.MenuItem) bool {
+		return !item.DeletedAt.Valid && slices.Contains(ids, item.ID)
+	})
+}
+
+func (s *MemoryStore) ListMenuItems(ctx context.Context, filter MenuItemFilter, page Page) ([]models.MenuItem, error) {
+	name := strings.ToLower(filter.NameContains)
+	return s.listMenuItems(ctx, page, func(item models.MenuItem) bool {
+		return !item.DeletedAt.Valid &&
+			(filter.MinPriceCents == nil || item.PriceCents >= *filter.MinPriceCents) &&
+			(filter.MaxPriceCents == nil || item.PriceCents <= *filter.MaxPriceCents) &&
+			strings.Contains(strings.ToLower(item.Name), name) &&
+			(filter.CategoryID == 0 || item.CategoryID != nil && *item.CategoryID == filter.CategoryID) &&
+			(filter.Tag == "" || slices.ContainsFunc(item.Tags, func(tag models.Tag) bool { return tag.Name == filter.Tag })) &&
+			(filter.AvailableAt == nil || item.AvailableAt(*filter.AvailableAt))
+	})
+}
+
+func (s *MemoryStore) ListDeletedMenuItems(ctx context.Context, page Page) ([]models.MenuItem, error) {
+	return s.listMenuItems(ctx, page, func(item models.MenuItem) bool {
+		return item.DeletedAt.Valid
+	})
+}
+
+// listMenuItems returns the menu items matching keep, which sees them with
+// their details, in ID order, limited to page when it has a limit.
+func (s *MemoryStore) listMenuItems(ctx context.Context, page Page, keep func(models.MenuItem) bool) ([]models.MenuItem, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var items []models.MenuItem
+	for _, id := range slices.Sorted(maps.Keys(s.data.items)) {
+		if id <= page.AfterID {
+			continue
+		}
+		item := s.withDetails(s.data.items[id])
+		if !keep(item) {
+			continue
+		}
+		items = append(items, item)
+		if len(items) == page.Limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (s *MemoryStore) UpdateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.items[item.ID]; !ok {
+		return ErrNotFound
+	}
+	item.UpdatedAt = time.Now()
+	s.data.items[item.ID] = stripDetails(*item)
+	return nil
+}
+
+func (s *MemoryStore) SetMenuItemTags(ctx context.Context, id uint, tags []models.Tag) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.items[id]; !ok {
+		return ErrNotFound
+	}
+	var tagIDs []uint
+	for _, tag := range tags {
+		if _, ok := s.data.tags[tag.ID]; !ok {
+			return ErrNotFound
+		}
+		if !slices.Contains(tagIDs, tag.ID) {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	s.data.itemTags[id] = tagIDs
+	return nil
+}
+
+func (s *MemoryStore) SetAvailability(ctx context.Context, id uint, windows []models.AvailabilityWindow) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.items[id]; !ok {
+		return ErrNotFound
+	}
+	s.setAvailability(id, windows)
+	return nil
+}
+
+// setAvailability stores copies of windows for the item, sorted as they are
+// read back.
+func (s *MemoryStore) setAvailability(id uint, windows []models.AvailabilityWindow) {
+	var stored []models.AvailabilityWindow
+	for _, window := range windows {
+		window.ID = s.data.ids.Next("availability_windows")
+		window.MenuItemID = id
+		stored = append(stored, window)
+	}
+	slices.SortStableFunc(stored, func(a, b models.AvailabilityWindow) int {
+		return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.StartMinute, b.StartMinute))
+	})
+	s.data.availability[id] = stored
+}
+
+func (s *MemoryStore) DeleteMenuItem(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	item, ok := s.data.items[id]
+	if !ok || item.DeletedAt.Valid {
+		return false, nil
+	}
+	item.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	s.data.items[id] = item
+	return true, nil
+}
+
+func (s *MemoryStore) RestoreMenuItem(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	item, ok := s.data.items[id]
+	if !ok || !item.DeletedAt.Valid {
+		return false, nil
+	}
+	item.DeletedAt = gorm.DeletedAt{}
+	s.data.items[id] = item
+	return true, nil
+}
+
+func (s *MemoryStore) PurgeDeletedMenuItems(ctx context.Context, cutoff time.Time) (int64, error) {
+	return s.purgeMenuItems(ctx, func(item models.MenuItem) bool {
+		return !item.DeletedAt.Time.After(cutoff)
+	})
+}
+
+func (s *MemoryStore) PurgeMenuItem(ctx context.Context, id uint) (int64, error) {
+	return s.purgeMenuItems(ctx, func(item models.MenuItem) bool {
+		return item.ID == id
+	})
+}
+
+// purgeMenuItems hard-deletes the soft-deleted menu items selected by match.
+func (s *MemoryStore) purgeMenuItems(ctx context.Context, match func(models.MenuItem) bool) (int64, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var purged int64
+	for _, id := range slices.Sorted(maps.Keys(s.data.items)) {
+		item := s.data.items[id]
+		if !item.DeletedAt.Valid || !match(item) {
+			continue
+		}
+		delete(s.data.itemTags, id)
+		delete(s.data.availability, id)
+		delete(s.data.items, id)
+		s.recordEvent(models.EventMenuItemPurged, id)
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *MemoryStore) RecordEvent(ctx context.Context, eventType string, menuItemID uint) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.recordEvent(eventType, menuItemID)
+	return nil
+}
+
+func (s *MemoryStore) recordEvent(eventType string, menuItemID uint) {
+	// The store lock serialises writers, so IDs already follow commit order.
+	id := s.data.ids.Next("outbox_events")
+	s.data.events = append(s.data.events, models.OutboxEvent{
+		ID:         id,
+		Sequence:   uint64(id),
+		Type:       eventType,
+		MenuItemID: menuItemID,
+		CreatedAt:  time.Now(),
+	})
+}
+
+func (s *MemoryStore) ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var events []models.OutboxEvent
+	for _, event := range s.data.events {
+		if event.Sequence > afterSequence && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) AdjustStock(ctx context.Context, id uint, delta int64) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	item, ok := s.data.items[id]
+	if !ok || item.DeletedAt.Valid || item.Stock == nil || *item.Stock+delta < 0 {
+		return false, nil
+	}
+	stock := *item.Stock + delta
+	item.Stock = &stock
+	item.UpdatedAt = time.Now()
+	s.data.items[id] = item
+	return true, nil
+}
+
+func (s *MemoryStore) SetStock(ctx context.Context, id uint, stock *int64) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	item, ok := s.data.items[id]
+	if !ok || item.DeletedAt.Valid {
+		return false, nil
+	}
+	item.Stock = stock
+	item.UpdatedAt = time.Now()
+	s.data.items[id] = stripDetails(item)
+	return true, nil
+}
+
+func (s *MemoryStore) CreateReservation(ctx context.Context, reservation *models.StockReservation) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, ok := s.data.reservations[reservation.ID]; ok {
+		return &dberr.UniqueViolation{Field: "id"}
+	}
+	reservation.CreatedAt = time.Now()
+	for i := range reservation.Lines {
+		reservation.Lines[i].ID = s.data.ids.Next("stock_reservation_lines")
+		reservation.Lines[i].ReservationID = reservation.ID
+	}
+	stored := *reservation
+	stored.Lines = slices.Clone(reservation.Lines)
+	s.data.reservations[reservation.ID] = stored
+	return nil
+}
+
+func (s *MemoryStore) GetReservation(ctx context.Context, id string) (models.StockReservation, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.StockReservation{}, err
+	}
+	defer unlock()
+
+	reservation, ok := s.data.reservations[id]
+	if !ok {
+		return models.StockReservation{}, ErrNotFound
+	}
+	reservation.Lines = slices.Clone(reservation.Lines)
+	return reservation, nil
+}
+
+func (s *MemoryStore) ReleaseReservation(ctx context.Context, id string, at time.Time) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	reservation, ok := s.data.reservations[id]
+	if !ok || reservation.ReleasedAt != nil {
+		return false, nil
+	}
+	reservation.ReleasedAt = &at
+	s.data.reservations[id] = reservation
+	return true, nil
+}
+
+func (s *MemoryStore) ConfirmReservation(ctx context.Context, id string) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	reservation, ok := s.data.reservations[id]
+	if !ok || reservation.ReleasedAt != nil {
+		return false, nil
+	}
+	reservation.ExpiresAt = nil
+	s.data.reservations[id] = reservation
+	return true, nil
+}
+
+func (s *MemoryStore) ExpiredReservations(ctx context.Context, at time.Time) ([]string, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var expired []models.StockReservation
+	for _, reservation := range s.data.reservations {
+		if reservation.ReleasedAt == nil && reservation.ExpiresAt != nil && !reservation.ExpiresAt.After(at) {
+			expired = append(expired, reservation)
+		}
+	}
+	slices.SortFunc(expired, func(a, b models.StockReservation) int { return a.ExpiresAt.Compare(*b.ExpiresAt) })
+	ids := make([]string, len(expired))
+	for i, reservation := range expired {
+		ids[i] = reservation.ID
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) ExpireReservation(ctx context.Context, id string, at time.Time) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	reservation, ok := s.data.reservations[id]
+	if !ok || reservation.ReleasedAt != nil || reservation.ExpiresAt == nil || reservation.ExpiresAt.After(at) {
+		return false, nil
+	}
+	reservation.ReleasedAt = &at
+	s.data.reservations[id] = reservation
+	return true, nil
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"storedriver"
+
+	"gorm.io/gorm"
+)
+
+// Backends builds a MenuStore on each store driver.
+var Backends = storedriver.Backends[MenuStore]{
+	Migrate:   Migrate,
+	NewGorm:   func(db *gorm.DB) MenuStore { return NewGormStore(db) },
+	NewMemory: func() MenuStore { return NewMemoryStore() },
+}
+
+// Open opens the menu store selected by cfg.
+func Open(cfg storedriver.Config) (MenuStore, error) {
+	return storedriver.Open(cfg, Backends)
+}
@@ -0,0 +1,131 @@
+// Package store persists the menu: categories, tags, menu items with their
+// availability windows and stock, stock reservations and lifecycle events.
+// The gRPC handlers depend only on MenuStore.
+package store
+
+import (
+	"context"
+	"menu-service/models"
+	"pagination"
+	"storedriver"
+	"time"
+)
+
+// ErrNotFound is returned when no row matches.
+var ErrNotFound = storedriver.ErrNotFound
+
+// DefaultDeletedRetention is how long deleted menu items can be restored
+// before they are purged.
+const DefaultDeletedRetention = 30 * 24 * time.Hour
+
+// Page selects the rows a list reads, in ID order.
+type Page = pagination.Page
+
+// MenuItemFilter narrows ListMenuItems. Zero fields match every item.
+type MenuItemFilter struct {
+	MinPriceCents *int64
+	MaxPriceCents *int64
+	// NameContains matches part of the name case-insensitively, with no
+	// wildcards.
+	NameContains string
+	CategoryID   uint
+	// Tag is the normalized name of a tag the item must have.
+	Tag string
+	// AvailableAt keeps items that can be ordered at the time, which must
+	// be in the cafe's time zone.
+	AvailableAt *time.Time
+}
+
+// MenuStore is the persistence used by the menu service. Menu items are
+// returned with their tags, sorted by name, and availability windows,
+// sorted by weekday and start. Reads of menu items skip deleted ones unless
+// the method says otherwise.
+type MenuStore interface {
+	storedriver.Store[MenuStore]
+
+	CreateCategory(ctx context.Context, category *models.Category) error
+	GetCategory(ctx context.Context, id uint) (models.Category, error)
+	// ListCategories returns every category by display order, then name.
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	// DeleteCategory deletes a category, leaving its menu items without
+	// one, and reports whether it existed.
+	DeleteCategory(ctx context.Context, id uint) (bool, error)
+
+	// CreateTag inserts tag. Tag names are unique.
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	GetTag(ctx context.Context, id uint) (models.Tag, error)
+	FindTagByName(ctx context.Context, name string) (models.Tag, error)
+	// ListTags returns every tag by name.
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	UpdateTag(ctx context.Context, tag *models.Tag) error
+	// DeleteTag deletes a tag, removing it from the menu items that have
+	// it, and reports whether it existed.
+	DeleteTag(ctx context.Context, id uint) (bool, error)
+
+	// CreateMenuItem inserts item with its availability windows and links
+	// it to item.Tags, which must already exist. item is reloaded
+	// afterwards.
+	CreateMenuItem(ctx context.Context, item *models.MenuItem) error
+	GetMenuItem(ctx context.Context, id uint) (models.MenuItem, error)
+	// GetMenuItemIncludingDeleted also returns soft-deleted items.
+	GetMenuItemIncludingDeleted(ctx context.Context, id uint) (models.MenuItem, error)
+	// GetMenuItems returns the items with the given IDs that exist, in no
+	// particular order.
+	GetMenuItems(ctx context.Context, ids []uint) ([]models.MenuItem, error)
+	ListMenuItems(ctx context.Context, filter MenuItemFilter, page Page) ([]models.MenuItem, error)
+	ListDeletedMenuItems(ctx context.Context, page Page) ([]models.MenuItem, error)
+	// UpdateMenuItem saves the item's own fields; its tags and availability
+	// are left alone.
+	UpdateMenuItem(ctx context.Context, item *models.MenuItem) error
+	// SetMenuItemTags replaces the item's tags, which must already exist.
+	SetMenuItemTags(ctx context.Context, id uint, tags []models.Tag) error
+	// SetAvailability replaces the item's availability windows.
+	SetAvailability(ctx context.Context, id uint, windows []models.AvailabilityWindow) error
+	// DeleteMenuItem soft-deletes an item and reports whether one was
+	// deleted.
+	DeleteMenuItem(ctx context.Context, id uint) (bool, error)
+	// RestoreMenuItem undeletes an item and reports whether a deleted one
+	// was found.
+	RestoreMenuItem(ctx context.Context, id uint) (bool, error)
+	// PurgeDeletedMenuItems permanently removes menu items deleted at or
+	// before cutoff, with their tags and availability windows, and returns
+	// how many were removed. A MenuItemPurged event is recorded for each.
+	// Stock reservation lines are kept as a record of past orders.
+	PurgeDeletedMenuItems(ctx context.Context, cutoff time.Time) (int64, error)
+	// PurgeMenuItem permanently removes a deleted menu item like
+	// PurgeDeletedMenuItems. It returns 0 when no deleted item has the ID.
+	PurgeMenuItem(ctx context.Context, id uint) (int64, error)
+
+	// RecordEvent writes a menu item event to the outbox. It should be
+	// called in the transaction that makes the change the event describes.
+	RecordEvent(ctx context.Context, eventType string, menuItemID uint) error
+	// ListEvents returns up to limit events after the sequence number, in
+	// sequence order.
+	ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error)
+
+	// AdjustStock adds delta to the stock of an item that tracks it, unless
+	// that would take it below zero, and reports whether it did. The check
+	// and change are atomic with respect to other adjustments.
+	AdjustStock(ctx context.Context, id uint, delta int64) (bool, error)
+	// SetStock replaces the item's stock, nil to stop tracking it, and
+	// reports whether the item exists.
+	SetStock(ctx context.Context, id uint, stock *int64) (bool, error)
+	CreateReservation(ctx context.Context, reservation *models.StockReservation) error
+	// GetReservation returns a reservation with its lines.
+	GetReservation(ctx context.Context, id string) (models.StockReservation, error)
+	// ReleaseReservation marks a reservation released at the given time
+	// and reports whether it was still held. Only one of several concurrent
+	// calls succeeds.
+	ReleaseReservation(ctx context.Context, id string, at time.Time) (bool, error)
+	// ConfirmReservation clears the expiry of a reservation and reports
+	// whether it was still held.
+	ConfirmReservation(ctx context.Context, id string) (bool, error)
+	// ExpiredReservations returns the IDs of held reservations that expired
+	// at or before at.
+	ExpiredReservations(ctx context.Context, at time.Time) ([]string, error)
+	// ExpireReservation releases a held reservation at the given time if it
+	// expired by then, and reports whether it did. A reservation confirmed
+	// in the meantime is kept.
+	ExpireReservation(ctx context.Context, id string, at time.Time) (bool, error)
+}
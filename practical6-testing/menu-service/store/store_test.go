@@ -0,0 +1,16 @@
+package store_test
+
+import (
+	"menu-service/store"
+	"menu-service/store/storetest"
+	"storedriver/storedrivertest"
+	"testing"
+)
+
+// TestStore also runs against the database in TEST_POSTGRES_DSN, whose
+// tables it drops.
+func TestStore(t *testing.T) {
+	storedrivertest.Run(t, store.Backends, storetest.Run,
+		"categories", "tags", "menu_items", "menu_item_tags", "availability_windows",
+		"stock_reservations", "stock_reservation_lines", "outbox_events", "event_sequences")
+}
@@ -0,0 +1,564 @@
+// Package storetest is the conformance suite every store.MenuStore
+// implementation must pass.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"menu-service/models"
+	"menu-service/store"
+	"testing"
+	"time"
+
+	"dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Run runs the suite against stores returned by newStore, which must be
+// empty and independent of each other.
+func Run(t *testing.T, newStore func(t *testing.T) store.MenuStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.MenuStore)
+	}{
+		{"Ping", testPing},
+		{"Close", testClose},
+		{"Categories", testCategories},
+		{"Tags", testTags},
+		{"CreateAndGetMenuItem", testCreateAndGetMenuItem},
+		{"ListMenuItems", testListMenuItems},
+		{"UpdateMenuItem", testUpdateMenuItem},
+		{"DeleteRestorePurge", testDeleteRestorePurge},
+		{"Events", testEvents},
+		{"Stock", testStock},
+		{"Reservations", testReservations},
+		{"ReservationExpiry", testReservationExpiry},
+		{"TransactionRollback", testTransactionRollback},
+		{"CancelledContext", testCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func createTag(t *testing.T, s store.MenuStore, name string) models.Tag {
+	t.Helper()
+	tag := models.Tag{Name: name}
+	require.NoError(t, s.CreateTag(context.Background(), &tag))
+	return tag
+}
+
+func createMenuItem(t *testing.T, s store.MenuStore, item models.MenuItem) models.MenuItem {
+	t.Helper()
+	if item.Currency == "" {
+		item.Currency = models.DefaultCurrency
+	}
+	require.NoError(t, s.CreateMenuItem(context.Background(), &item))
+	return item
+}
+
+func ids(items []models.MenuItem) []uint {
+	var ids []uint
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func tagNames(item models.MenuItem) []string {
+	var names []string
+	for _, tag := range item.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testCategories(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	drinks := models.Category{Name: "Drinks", DisplayOrder: 2}
+	require.NoError(t, s.CreateCategory(ctx, &drinks))
+	assert.NotZero(t, drinks.ID)
+	cakes := models.Category{Name: "Cakes", DisplayOrder: 2}
+	require.NoError(t, s.CreateCategory(ctx, &cakes))
+	breakfast := models.Category{Name: "Breakfast", DisplayOrder: 1}
+	require.NoError(t, s.CreateCategory(ctx, &breakfast))
+
+	categories, err := s.ListCategories(ctx)
+	require.NoError(t, err)
+	require.Len(t, categories, 3)
+	assert.Equal(t, []string{"Breakfast", "Cakes", "Drinks"},
+		[]string{categories[0].Name, categories[1].Name, categories[2].Name})
+
+	drinks.Description = "Hot and cold"
+	require.NoError(t, s.UpdateCategory(ctx, &drinks))
+	got, err := s.GetCategory(ctx, drinks.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hot and cold", got.Description)
+
+	item := createMenuItem(t, s, models.MenuItem{Name: "Latte", CategoryID: &drinks.ID})
+	deleted, err := s.DeleteCategory(ctx, drinks.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = s.GetCategory(ctx, drinks.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	got2, err := s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got2.CategoryID, "items of a deleted category are uncategorised")
+
+	deleted, err = s.DeleteCategory(ctx, drinks.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func testTags(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	vegan := createTag(t, s, "vegan")
+	hot := createTag(t, s, "hot")
+
+	err := s.CreateTag(ctx, &models.Tag{Name: "vegan"})
+	st := dberr.Status(err, "tag")
+	assert.Equal(t, codes.AlreadyExists, status.Code(st))
+	assert.Equal(t, "name", dberr.ConflictingField(st))
+
+	found, err := s.FindTagByName(ctx, "hot")
+	require.NoError(t, err)
+	assert.Equal(t, hot.ID, found.ID)
+	_, err = s.FindTagByName(ctx, "cold")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	tags, err := s.ListTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "hot", tags[0].Name)
+
+	hot.Name = "spicy"
+	require.NoError(t, s.UpdateTag(ctx, &hot))
+	got, err := s.GetTag(ctx, hot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "spicy", got.Name)
+
+	item := createMenuItem(t, s, models.MenuItem{Name: "Chilli", Tags: []models.Tag{vegan, hot}})
+	deleted, err := s.DeleteTag(ctx, vegan.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	got2, err := s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spicy"}, tagNames(got2))
+
+	deleted, err = s.DeleteTag(ctx, vegan.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func testCreateAndGetMenuItem(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	vegan := createTag(t, s, "vegan")
+	hot := createTag(t, s, "hot")
+
+	item := createMenuItem(t, s, models.MenuItem{
+		Name:       "Soup",
+		PriceCents: 450,
+		Stock:      ptr(int64(3)),
+		Tags:       []models.Tag{vegan, hot},
+		Availability: []models.AvailabilityWindow{
+			{Weekday: time.Tuesday, StartMinute: 720, EndMinute: 840},
+			{Weekday: time.Monday, StartMinute: 720, EndMinute: 840},
+		},
+	})
+	assert.NotZero(t, item.ID)
+	assert.WithinDuration(t, time.Now(), item.CreatedAt, time.Minute)
+	assert.Equal(t, []string{"hot", "vegan"}, tagNames(item), "tags are sorted by name")
+	require.Len(t, item.Availability, 2)
+	assert.Equal(t, time.Monday, item.Availability[0].Weekday, "windows are sorted by weekday")
+
+	got, err := s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Soup", got.Name)
+	assert.Equal(t, int64(450), got.PriceCents)
+	require.NotNil(t, got.Stock)
+	assert.Equal(t, int64(3), *got.Stock)
+	assert.Equal(t, []string{"hot", "vegan"}, tagNames(got))
+	assert.Len(t, got.Availability, 2)
+
+	_, err = s.GetMenuItem(ctx, item.ID+100)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	other := createMenuItem(t, s, models.MenuItem{Name: "Bread"})
+	batch, err := s.GetMenuItems(ctx, []uint{item.ID, other.ID, other.ID + 100})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{item.ID, other.ID}, ids(batch))
+}
+
+func testListMenuItems(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	drinks := models.Category{Name: "Drinks"}
+	require.NoError(t, s.CreateCategory(ctx, &drinks))
+	vegan := createTag(t, s, "vegan")
+
+	latte := createMenuItem(t, s, models.MenuItem{Name: "Latte", PriceCents: 350, CategoryID: &drinks.ID})
+	oatLatte := createMenuItem(t, s, models.MenuItem{Name: "Oat Latte", PriceCents: 400, CategoryID: &drinks.ID,
+		Tags: []models.Tag{vegan}})
+	lunch := createMenuItem(t, s, models.MenuItem{Name: "100% Lunch", PriceCents: 900,
+		Availability: []models.AvailabilityWindow{{Weekday: time.Monday, StartMinute: 720, EndMinute: 840}}})
+	deleted := createMenuItem(t, s, models.MenuItem{Name: "Old Latte", PriceCents: 300})
+	_, err := s.DeleteMenuItem(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	list := func(filter store.MenuItemFilter, page store.Page) []uint {
+		t.Helper()
+		items, err := s.ListMenuItems(ctx, filter, page)
+		require.NoError(t, err)
+		return ids(items)
+	}
+
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID, lunch.ID}, list(store.MenuItemFilter{}, store.Page{}))
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID}, list(store.MenuItemFilter{}, store.Page{Limit: 2}))
+	assert.Equal(t, []uint{oatLatte.ID, lunch.ID}, list(store.MenuItemFilter{}, store.Page{AfterID: latte.ID}))
+
+	assert.Equal(t, []uint{oatLatte.ID, lunch.ID}, list(store.MenuItemFilter{MinPriceCents: ptr(int64(400))}, store.Page{}))
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID}, list(store.MenuItemFilter{MaxPriceCents: ptr(int64(400))}, store.Page{}))
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID}, list(store.MenuItemFilter{NameContains: "LATTE"}, store.Page{}))
+	assert.Equal(t, []uint{lunch.ID}, list(store.MenuItemFilter{NameContains: "0%"}, store.Page{}))
+	assert.Empty(t, list(store.MenuItemFilter{NameContains: "L_tte"}, store.Page{}), "wildcards are literal")
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID}, list(store.MenuItemFilter{CategoryID: drinks.ID}, store.Page{}))
+	assert.Equal(t, []uint{oatLatte.ID}, list(store.MenuItemFilter{Tag: "vegan"}, store.Page{}))
+
+	mondayNoon := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	tuesdayNoon := mondayNoon.AddDate(0, 0, 1)
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID, lunch.ID}, list(store.MenuItemFilter{AvailableAt: &mondayNoon}, store.Page{}))
+	assert.Equal(t, []uint{latte.ID, oatLatte.ID}, list(store.MenuItemFilter{AvailableAt: &tuesdayNoon}, store.Page{}))
+}
+
+func testUpdateMenuItem(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	vegan := createTag(t, s, "vegan")
+	hot := createTag(t, s, "hot")
+	item := createMenuItem(t, s, models.MenuItem{
+		Name:         "Soup",
+		Tags:         []models.Tag{vegan},
+		Availability: []models.AvailabilityWindow{{Weekday: time.Monday, StartMinute: 720, EndMinute: 840}},
+	})
+
+	item.Name = "Tomato Soup"
+	item.Tags = nil
+	item.Availability = nil
+	require.NoError(t, s.UpdateMenuItem(ctx, &item))
+	got, err := s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Tomato Soup", got.Name)
+	assert.Equal(t, []string{"vegan"}, tagNames(got), "UpdateMenuItem leaves tags alone")
+	assert.Len(t, got.Availability, 1, "UpdateMenuItem leaves availability alone")
+
+	require.NoError(t, s.SetMenuItemTags(ctx, item.ID, []models.Tag{hot}))
+	require.NoError(t, s.SetAvailability(ctx, item.ID, []models.AvailabilityWindow{
+		{Weekday: time.Friday, StartMinute: 0, EndMinute: 60},
+		{Weekday: time.Friday, StartMinute: 600, EndMinute: 660},
+	}))
+	got, err = s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Tomato Soup", got.Name)
+	assert.Equal(t, []string{"hot"}, tagNames(got))
+	require.Len(t, got.Availability, 2)
+	assert.Equal(t, time.Friday, got.Availability[0].Weekday)
+
+	require.NoError(t, s.SetMenuItemTags(ctx, item.ID, nil))
+	require.NoError(t, s.SetAvailability(ctx, item.ID, nil))
+	got, err = s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Tags)
+	assert.Empty(t, got.Availability)
+}
+
+func testDeleteRestorePurge(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	vegan := createTag(t, s, "vegan")
+	item := createMenuItem(t, s, models.MenuItem{
+		Name:         "Soup",
+		Tags:         []models.Tag{vegan},
+		Availability: []models.AvailabilityWindow{{Weekday: time.Monday, StartMinute: 720, EndMinute: 840}},
+	})
+	old := createMenuItem(t, s, models.MenuItem{Name: "Stew"})
+
+	deleted, err := s.DeleteMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = s.DeleteMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	_, err = s.GetMenuItem(ctx, item.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	got, err := s.GetMenuItemIncludingDeleted(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, got.DeletedAt.Valid)
+	assert.Equal(t, []string{"vegan"}, tagNames(got))
+	batch, err := s.GetMenuItems(ctx, []uint{item.ID})
+	require.NoError(t, err)
+	assert.Empty(t, batch)
+
+	deletedItems, err := s.ListDeletedMenuItems(ctx, store.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{item.ID}, ids(deletedItems))
+
+	restored, err := s.RestoreMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	restored, err = s.RestoreMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.False(t, restored)
+
+	// Only deleted items are purged, and only once past the cutoff
+	purged, err := s.PurgeMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	_, err = s.DeleteMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	_, err = s.DeleteMenuItem(ctx, old.ID)
+	require.NoError(t, err)
+	purged, err = s.PurgeDeletedMenuItems(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	purged, err = s.PurgeMenuItem(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	purged, err = s.PurgeDeletedMenuItems(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = s.GetMenuItemIncludingDeleted(ctx, item.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.GetTag(ctx, vegan.ID)
+	assert.NoError(t, err, "tags outlive the items that had them")
+
+	// A new item must not inherit the purged item's details
+	fresh := createMenuItem(t, s, models.MenuItem{Name: "Bread"})
+	assert.Empty(t, fresh.Tags)
+	assert.Empty(t, fresh.Availability)
+
+	events, err := s.ListEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.EventMenuItemPurged, events[0].Type)
+	assert.Equal(t, old.ID, events[0].MenuItemID)
+	assert.Equal(t, item.ID, events[1].MenuItemID)
+}
+
+func testEvents(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	for _, id := range []uint{1, 2, 3} {
+		require.NoError(t, s.RecordEvent(ctx, models.EventMenuItemDeleted, id))
+	}
+
+	events, err := s.ListEvents(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint(1), events[0].MenuItemID)
+	assert.Equal(t, events[0].Sequence+1, events[1].Sequence)
+	last := events[1].Sequence
+
+	events, err = s.ListEvents(ctx, last, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint(3), events[0].MenuItemID)
+	assert.WithinDuration(t, time.Now(), events[0].CreatedAt, time.Minute)
+
+	// A rolled back event leaves no gap in the sequence
+	errRollback := errors.New("rollback")
+	err = s.Transaction(ctx, func(tx store.MenuStore) error {
+		require.NoError(t, tx.RecordEvent(ctx, models.EventMenuItemDeleted, 4))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	require.NoError(t, s.RecordEvent(ctx, models.EventMenuItemDeleted, 5))
+
+	events, err = s.ListEvents(ctx, last+1, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint(5), events[0].MenuItemID)
+	assert.Equal(t, last+2, events[0].Sequence)
+}
+
+func testStock(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	tracked := createMenuItem(t, s, models.MenuItem{Name: "Muffin", Stock: ptr(int64(2))})
+	untracked := createMenuItem(t, s, models.MenuItem{Name: "Coffee"})
+
+	stock := func(id uint) *int64 {
+		t.Helper()
+		item, err := s.GetMenuItem(ctx, id)
+		require.NoError(t, err)
+		return item.Stock
+	}
+
+	applied, err := s.AdjustStock(ctx, tracked.ID, -2)
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, int64(0), *stock(tracked.ID))
+
+	applied, err = s.AdjustStock(ctx, tracked.ID, -1)
+	require.NoError(t, err)
+	assert.False(t, applied, "stock may not go below zero")
+	assert.Equal(t, int64(0), *stock(tracked.ID))
+
+	applied, err = s.AdjustStock(ctx, untracked.ID, 5)
+	require.NoError(t, err)
+	assert.False(t, applied, "untracked stock is not adjusted")
+	assert.Nil(t, stock(untracked.ID))
+
+	found, err := s.SetStock(ctx, untracked.ID, ptr(int64(10)))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(10), *stock(untracked.ID))
+
+	found, err = s.SetStock(ctx, tracked.ID, nil)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Nil(t, stock(tracked.ID))
+
+	found, err = s.SetStock(ctx, tracked.ID+100, ptr(int64(1)))
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// Stock read from one item must not change when the item is adjusted
+	item, err := s.GetMenuItem(ctx, untracked.ID)
+	require.NoError(t, err)
+	_, err = s.AdjustStock(ctx, untracked.ID, -4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), *item.Stock)
+	assert.Equal(t, int64(6), *stock(untracked.ID))
+}
+
+func testReservations(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	item := createMenuItem(t, s, models.MenuItem{Name: "Muffin", Stock: ptr(int64(5))})
+
+	reservation := models.StockReservation{
+		ID:    "r1",
+		Lines: []models.StockReservationLine{{MenuItemID: item.ID, Quantity: 2}},
+	}
+	require.NoError(t, s.CreateReservation(ctx, &reservation))
+	err := s.CreateReservation(ctx, &models.StockReservation{ID: "r1"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(dberr.Status(err, "reservation")))
+
+	got, err := s.GetReservation(ctx, "r1")
+	require.NoError(t, err)
+	assert.Nil(t, got.ReleasedAt)
+	require.Len(t, got.Lines, 1)
+	assert.Equal(t, item.ID, got.Lines[0].MenuItemID)
+	assert.Equal(t, int64(2), got.Lines[0].Quantity)
+
+	_, err = s.GetReservation(ctx, "missing")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	released, err := s.ReleaseReservation(ctx, "r1", time.Now())
+	require.NoError(t, err)
+	assert.True(t, released)
+	released, err = s.ReleaseReservation(ctx, "r1", time.Now())
+	require.NoError(t, err)
+	assert.False(t, released, "a reservation is released once")
+
+	got, err = s.GetReservation(ctx, "r1")
+	require.NoError(t, err)
+	assert.NotNil(t, got.ReleasedAt)
+}
+
+func testReservationExpiry(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	now := time.Now()
+	for id, expiry := range map[string]time.Time{"late": now.Add(-time.Minute), "early": now.Add(-time.Hour), "held": now.Add(time.Hour)} {
+		require.NoError(t, s.CreateReservation(ctx, &models.StockReservation{ID: id, ExpiresAt: ptr(expiry)}))
+	}
+	require.NoError(t, s.CreateReservation(ctx, &models.StockReservation{ID: "confirmed", ExpiresAt: ptr(now.Add(-time.Hour))}))
+	confirmed, err := s.ConfirmReservation(ctx, "confirmed")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	expired, err := s.ExpiredReservations(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"early", "late"}, expired, "oldest expiry first")
+
+	released, err := s.ExpireReservation(ctx, "held", now)
+	require.NoError(t, err)
+	assert.False(t, released, "a reservation is kept until it expires")
+	released, err = s.ExpireReservation(ctx, "confirmed", now)
+	require.NoError(t, err)
+	assert.False(t, released, "a confirmed reservation does not expire")
+	released, err = s.ExpireReservation(ctx, "early", now)
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	expired, err = s.ExpiredReservations(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"late"}, expired)
+
+	// A released reservation can no longer be confirmed
+	confirmed, err = s.ConfirmReservation(ctx, "early")
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
+
+func testTransactionRollback(t *testing.T, s store.MenuStore) {
+	ctx := context.Background()
+	item := createMenuItem(t, s, models.MenuItem{Name: "Muffin", Stock: ptr(int64(5))})
+	errAbort := errors.New("abort")
+
+	err := s.Transaction(ctx, func(tx store.MenuStore) error {
+		if _, err := tx.AdjustStock(ctx, item.ID, -3); err != nil {
+			return err
+		}
+		if err := tx.CreateTag(ctx, &models.Tag{Name: "vegan"}); err != nil {
+			return err
+		}
+		if err := tx.RecordEvent(ctx, models.EventMenuItemDeleted, item.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	got, err := s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), *got.Stock)
+	tags, err := s.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+	events, err := s.ListEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	err = s.Transaction(ctx, func(tx store.MenuStore) error {
+		_, err := tx.AdjustStock(ctx, item.ID, -3)
+		return err
+	})
+	require.NoError(t, err)
+	got, err = s.GetMenuItem(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), *got.Stock)
+}
+
+func testCancelledContext(t *testing.T, s store.MenuStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.GetMenuItem(ctx, 1)
+	assert.Equal(t, codes.Canceled, status.Code(dberr.Status(err, "menu item")))
+}
+
+func testPing(t *testing.T, s store.MenuStore) {
+	require.NoError(t, s.Ping(context.Background()))
+}
+
+func testClose(t *testing.T, s store.MenuStore) {
+	require.NoError(t, s.Close())
+}
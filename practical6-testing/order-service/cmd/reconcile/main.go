@@ -0,0 +1,79 @@
+// Command reconcile reports orders that reference users or menu items which
+// no longer exist, and with -repair flags them as deleted. It exits with
+// status 1 when it finds orphans it did not repair.
+//
+// It reads the same STORE_DRIVER, DB_*, SQLITE_PATH and *_SERVICE_ADDR
+// variables as order-service.
+// Looking up users needs a cafe owner's access token in ACCESS_TOKEN, as
+// returned by Login.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"storedriver"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/consistency"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "flag orphaned references as deleted instead of only reporting them")
+	timeout := flag.Duration("timeout", 5*time.Minute, "give up after this long")
+	flag.Parse()
+
+	orderStore, err := store.Open(storedriver.FromEnv("orderdb", "orders.db"))
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+
+	userConn, err := grpc.Dial(getEnv("USER_SERVICE_ADDR", "localhost:50051"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userConn.Close()
+	menuConn, err := grpc.Dial(getEnv("MENU_SERVICE_ADDR", "localhost:50052"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to menu service: %v", err)
+	}
+	defer menuConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if token := os.Getenv("ACCESS_TOKEN"); token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+
+	report, err := consistency.Reconcile(ctx, orderStore, userv1.NewUserServiceClient(userConn), menuv1.NewMenuServiceClient(menuConn), *repair)
+	if err != nil {
+		log.Fatalf("Reconciliation failed: %v", err)
+	}
+
+	fmt.Printf("Orphaned users: %v\n", report.OrphanedUsers)
+	fmt.Printf("Orphaned menu items: %v\n", report.OrphanedMenuItems)
+	if report.Repaired {
+		fmt.Println("Flagged the orders referencing them as deleted.")
+	} else if len(report.OrphanedUsers) > 0 || len(report.OrphanedMenuItems) > 0 {
+		fmt.Println("Run with -repair to flag them.")
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,178 @@
+package consistency
+
+import (
+	"context"
+	"testing"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/models"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeUsers serves a fixed user event feed and user directory.
+type fakeUsers struct {
+	userv1.UserServiceClient
+	events []*userv1.UserEvent
+	known  map[uint32]bool
+	err    error
+}
+
+func (f *fakeUsers) ListUserEvents(ctx context.Context, req *userv1.ListUserEventsRequest, opts ...grpc.CallOption) (*userv1.ListUserEventsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	resp := &userv1.ListUserEventsResponse{}
+	for _, event := range f.events {
+		if event.Sequence > req.AfterSequence && len(resp.Events) < int(req.PageSize) {
+			resp.Events = append(resp.Events, event)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeUsers) GetUser(ctx context.Context, req *userv1.GetUserRequest, opts ...grpc.CallOption) (*userv1.GetUserResponse, error) {
+	if !f.known[req.Id] {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &userv1.GetUserResponse{User: &userv1.User{Id: req.Id}}, nil
+}
+
+// fakeMenu serves a fixed menu item event feed and menu.
+type fakeMenu struct {
+	menuv1.MenuServiceClient
+	events []*menuv1.MenuItemEvent
+	known  map[uint32]bool
+}
+
+func (f *fakeMenu) ListMenuItemEvents(ctx context.Context, req *menuv1.ListMenuItemEventsRequest, opts ...grpc.CallOption) (*menuv1.ListMenuItemEventsResponse, error) {
+	resp := &menuv1.ListMenuItemEventsResponse{}
+	for _, event := range f.events {
+		if event.Sequence > req.AfterSequence && len(resp.Events) < int(req.PageSize) {
+			resp.Events = append(resp.Events, event)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeMenu) BatchGetMenuItems(ctx context.Context, req *menuv1.BatchGetMenuItemsRequest, opts ...grpc.CallOption) (*menuv1.BatchGetMenuItemsResponse, error) {
+	resp := &menuv1.BatchGetMenuItemsResponse{}
+	for _, id := range req.Ids {
+		if f.known[id] {
+			resp.MenuItems = append(resp.MenuItems, &menuv1.MenuItem{Id: id})
+		} else {
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+	return resp, nil
+}
+
+func createOrder(t *testing.T, orders store.OrderStore, userID uint, menuItemIDs ...uint) models.Order {
+	order := models.Order{UserID: userID, Status: models.StatusPending}
+	for _, id := range menuItemIDs {
+		order.OrderItems = append(order.OrderItems, models.OrderItem{MenuItemID: id, MenuItemName: "Item", Quantity: 1})
+	}
+	require.NoError(t, orders.CreateOrder(context.Background(), &order))
+	return order
+}
+
+func loadOrder(t *testing.T, orders store.OrderStore, id uint) models.Order {
+	order, err := orders.GetOrder(context.Background(), id)
+	require.NoError(t, err)
+	return order
+}
+
+func TestConsumer(t *testing.T) {
+	orders := store.NewMemoryStore()
+	ctx := context.Background()
+
+	purged := createOrder(t, orders, 7, 3)
+	restored := createOrder(t, orders, 8, 4)
+	cancelledBy := uint(7)
+	cancelled := createOrder(t, orders, 9, 4)
+	cancelled.Status, cancelled.CancelledBy = models.StatusCancelled, &cancelledBy
+	_, err := orders.TransitionOrder(ctx, &cancelled, models.StatusPending)
+	require.NoError(t, err)
+
+	users := &fakeUsers{events: []*userv1.UserEvent{
+		{Sequence: 1, Type: userv1.UserEventType_USER_EVENT_TYPE_DELETED, UserId: 7},
+		{Sequence: 2, Type: userv1.UserEventType_USER_EVENT_TYPE_DELETED, UserId: 8},
+		{Sequence: 3, Type: userv1.UserEventType_USER_EVENT_TYPE_RESTORED, UserId: 8},
+		{Sequence: 4, Type: userv1.UserEventType_USER_EVENT_TYPE_PURGED, UserId: 7},
+	}}
+	menu := &fakeMenu{events: []*menuv1.MenuItemEvent{
+		{Sequence: 1, Type: menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED, MenuItemId: 3},
+	}}
+	consumer := NewConsumer(orders, users, menu)
+	consumer.BatchSize = 2
+
+	applied, err := consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, applied)
+	assert.True(t, loadOrder(t, orders, restored.ID).CustomerDeleted, "deleted users are flagged")
+
+	applied, err = consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+
+	applied, err = consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, applied, "events are applied once")
+
+	order := loadOrder(t, orders, purged.ID)
+	assert.Zero(t, order.UserID, "purged users are anonymised")
+	assert.True(t, order.CustomerDeleted)
+	assert.True(t, order.OrderItems[0].MenuItemDeleted)
+	assert.False(t, loadOrder(t, orders, restored.ID).CustomerDeleted, "restored users are unflagged")
+	assert.Nil(t, loadOrder(t, orders, cancelled.ID).CancelledBy)
+	assert.False(t, loadOrder(t, orders, cancelled.ID).OrderItems[0].MenuItemDeleted)
+
+	t.Run("failing feed does not hold back the other", func(t *testing.T) {
+		users.err = status.Error(codes.Unavailable, "user service down")
+		menu.events = append(menu.events, &menuv1.MenuItemEvent{Sequence: 2, Type: menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED, MenuItemId: 4})
+
+		applied, err := consumer.Poll(ctx)
+		assert.ErrorContains(t, err, "user service down")
+		assert.Equal(t, 1, applied)
+		assert.True(t, loadOrder(t, orders, restored.ID).OrderItems[0].MenuItemDeleted)
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	orders := store.NewMemoryStore()
+	ctx := context.Background()
+
+	kept := createOrder(t, orders, 1, 10)
+	orphaned := createOrder(t, orders, 2, 10, 11)
+	users := &fakeUsers{known: map[uint32]bool{1: true}}
+	menu := &fakeMenu{known: map[uint32]bool{10: true}}
+
+	report, err := Reconcile(ctx, orders, users, menu, false)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{2}, report.OrphanedUsers)
+	assert.Equal(t, []uint{11}, report.OrphanedMenuItems)
+	assert.False(t, report.Repaired)
+	assert.False(t, loadOrder(t, orders, orphaned.ID).CustomerDeleted, "reporting changes nothing")
+
+	report, err = Reconcile(ctx, orders, users, menu, true)
+	require.NoError(t, err)
+	assert.True(t, report.Repaired)
+
+	order := loadOrder(t, orders, orphaned.ID)
+	assert.True(t, order.CustomerDeleted)
+	assert.Equal(t, uint(2), order.UserID, "a missed purge is not assumed")
+	assert.False(t, order.OrderItems[0].MenuItemDeleted)
+	assert.True(t, order.OrderItems[1].MenuItemDeleted)
+	assert.False(t, loadOrder(t, orders, kept.ID).CustomerDeleted)
+
+	report, err = Reconcile(ctx, orders, users, menu, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.OrphanedUsers)
+	assert.Empty(t, report.OrphanedMenuItems)
+}
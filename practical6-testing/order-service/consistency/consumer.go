@@ -0,0 +1,162 @@
+// Package consistency keeps orders consistent with the users and menu items
+// they reference, which live in the databases of user-service and
+// menu-service.
+package consistency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc"
+)
+
+// Event feed names stored in EventCursor.Source.
+const (
+	SourceUsers     = "user-service"
+	SourceMenuItems = "menu-service"
+)
+
+const (
+	defaultBatchSize = 100
+	defaultInterval  = 10 * time.Second
+)
+
+// Consumer follows the user and menu item event feeds and applies deletions,
+// restores and purges to the orders that reference them. The position in
+// each feed is saved in the same transaction as the changes an event makes,
+// so every event is applied exactly once.
+type Consumer struct {
+	Store store.OrderStore
+	Users userv1.UserServiceClient
+	Menu  menuv1.MenuServiceClient
+	// CallOptions are passed on every call, typically to authenticate as a
+	// service.
+	CallOptions []grpc.CallOption
+	// BatchSize is the most events read from each feed per poll.
+	BatchSize int32
+	// Interval is how often the feeds are polled.
+	Interval time.Duration
+}
+
+func NewConsumer(store store.OrderStore, users userv1.UserServiceClient, menu menuv1.MenuServiceClient, opts ...grpc.CallOption) *Consumer {
+	return &Consumer{
+		Store:       store,
+		Users:       users,
+		Menu:        menu,
+		CallOptions: opts,
+		BatchSize:   defaultBatchSize,
+		Interval:    defaultInterval,
+	}
+}
+
+// Run polls both feeds until ctx is cancelled.
+func (c *Consumer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Poll(ctx); err != nil {
+			log.Printf("Consistency consumer: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll applies the next batch of events from each feed and returns how many
+// were applied. A failing feed does not hold back the other.
+func (c *Consumer) Poll(ctx context.Context) (int, error) {
+	users, userErr := c.pollUsers(ctx)
+	menuItems, menuErr := c.pollMenuItems(ctx)
+	return users + menuItems, errors.Join(userErr, menuErr)
+}
+
+func (c *Consumer) pollUsers(ctx context.Context) (int, error) {
+	after, err := c.cursor(ctx, SourceUsers)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.Users.ListUserEvents(ctx, &userv1.ListUserEventsRequest{AfterSequence: after, PageSize: c.BatchSize}, c.CallOptions...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list user events: %w", err)
+	}
+
+	for i, event := range resp.Events {
+		err := c.apply(ctx, SourceUsers, event.Sequence, func(tx store.OrderStore) error {
+			userID := uint(event.UserId)
+			switch event.Type {
+			case userv1.UserEventType_USER_EVENT_TYPE_DELETED:
+				return tx.FlagDeletedUser(ctx, userID, true)
+			case userv1.UserEventType_USER_EVENT_TYPE_RESTORED:
+				return tx.FlagDeletedUser(ctx, userID, false)
+			case userv1.UserEventType_USER_EVENT_TYPE_PURGED:
+				return tx.AnonymiseUser(ctx, userID)
+			}
+			log.Printf("Skipping user event %d of unknown type %v", event.Sequence, event.Type)
+			return nil
+		})
+		if err != nil {
+			return i, fmt.Errorf("failed to apply user event %d: %w", event.Sequence, err)
+		}
+	}
+	return len(resp.Events), nil
+}
+
+func (c *Consumer) pollMenuItems(ctx context.Context) (int, error) {
+	after, err := c.cursor(ctx, SourceMenuItems)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.Menu.ListMenuItemEvents(ctx, &menuv1.ListMenuItemEventsRequest{AfterSequence: after, PageSize: c.BatchSize}, c.CallOptions...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list menu item events: %w", err)
+	}
+
+	for i, event := range resp.Events {
+		err := c.apply(ctx, SourceMenuItems, event.Sequence, func(tx store.OrderStore) error {
+			menuItemID := uint(event.MenuItemId)
+			switch event.Type {
+			case menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_DELETED, menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_PURGED:
+				return tx.FlagDeletedMenuItem(ctx, menuItemID, true)
+			case menuv1.MenuItemEventType_MENU_ITEM_EVENT_TYPE_RESTORED:
+				return tx.FlagDeletedMenuItem(ctx, menuItemID, false)
+			}
+			log.Printf("Skipping menu item event %d of unknown type %v", event.Sequence, event.Type)
+			return nil
+		})
+		if err != nil {
+			return i, fmt.Errorf("failed to apply menu item event %d: %w", event.Sequence, err)
+		}
+	}
+	return len(resp.Events), nil
+}
+
+// cursor returns the last sequence applied from source, 0 before the first.
+func (c *Consumer) cursor(ctx context.Context, source string) (uint64, error) {
+	sequence, err := c.Store.EventCursor(ctx, source)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load %s cursor: %w", source, err)
+	}
+	return sequence, nil
+}
+
+// apply runs change and moves the source's cursor to sequence in one
+// transaction.
+func (c *Consumer) apply(ctx context.Context, source string, sequence uint64, change func(tx store.OrderStore) error) error {
+	return c.Store.Transaction(ctx, func(tx store.OrderStore) error {
+		if err := change(tx); err != nil {
+			return err
+		}
+		return tx.SaveEventCursor(ctx, source, sequence)
+	})
+}
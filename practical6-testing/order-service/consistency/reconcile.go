@@ -0,0 +1,90 @@
+package consistency
+
+import (
+	"context"
+	"fmt"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchGetLimit is the most IDs BatchGetMenuItems accepts per call.
+const batchGetLimit = 100
+
+// Report lists references from orders to users and menu items that their
+// service no longer returns, although the orders are not flagged as such.
+type Report struct {
+	OrphanedUsers     []uint
+	OrphanedMenuItems []uint
+	// Repaired is set when the orphans have been flagged.
+	Repaired bool
+}
+
+// Reconcile looks up every user and menu item referenced by an order that
+// is not already flagged as deleted, to catch events that were never
+// applied. With repair set, orphans are flagged as though their deletion
+// event had arrived. A missed purge cannot be told apart from a missed
+// deletion, so orphaned users are flagged but not anonymised.
+func Reconcile(ctx context.Context, orders store.OrderStore, users userv1.UserServiceClient, menu menuv1.MenuServiceClient, repair bool, opts ...grpc.CallOption) (*Report, error) {
+	report := &Report{}
+
+	userIDs, err := orders.ReferencedUserIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order users: %w", err)
+	}
+	for _, id := range userIDs {
+		_, err := users.GetUser(ctx, &userv1.GetUserRequest{Id: uint32(id)}, opts...)
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.NotFound:
+			report.OrphanedUsers = append(report.OrphanedUsers, id)
+		default:
+			return nil, fmt.Errorf("failed to look up user %d: %w", id, err)
+		}
+	}
+
+	menuItemIDs, err := orders.ReferencedMenuItemIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ordered menu items: %w", err)
+	}
+	for start := 0; start < len(menuItemIDs); start += batchGetLimit {
+		req := &menuv1.BatchGetMenuItemsRequest{}
+		for _, id := range menuItemIDs[start:min(start+batchGetLimit, len(menuItemIDs))] {
+			req.Ids = append(req.Ids, uint32(id))
+		}
+		resp, err := menu.BatchGetMenuItems(ctx, req, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up menu items: %w", err)
+		}
+		for _, id := range resp.MissingIds {
+			report.OrphanedMenuItems = append(report.OrphanedMenuItems, uint(id))
+		}
+	}
+
+	if !repair || (len(report.OrphanedUsers) == 0 && len(report.OrphanedMenuItems) == 0) {
+		return report, nil
+	}
+	err = orders.Transaction(ctx, func(tx store.OrderStore) error {
+		for _, id := range report.OrphanedUsers {
+			if err := tx.FlagDeletedUser(ctx, id, true); err != nil {
+				return err
+			}
+		}
+		for _, id := range report.OrphanedMenuItems {
+			if err := tx.FlagDeletedMenuItem(ctx, id, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to flag orphans: %w", err)
+	}
+	report.Repaired = true
+	return report, nil
+}
@@ -0,0 +1,269 @@
+// Package downstream guards the calls order-service makes to the services it
+// depends on. Every call gets a deadline, calls that failed because the
+// dependency was down or overloaded are retried with jittered exponential
+// backoff, and a circuit breaker fails calls at once while the dependency
+// keeps failing, so one slow service cannot stall every order.
+package downstream
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTimeout is the default deadline of each attempt.
+const DefaultTimeout = 2 * time.Second
+
+const (
+	defaultMaxAttempts      = 3
+	defaultBaseBackoff      = 50 * time.Millisecond
+	defaultMaxBackoff       = time.Second
+	defaultFailureThreshold = 5
+	defaultOpenFor          = 10 * time.Second
+)
+
+// circuit is the state of a circuit breaker.
+type circuit int
+
+const (
+	// closed lets every call through.
+	closed circuit = iota
+	// open fails every call without trying it.
+	open
+	// halfOpen lets a single probe call through to find out whether the
+	// dependency is back.
+	halfOpen
+)
+
+func (c circuit) String() string {
+	switch c {
+	case closed:
+		return "closed"
+	case open:
+		return "open"
+	default:
+		return "half-open"
+	}
+}
+
+// Dependency calls one service, such as menu-service, and keeps the circuit
+// breaker for it.
+type Dependency struct {
+	// Name identifies the dependency in errors and logs.
+	Name string
+	// Timeout bounds each attempt. An earlier deadline of the caller still
+	// applies.
+	Timeout time.Duration
+	// MaxAttempts is how many times a call is tried, the first included.
+	// Only calls failing with Unavailable or ResourceExhausted are retried.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry. It doubles with every
+	// retry up to MaxBackoff, and each wait is jittered so that callers do
+	// not retry in lockstep.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// NoRetry holds the full names of methods that are tried only once,
+	// because repeating them after a lost reply would apply them twice.
+	NoRetry map[string]bool
+	// FailureThreshold is how many failures in a row open the circuit.
+	// Only Unavailable, ResourceExhausted and DeadlineExceeded count: an
+	// error the dependency chose to return shows it is up.
+	FailureThreshold int
+	// OpenFor is how long an open circuit fails calls before letting a
+	// probe through.
+	OpenFor time.Duration
+
+	mu       sync.Mutex
+	circuit  circuit
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func New(name string) *Dependency {
+	return &Dependency{
+		Name:             name,
+		Timeout:          DefaultTimeout,
+		MaxAttempts:      defaultMaxAttempts,
+		BaseBackoff:      defaultBaseBackoff,
+		MaxBackoff:       defaultMaxBackoff,
+		FailureThreshold: defaultFailureThreshold,
+		OpenFor:          defaultOpenFor,
+	}
+}
+
+// Invoke runs call, which makes one attempt at method, under the deadline,
+// retry and circuit breaker rules of d. While the circuit is open it fails
+// with Unavailable without running call.
+func (d *Dependency) Invoke(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	attempts := d.MaxAttempts
+	if attempts < 1 || d.NoRetry[method] {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := range attempts {
+		if attempt > 0 {
+			if sleep(ctx, d.backoff(attempt)) != nil {
+				return err
+			}
+		}
+		probe, ok := d.allow()
+		if !ok {
+			return status.Errorf(codes.Unavailable, "%s is unavailable: circuit breaker is open", d.Name)
+		}
+		err = d.attempt(ctx, probe, call)
+		if !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// attempt runs call once under its own deadline and records the outcome.
+func (d *Dependency) attempt(ctx context.Context, probe bool, call func(ctx context.Context) error) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+
+	err := call(attemptCtx)
+	d.record(ctx, probe, err)
+	return err
+}
+
+// allow reports whether a call may be made now and whether it is the probe
+// of a half-open circuit. An open circuit turns half-open once OpenFor has
+// passed.
+func (d *Dependency) allow() (probe, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch d.circuit {
+	case open:
+		if time.Since(d.openedAt) < d.OpenFor {
+			return false, false
+		}
+		d.setCircuit(halfOpen)
+	case halfOpen:
+		if d.probing {
+			return false, false
+		}
+	default:
+		return false, true
+	}
+	d.probing = true
+	return true, true
+}
+
+// record updates the circuit breaker with the outcome of a call. A call the
+// caller gave up on says nothing about the dependency, and only the probe
+// decides what happens to a half-open circuit.
+func (d *Dependency) record(ctx context.Context, probe bool, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if probe {
+		d.probing = false
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	switch {
+	case probe && !failed(err):
+		d.setCircuit(closed)
+	case probe:
+		d.openedAt = time.Now()
+		d.setCircuit(open)
+	case d.circuit != closed:
+		// Started before the circuit opened
+	case !failed(err):
+		d.failures = 0
+	default:
+		d.failures++
+		if d.failures >= d.FailureThreshold {
+			log.Printf("%s failed %d calls in a row: %v", d.Name, d.failures, err)
+			d.openedAt = time.Now()
+			d.setCircuit(open)
+		}
+	}
+}
+
+// setCircuit changes the state of the circuit breaker and logs it.
+func (d *Dependency) setCircuit(c circuit) {
+	if d.circuit == c {
+		return
+	}
+	log.Printf("Circuit breaker for %s is %s", d.Name, c)
+	d.circuit = c
+	d.failures = 0
+}
+
+// backoff returns the wait before retry number attempt: a random duration
+// between half and all of the exponential delay.
+func (d *Dependency) backoff(attempt int) time.Duration {
+	delay := d.BaseBackoff
+	for range attempt - 1 {
+		if delay >= d.MaxBackoff/2 {
+			delay = d.MaxBackoff
+			break
+		}
+		delay *= 2
+	}
+	delay = min(delay, d.MaxBackoff)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// sleep waits for d unless ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryable reports whether err means the call was not served and may be
+// tried again.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// failed reports whether err counts against the circuit breaker.
+func failed(err error) bool {
+	return retryable(err) || status.Code(err) == codes.DeadlineExceeded
+}
+
+// Conn returns a connection that makes the unary calls of clients created on
+// it through d. Streams are passed through unchanged.
+func (d *Dependency) Conn(cc grpc.ClientConnInterface) grpc.ClientConnInterface {
+	return &conn{ClientConnInterface: cc, dependency: d}
+}
+
+type conn struct {
+	grpc.ClientConnInterface
+	dependency *Dependency
+}
+
+func (c *conn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.dependency.Invoke(ctx, method, func(ctx context.Context) error {
+		return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	})
+}
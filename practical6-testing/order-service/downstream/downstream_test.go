@@ -0,0 +1,223 @@
+package downstream
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/test.v1.TestService/Get"
+
+// newTestDependency returns a Dependency with backoffs short enough for
+// tests.
+func newTestDependency() *Dependency {
+	d := New("test-service")
+	d.BaseBackoff = time.Millisecond
+	d.MaxBackoff = 5 * time.Millisecond
+	return d
+}
+
+// failing returns a call that fails with the given codes in turn, then
+// succeeds, counting its attempts in calls.
+func failing(calls *atomic.Int32, failures ...codes.Code) func(context.Context) error {
+	return func(context.Context) error {
+		n := int(calls.Add(1))
+		if n <= len(failures) {
+			return status.Error(failures[n-1], "failed")
+		}
+		return nil
+	}
+}
+
+func TestInvokeRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		codes     []codes.Code
+		noRetry   bool
+		wantCalls int32
+		wantCode  codes.Code
+	}{
+		{"success", nil, false, 1, codes.OK},
+		{"unavailable then success", []codes.Code{codes.Unavailable, codes.Unavailable}, false, 3, codes.OK},
+		{"resource exhausted then success", []codes.Code{codes.ResourceExhausted}, false, 2, codes.OK},
+		{"gives up after max attempts", []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable}, false, 3, codes.Unavailable},
+		{"not found is not retried", []codes.Code{codes.NotFound}, false, 1, codes.NotFound},
+		{"deadline exceeded is not retried", []codes.Code{codes.DeadlineExceeded}, false, 1, codes.DeadlineExceeded},
+		{"unsafe method is not retried", []codes.Code{codes.Unavailable}, true, 1, codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDependency()
+			if tt.noRetry {
+				d.NoRetry = map[string]bool{testMethod: true}
+			}
+
+			var calls atomic.Int32
+			err := d.Invoke(context.Background(), testMethod, failing(&calls, tt.codes...))
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestInvokeAppliesDeadline(t *testing.T) {
+	d := newTestDependency()
+	d.Timeout = 20 * time.Millisecond
+	d.MaxAttempts = 1
+
+	start := time.Now()
+	err := d.Invoke(context.Background(), testMethod, func(ctx context.Context) error {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// A shorter deadline of the caller wins
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err = d.Invoke(ctx, testMethod, func(attemptCtx context.Context) error {
+		deadline, ok := attemptCtx.Deadline()
+		require.True(t, ok)
+		callerDeadline, _ := ctx.Deadline()
+		assert.Equal(t, callerDeadline, deadline)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestInvokeStopsRetryingWhenCallerGivesUp(t *testing.T) {
+	d := newTestDependency()
+	d.BaseBackoff = time.Hour
+	d.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := d.Invoke(ctx, testMethod, failing(&calls, codes.Unavailable, codes.Unavailable))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	d := newTestDependency()
+	d.MaxAttempts = 1
+	d.FailureThreshold = 2
+	d.OpenFor = 50 * time.Millisecond
+	ctx := context.Background()
+	down := func(context.Context) error { return status.Error(codes.Unavailable, "connection refused") }
+	up := func(context.Context) error { return nil }
+
+	// Errors returned by a running dependency do not count
+	for range 3 {
+		d.Invoke(ctx, testMethod, func(context.Context) error { return status.Error(codes.NotFound, "not found") })
+	}
+	require.Equal(t, closed, d.circuit)
+
+	// Failures in a row open the circuit, which then fails calls at once
+	d.Invoke(ctx, testMethod, down)
+	require.Equal(t, closed, d.circuit)
+	d.Invoke(ctx, testMethod, down)
+	require.Equal(t, open, d.circuit)
+
+	called := false
+	err := d.Invoke(ctx, testMethod, func(context.Context) error { called = true; return nil })
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "circuit breaker is open")
+	assert.False(t, called)
+
+	// After OpenFor a single probe is let through, and a failed probe opens
+	// the circuit again
+	time.Sleep(d.OpenFor)
+	d.Invoke(ctx, testMethod, down)
+	require.Equal(t, open, d.circuit)
+	assert.Equal(t, codes.Unavailable, status.Code(d.Invoke(ctx, testMethod, up)))
+
+	// While the probe runs other calls still fail, and its success closes
+	// the circuit
+	time.Sleep(d.OpenFor)
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	probed := make(chan error)
+	go func() {
+		probed <- d.Invoke(ctx, testMethod, func(context.Context) error {
+			close(probing)
+			<-release
+			return nil
+		})
+	}()
+	<-probing
+	assert.Equal(t, codes.Unavailable, status.Code(d.Invoke(ctx, testMethod, up)))
+	close(release)
+	require.NoError(t, <-probed)
+	assert.Equal(t, closed, d.circuit)
+	assert.NoError(t, d.Invoke(ctx, testMethod, up))
+}
+
+func TestCircuitBreakerIgnoresCallsTheCallerGaveUpOn(t *testing.T) {
+	d := newTestDependency()
+	d.MaxAttempts = 1
+	d.FailureThreshold = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Invoke(ctx, testMethod, func(ctx context.Context) error {
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	assert.Equal(t, closed, d.circuit)
+}
+
+func TestBackoff(t *testing.T) {
+	d := New("test-service")
+	d.BaseBackoff = 100 * time.Millisecond
+	d.MaxBackoff = time.Second
+
+	for attempt, ceiling := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		5:  time.Second,
+		60: time.Second,
+	} {
+		for range 20 {
+			wait := d.backoff(attempt)
+			assert.GreaterOrEqual(t, wait, ceiling/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, wait, ceiling, "attempt %d", attempt)
+		}
+	}
+}
+
+// fakeConn answers every call with err.
+type fakeConn struct {
+	grpc.ClientConnInterface
+	calls atomic.Int32
+	err   error
+}
+
+func (c *fakeConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+func TestConn(t *testing.T) {
+	d := newTestDependency()
+	d.NoRetry = map[string]bool{"/test.v1.TestService/Create": true}
+	cc := &fakeConn{err: status.Error(codes.Unavailable, "connection refused")}
+	conn := d.Conn(cc)
+
+	err := conn.Invoke(context.Background(), testMethod, nil, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(3), cc.calls.Load())
+
+	err = conn.Invoke(context.Background(), "/test.v1.TestService/Create", nil, nil)
+	assert.True(t, errors.Is(err, cc.err))
+	assert.Equal(t, int32(4), cc.calls.Load())
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"authz"
+	"context"
+	"errors"
+	"time"
+
+	"dberr"
+	"order-service/models"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+	"prototime"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxCancellationNoteLength = 500
+
+func (s *OrderServer) CancelOrder(ctx context.Context, req *orderv1.CancelOrderRequest) (*orderv1.CancelOrderResponse, error) {
+	reason, ok := fromProtoReason(req.Reason)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid cancellation reason %s", req.Reason)
+	}
+	if reason == models.ReasonOther && req.Note == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "a note is required when the cancellation reason is %s", req.Reason)
+	}
+	if len(req.Note) > maxCancellationNoteLength {
+		return nil, status.Errorf(codes.InvalidArgument, "note must be at most %d characters", maxCancellationNoteLength)
+	}
+
+	order, err := s.store.GetOrder(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+
+	current := order.Status
+	if current.IsTerminal() {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"cannot cancel order %d in status %s", order.ID, toProtoStatus(current))
+	}
+
+	// Owners may cancel any unfinished order, customers only their own
+	// orders before the cafe has confirmed them. The role and the user
+	// recorded as cancelling come from the caller's token, not the request
+	claims, ok := authz.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	callerID, err := claims.UserID()
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token subject %q", claims.Subject)
+	}
+	if !claims.CafeOwner {
+		if order.UserID != uint(callerID) {
+			return nil, status.Errorf(codes.PermissionDenied, "user %d cannot cancel another customer's order", callerID)
+		}
+		if current != models.StatusPending {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"customers can only cancel pending orders, order %d is %s", order.ID, toProtoStatus(current))
+		}
+	}
+
+	cancelledBy := uint(callerID)
+	cancelledAt := time.Now()
+	var pbOrder *orderv1.Order
+	err = s.store.Transaction(ctx, func(tx store.OrderStore) error {
+		order.Status = models.StatusCancelled
+		order.CancellationReason = reason
+		order.CancellationNote = req.Note
+		order.CancelledBy = &cancelledBy
+		order.CancelledAt = &cancelledAt
+		applied, err := tx.TransitionOrder(ctx, &order, current)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return errConcurrentUpdate
+		}
+
+		pbOrder = toProtoOrder(order)
+		return recordOrderEvent(ctx, tx, outbox.OrderCancelled, pbOrder, current)
+	})
+	if errors.Is(err, errConcurrentUpdate) {
+		return nil, status.Errorf(codes.Aborted, "order %d changed status concurrently, retry", order.ID)
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+
+	s.releaseStock(order.StockReservationID)
+
+	s.Hub.Publish(pbOrder)
+
+	return &orderv1.CancelOrderResponse{Order: pbOrder}, nil
+}
+
+func toProtoCancellation(order models.Order) *orderv1.Cancellation {
+	if order.CancelledAt == nil {
+		return nil
+	}
+
+	cancellation := &orderv1.Cancellation{
+		Reason:      protoReasons[order.CancellationReason],
+		Note:        order.CancellationNote,
+		CancelledAt: prototime.Legacy(*order.CancelledAt),
+		CancelTime:  prototime.Optional(order.CancelledAt),
+	}
+	if order.CancelledBy != nil {
+		cancellation.CancelledBy = uint32(*order.CancelledBy)
+	}
+	return cancellation
+}
+
+var protoReasons = map[models.CancellationReason]orderv1.CancellationReason{
+	models.ReasonCustomerRequest: orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST,
+	models.ReasonItemUnavailable: orderv1.CancellationReason_CANCELLATION_REASON_ITEM_UNAVAILABLE,
+	models.ReasonCafeClosed:      orderv1.CancellationReason_CANCELLATION_REASON_CAFE_CLOSED,
+	models.ReasonPaymentFailed:   orderv1.CancellationReason_CANCELLATION_REASON_PAYMENT_FAILED,
+	models.ReasonDuplicateOrder:  orderv1.CancellationReason_CANCELLATION_REASON_DUPLICATE_ORDER,
+	models.ReasonOther:           orderv1.CancellationReason_CANCELLATION_REASON_OTHER,
+}
+
+func fromProtoReason(r orderv1.CancellationReason) (models.CancellationReason, bool) {
+	for model, pb := range protoReasons {
+		if pb == r {
+			return model, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/models"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCancelOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+
+	const customerID, otherCustomerID, ownerID = 1, 2, 3
+
+	tests := []struct {
+		name         string
+		current      models.OrderStatus
+		userID       uint32
+		owner        bool
+		reason       orderv1.CancellationReason
+		note         string
+		expectedCode codes.Code
+	}{
+		{"customer cancels pending order", models.StatusPending, customerID, false, orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST, "changed my mind", codes.OK},
+		{"customer cannot cancel confirmed order", models.StatusConfirmed, customerID, false, orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST, "", codes.FailedPrecondition},
+		{"customer cannot cancel another customer's order", models.StatusPending, otherCustomerID, false, orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST, "", codes.PermissionDenied},
+		{"owner cancels order being prepared", models.StatusPreparing, ownerID, true, orderv1.CancellationReason_CANCELLATION_REASON_ITEM_UNAVAILABLE, "out of oat milk", codes.OK},
+		{"owner cannot cancel completed order", models.StatusCompleted, ownerID, true, orderv1.CancellationReason_CANCELLATION_REASON_CAFE_CLOSED, "", codes.FailedPrecondition},
+		{"reason is required", models.StatusPending, customerID, false, orderv1.CancellationReason_CANCELLATION_REASON_UNSPECIFIED, "", codes.InvalidArgument},
+		{"other reason needs a note", models.StatusPending, customerID, false, orderv1.CancellationReason_CANCELLATION_REASON_OTHER, "", codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.Order{UserID: customerID, Status: tt.current}
+			require.NoError(t, db.Create(&order).Error)
+
+			ctx := asUser(tt.userID, tt.owner)
+			resp, err := server.CancelOrder(ctx, &orderv1.CancelOrderRequest{
+				Id:     uint32(order.ID),
+				Reason: tt.reason,
+				Note:   tt.note,
+			})
+
+			if tt.expectedCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_CANCELLED, resp.Order.State)
+			require.NotNil(t, resp.Order.Cancellation)
+			assert.Equal(t, tt.reason, resp.Order.Cancellation.Reason)
+
+			// Cancellation details are visible through GetOrder
+			getResp, err := server.GetOrder(ctx, &orderv1.GetOrderRequest{Id: uint32(order.ID)})
+			require.NoError(t, err)
+			require.NotNil(t, getResp.Order.Cancellation)
+			assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_CANCELLED, getResp.Order.State)
+			assert.Equal(t, tt.reason, getResp.Order.Cancellation.Reason)
+			assert.Equal(t, tt.note, getResp.Order.Cancellation.Note)
+			assert.Equal(t, tt.userID, getResp.Order.Cancellation.CancelledBy)
+			assert.NotNil(t, getResp.Order.Cancellation.CancelTime)
+		})
+	}
+}
+
+func TestCancelOrder_ReleasesStock(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), mockMenuClient)
+
+	mockMenuClient.On("ReleaseStock", mock.Anything, &menuv1.ReleaseStockRequest{ReservationId: "res-1"}).
+		Return(&menuv1.ReleaseStockResponse{}, nil)
+
+	order := models.Order{UserID: 1, Status: models.StatusPending, StockReservationID: "res-1"}
+	require.NoError(t, db.Create(&order).Error)
+
+	_, err := server.CancelOrder(asUser(1, false), &orderv1.CancelOrderRequest{
+		Id:     uint32(order.ID),
+		Reason: orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST,
+	})
+	require.NoError(t, err)
+	mockMenuClient.AssertExpectations(t)
+}
+
+func TestCancelOrder_RequiresCaller(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	order := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+
+	_, err := server.CancelOrder(context.Background(), &orderv1.CancelOrderRequest{
+		Id:     uint32(order.ID),
+		Reason: orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestCancelOrder_IgnoresRequestUser(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	order := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+
+	// Naming the order's customer in the deprecated field does not let
+	// another customer cancel it
+	_, err := server.CancelOrder(asUser(2, false), &orderv1.CancelOrderRequest{
+		Id:     uint32(order.ID),
+		UserId: 1,
+		Reason: orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Nor does leaving it out stop the customer themselves
+	resp, err := server.CancelOrder(asUser(1, false), &orderv1.CancelOrderRequest{
+		Id:     uint32(order.ID),
+		Reason: orderv1.CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), resp.Order.Cancellation.CancelledBy)
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dependencyError converts the error of a call to user-service or
+// menu-service into the one returned to the caller, prefixed with action.
+// Something the dependency did not find makes the request impossible as
+// things stand, FailedPrecondition, while a dependency that is down,
+// overloaded or too slow makes order-service Unavailable. Other errors keep
+// their code.
+func dependencyError(ctx context.Context, err error, action string) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.NotFound:
+		return status.Errorf(codes.FailedPrecondition, "%s: %s", action, st.Message())
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return status.Errorf(codes.Unavailable, "%s: %s", action, st.Message())
+	default:
+		return status.Errorf(st.Code(), "%s: %s", action, st.Message())
+	}
+}
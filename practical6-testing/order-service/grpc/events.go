@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"order-service/models"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// orderEventPayload is the body of every order domain event.
+type orderEventPayload struct {
+	Order          json.RawMessage `json:"order"`
+	PreviousStatus string          `json:"previous_status,omitempty"`
+}
+
+// recordOrderEvent writes an order event to the outbox within tx. previous
+// is the status the order moved from, empty for new orders.
+func recordOrderEvent(ctx context.Context, tx store.OrderStore, eventType string, order *orderv1.Order, previous models.OrderStatus) error {
+	pbOrder, err := protojson.Marshal(order)
+	if err != nil {
+		return err
+	}
+
+	payload := orderEventPayload{Order: pbOrder}
+	if previous != "" {
+		payload.PreviousStatus = toProtoStatus(previous).String()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(ctx, tx, eventType, uint(order.Id), body)
+}
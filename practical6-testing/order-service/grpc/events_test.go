@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/models"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+	ctx := asUser(1, true)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1, IsCafeOwner: true}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", PriceCents: 250}},
+		}, nil)
+
+	created, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	orderID := created.Order.Id
+
+	_, err = server.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
+		Id:    orderID,
+		State: orderv1.OrderStatus_ORDER_STATUS_CONFIRMED,
+	})
+	require.NoError(t, err)
+
+	// A rejected change writes no event
+	_, err = server.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
+		Id:    orderID,
+		State: orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	})
+	require.Error(t, err)
+
+	_, err = server.CancelOrder(ctx, &orderv1.CancelOrderRequest{
+		Id:     orderID,
+		Reason: orderv1.CancellationReason_CANCELLATION_REASON_CAFE_CLOSED,
+	})
+	require.NoError(t, err)
+
+	var events []models.OutboxEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	require.Len(t, events, 3)
+
+	expected := []struct {
+		eventType      string
+		status         string
+		previousStatus string
+	}{
+		{outbox.OrderCreated, "ORDER_STATUS_PENDING", ""},
+		{outbox.OrderStatusChanged, "ORDER_STATUS_CONFIRMED", "ORDER_STATUS_PENDING"},
+		{outbox.OrderCancelled, "ORDER_STATUS_CANCELLED", "ORDER_STATUS_CONFIRMED"},
+	}
+	for i, want := range expected {
+		event := events[i]
+		assert.Equal(t, want.eventType, event.Type)
+		assert.Equal(t, uint(orderID), event.OrderID)
+
+		var payload struct {
+			Order struct {
+				State      string `json:"state"`
+				TotalCents string `json:"totalCents"`
+			} `json:"order"`
+			PreviousStatus string `json:"previous_status"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+		assert.Equal(t, want.status, payload.Order.State)
+		assert.Equal(t, "500", payload.Order.TotalCents)
+		assert.Equal(t, want.previousStatus, payload.PreviousStatus)
+	}
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"dberr"
+	"order-service/models"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// idempotencyKeyHeader is the metadata alternative to
+	// CreateOrderRequest.idempotency_key.
+	idempotencyKeyHeader = "idempotency-key"
+
+	maxIdempotencyKeyLength = 255
+
+	// DefaultIdempotencyRetention is how long idempotency keys are honoured
+	// unless OrderServer.IdempotencyRetention is changed.
+	DefaultIdempotencyRetention = 24 * time.Hour
+)
+
+// idempotencyKey returns the key sent in the request body or metadata.
+func idempotencyKey(ctx context.Context, req *orderv1.CreateOrderRequest) (string, error) {
+	key := req.IdempotencyKey
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+			if key != "" && key != values[0] {
+				return "", status.Errorf(codes.InvalidArgument, "idempotency_key and %s metadata disagree", idempotencyKeyHeader)
+			}
+			key = values[0]
+		}
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return key, nil
+}
+
+// requestHash fingerprints the order payload, ignoring the key itself, so a
+// replay can be told apart from a different order reusing the same key.
+func requestHash(req *orderv1.CreateOrderRequest) (string, error) {
+	payload := proto.Clone(req).(*orderv1.CreateOrderRequest)
+	payload.IdempotencyKey = ""
+
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replayedOrder returns the order created earlier with the same unexpired
+// key, or nil if the key has not been used.
+func (s *OrderServer) replayedOrder(ctx context.Context, userID uint, key, hash string, now time.Time) (*models.Order, error) {
+	record, err := s.store.FindIdempotencyKey(ctx, userID, key, now)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "idempotency key")
+	}
+
+	if record.RequestHash != hash {
+		return nil, status.Errorf(codes.AlreadyExists, "idempotency key %q was already used for a different order", key)
+	}
+
+	order, err := s.store.GetOrder(ctx, record.OrderID)
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+	return &order, nil
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"log"
+
+	"order-service/models"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// redeemPoints spends the customer's loyalty points on a discount for order,
+// which must already have an ID, and saves the new totals within tx. Only
+// services may redeem points, so it authenticates with ServiceCallOptions;
+// the caller has already been authorized to order for the customer.
+func (s *OrderServer) redeemPoints(ctx context.Context, tx store.OrderStore, order *models.Order, points int64) error {
+	resp, err := s.UserClient.RedeemPoints(ctx, &userv1.RedeemPointsRequest{
+		UserId:           uint32(order.UserID),
+		OrderId:          uint32(order.ID),
+		Points:           points,
+		MaxDiscountCents: order.SubtotalCents,
+	}, s.ServiceCallOptions...)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return status.Errorf(codes.FailedPrecondition, "not enough loyalty points: %s", status.Convert(err).Message())
+		case codes.AlreadyExists:
+			return status.Errorf(codes.InvalidArgument, "cannot redeem loyalty points: %s", status.Convert(err).Message())
+		default:
+			return dependencyError(ctx, err, "cannot redeem loyalty points")
+		}
+	}
+
+	order.PointsRedeemed = points
+	order.DiscountCents = resp.DiscountCents
+	order.ApplyTotals(order.TaxRateBasisPoints)
+	return tx.SaveOrderTotals(ctx, order)
+}
+
+// returnPoints gives back any points the user redeemed for an order that
+// could not be saved. Reversing an order that redeemed nothing, or was
+// already reversed, has no effect. Like the stock calls, it authenticates
+// with ServiceCallOptions because only services may reverse points.
+func (s *OrderServer) returnPoints(userID, orderID uint) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	req := &userv1.ReverseOrderPointsRequest{OrderId: uint32(orderID), UserId: uint32(userID)}
+	if _, err := s.UserClient.ReverseOrderPoints(ctx, req, s.ServiceCallOptions...); err != nil {
+		log.Printf("Failed to return loyalty points redeemed for order %d: %v", orderID, err)
+	}
+}
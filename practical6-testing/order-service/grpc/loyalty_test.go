@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/models"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateOrder_RedeemPoints(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+	server.TaxRateBasisPoints = 825
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", PriceCents: 250, Currency: "USD"}},
+		}, nil)
+	ctx := context.Background()
+
+	t.Run("discount is taken off before tax", func(t *testing.T) {
+		mockUserClient.On("RedeemPoints", mock.Anything, mock.MatchedBy(func(req *userv1.RedeemPointsRequest) bool {
+			return req.Points == 200
+		})).Return(&userv1.RedeemPointsResponse{DiscountCents: 200}, nil).Once()
+
+		resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId:       1,
+			Items:        []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 4}},
+			RedeemPoints: 200,
+		})
+		require.NoError(t, err)
+
+		// 4 x 250 = 1000, less 200 = 800, tax 8.25% = 66
+		assert.Equal(t, int64(1000), resp.Order.SubtotalCents)
+		assert.Equal(t, int64(200), resp.Order.DiscountCents)
+		assert.Equal(t, int64(200), resp.Order.PointsRedeemed)
+		assert.Equal(t, int64(66), resp.Order.TaxCents)
+		assert.Equal(t, int64(866), resp.Order.TotalCents)
+
+		redeem := mockUserClient.Calls[len(mockUserClient.Calls)-1].Arguments.Get(1).(*userv1.RedeemPointsRequest)
+		assert.Equal(t, resp.Order.Id, redeem.OrderId)
+		assert.Equal(t, int64(1000), redeem.MaxDiscountCents)
+
+		getResp, err := server.GetOrder(asUser(1, true), &orderv1.GetOrderRequest{Id: resp.Order.Id})
+		require.NoError(t, err)
+		assert.Equal(t, resp.Order.TotalCents, getResp.Order.TotalCents)
+		assert.Equal(t, resp.Order.DiscountCents, getResp.Order.DiscountCents)
+	})
+
+	t.Run("not enough points", func(t *testing.T) {
+		mockUserClient.On("RedeemPoints", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.FailedPrecondition, "user 1 does not have 5000 points")).Once()
+		mockUserClient.On("ReverseOrderPoints", mock.Anything, mock.Anything).
+			Return(&userv1.ReverseOrderPointsResponse{}, nil).Once()
+
+		var before int64
+		require.NoError(t, db.Model(&models.Order{}).Count(&before).Error)
+
+		_, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId:       1,
+			Items:        []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+			RedeemPoints: 5000,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		var after int64
+		require.NoError(t, db.Model(&models.Order{}).Count(&after).Error)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("redemption that may have been applied is returned", func(t *testing.T) {
+		mockUserClient.On("RedeemPoints", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.DeadlineExceeded, "deadline exceeded")).Once()
+		mockUserClient.On("ReverseOrderPoints", mock.Anything, mock.Anything).
+			Return(&userv1.ReverseOrderPointsResponse{}, nil).Once()
+
+		_, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId:       1,
+			Items:        []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+			RedeemPoints: 10,
+		})
+		require.Error(t, err)
+
+		redeem := mockUserClient.Calls[len(mockUserClient.Calls)-2].Arguments.Get(1).(*userv1.RedeemPointsRequest)
+		reverse := mockUserClient.Calls[len(mockUserClient.Calls)-1].Arguments.Get(1).(*userv1.ReverseOrderPointsRequest)
+		assert.Equal(t, redeem.OrderId, reverse.OrderId)
+		assert.Equal(t, uint32(1), reverse.UserId)
+	})
+}
+
+func TestRefundOrder(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	ctx := context.Background()
+
+	completed := models.Order{UserID: 1, Status: models.StatusCompleted}
+	require.NoError(t, db.Create(&completed).Error)
+	pending := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&pending).Error)
+
+	resp, err := server.RefundOrder(ctx, &orderv1.RefundOrderRequest{Id: uint32(completed.ID)})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Order.RefundTime)
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, resp.Order.State)
+
+	var events []models.OutboxEvent
+	require.NoError(t, db.Where("order_id = ?", completed.ID).Find(&events).Error)
+	require.Len(t, events, 1)
+	assert.Equal(t, outbox.OrderRefunded, events[0].Type)
+
+	_, err = server.RefundOrder(ctx, &orderv1.RefundOrderRequest{Id: uint32(completed.ID)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = server.RefundOrder(ctx, &orderv1.RefundOrderRequest{Id: uint32(pending.ID)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
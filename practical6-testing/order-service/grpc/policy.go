@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"authz"
+	orderv1 "order-service/proto/orderv1"
+)
+
+// Policy is the authorization policy of the order service. Customers may
+// place, read, watch and cancel only their own orders; only cafe owners may
+// see everyone's orders, move an order through the kitchen or refund it.
+var Policy = authz.Policy{
+	orderv1.OrderService_CreateOrder_FullMethodName: {
+		Access: authz.Authenticated,
+		Check:  authz.SelfOrOwner(func(req *orderv1.CreateOrderRequest) uint32 { return req.UserId }),
+	},
+	// GetOrder, WatchOrder and CancelOrder check the order's owner once it
+	// is loaded
+	orderv1.OrderService_GetOrder_FullMethodName: {Access: authz.Authenticated},
+	orderv1.OrderService_GetOrders_FullMethodName: {
+		Access: authz.Authenticated,
+		Check:  authz.SelfOrOwner(func(req *orderv1.GetOrdersRequest) uint32 { return req.UserId }),
+	},
+	orderv1.OrderService_UpdateOrderStatus_FullMethodName: {Access: authz.Owner},
+	orderv1.OrderService_CancelOrder_FullMethodName:       {Access: authz.Authenticated},
+	orderv1.OrderService_RefundOrder_FullMethodName:       {Access: authz.Owner},
+	orderv1.OrderService_WatchOrder_FullMethodName:        {Access: authz.Authenticated},
+	orderv1.OrderService_WatchOrders_FullMethodName: {
+		Access: authz.Authenticated,
+		Check:  authz.SelfOrOwner(func(req *orderv1.WatchOrdersRequest) uint32 { return req.UserId }),
+	},
+}
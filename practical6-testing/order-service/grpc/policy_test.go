@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"authz"
+	"context"
+	"testing"
+	"time"
+
+	"order-service/models"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy(t *testing.T) {
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	interceptor := authz.NewAuthorizer(keys, Policy).UnaryServerInterceptor()
+
+	tokenFor := func(userID uint, owner bool) string {
+		token, err := authz.IssueAccessToken(keys, userID, owner, time.Now(), time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+	ownerToken, customerToken := tokenFor(1, true), tokenFor(2, false)
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{"customer orders for self", customerToken, orderv1.OrderService_CreateOrder_FullMethodName,
+			&orderv1.CreateOrderRequest{UserId: 2}, codes.OK},
+		{"customer orders for someone else", customerToken, orderv1.OrderService_CreateOrder_FullMethodName,
+			&orderv1.CreateOrderRequest{UserId: 3}, codes.PermissionDenied},
+		{"anonymous order", "", orderv1.OrderService_CreateOrder_FullMethodName,
+			&orderv1.CreateOrderRequest{UserId: 2}, codes.Unauthenticated},
+		{"customer lists own orders", customerToken, orderv1.OrderService_GetOrders_FullMethodName,
+			&orderv1.GetOrdersRequest{UserId: 2}, codes.OK},
+		{"customer lists all orders", customerToken, orderv1.OrderService_GetOrders_FullMethodName,
+			&orderv1.GetOrdersRequest{}, codes.PermissionDenied},
+		{"owner lists all orders", ownerToken, orderv1.OrderService_GetOrders_FullMethodName,
+			&orderv1.GetOrdersRequest{}, codes.OK},
+		{"customer advances order", customerToken, orderv1.OrderService_UpdateOrderStatus_FullMethodName,
+			&orderv1.UpdateOrderStatusRequest{Id: 1, State: orderv1.OrderStatus_ORDER_STATUS_COMPLETED}, codes.PermissionDenied},
+		{"owner advances order", ownerToken, orderv1.OrderService_UpdateOrderStatus_FullMethodName,
+			&orderv1.UpdateOrderStatusRequest{Id: 1, State: orderv1.OrderStatus_ORDER_STATUS_COMPLETED}, codes.OK},
+		{"customer cancels an order", customerToken, orderv1.OrderService_CancelOrder_FullMethodName,
+			&orderv1.CancelOrderRequest{Id: 1}, codes.OK},
+		{"anonymous cancel", "", orderv1.OrderService_CancelOrder_FullMethodName,
+			&orderv1.CancelOrderRequest{Id: 1}, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+			handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+
+			_, err := interceptor(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
+
+func TestPolicy_CoversEveryMethod(t *testing.T) {
+	desc := orderv1.OrderService_ServiceDesc
+	for _, method := range desc.Methods {
+		assert.Contains(t, Policy, "/"+desc.ServiceName+"/"+method.MethodName)
+	}
+	for _, stream := range desc.Streams {
+		assert.Contains(t, Policy, "/"+desc.ServiceName+"/"+stream.StreamName)
+	}
+}
+
+func TestGetOrder_OnlyOwnOrders(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	order := models.Order{UserID: 2, Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+
+	_, err := server.GetOrder(asUser(2, false), &orderv1.GetOrderRequest{Id: uint32(order.ID)})
+	require.NoError(t, err)
+
+	_, err = server.GetOrder(asUser(3, false), &orderv1.GetOrderRequest{Id: uint32(order.ID)})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.GetOrder(asUser(1, true), &orderv1.GetOrderRequest{Id: uint32(order.ID)})
+	require.NoError(t, err)
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dberr"
+	"order-service/models"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefundOrder marks a completed order as refunded. The loyalty points the
+// order earned and redeemed are reversed when the OrderRefunded event is
+// relayed.
+func (s *OrderServer) RefundOrder(ctx context.Context, req *orderv1.RefundOrderRequest) (*orderv1.RefundOrderResponse, error) {
+	order, err := s.store.GetOrder(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+	if order.Status != models.StatusCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"only completed orders can be refunded, order %d is %s", order.ID, toProtoStatus(order.Status))
+	}
+	if order.RefundedAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %d has already been refunded", order.ID)
+	}
+
+	refundedAt := time.Now()
+	var pbOrder *orderv1.Order
+	err = s.store.Transaction(ctx, func(tx store.OrderStore) error {
+		refunded, err := tx.MarkOrderRefunded(ctx, &order, refundedAt)
+		if err != nil {
+			return err
+		}
+		if !refunded {
+			return errConcurrentUpdate
+		}
+
+		pbOrder = toProtoOrder(order)
+		return recordOrderEvent(ctx, tx, outbox.OrderRefunded, pbOrder, "")
+	})
+	if errors.Is(err, errConcurrentUpdate) {
+		return nil, status.Errorf(codes.Aborted, "order %d was refunded concurrently", order.ID)
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+
+	s.Hub.Publish(pbOrder)
+
+	return &orderv1.RefundOrderResponse{Order: pbOrder}, nil
+}
@@ -1,180 +1,434 @@
 package grpc
 
 import (
+	"authz"
 	"context"
-	"order-service/database"
+	"dberr"
+	"errors"
 	"order-service/models"
+	"order-service/outbox"
 	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+	"pagination"
+	"prototime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	menuv1 "order-service/proto/menuv1"
-	userv1 "order-service/proto/userv1"
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/watch"
+	userv1 "user-service/proto/userv1"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// errConcurrentUpdate aborts a transaction whose conditional update found
+// the order already changed by another request.
+var errConcurrentUpdate = errors.New("order changed concurrently")
+
 type OrderServer struct {
 	orderv1.UnimplementedOrderServiceServer
+	store      store.OrderStore
 	UserClient userv1.UserServiceClient
 	MenuClient menuv1.MenuServiceClient
+	// TaxRateBasisPoints is the tax rate charged on new orders (825 = 8.25%).
+	TaxRateBasisPoints int64
+	// Hub notifies WatchOrder and WatchOrders streams of order changes.
+	Hub *watch.Hub
+	// IdempotencyRetention is how long CreateOrder idempotency keys are
+	// honoured.
+	IdempotencyRetention time.Duration
+	// ServiceCallOptions authenticate calls order-service makes on its own
+	// behalf rather than for the caller, such as holding stock or redeeming
+	// and returning loyalty points.
+	ServiceCallOptions []grpc.CallOption
 }
 
-func NewOrderServer(userClient userv1.UserServiceClient, menuClient menuv1.MenuServiceClient) *OrderServer {
+func NewOrderServer(store store.OrderStore, userClient userv1.UserServiceClient, menuClient menuv1.MenuServiceClient) *OrderServer {
 	return &OrderServer{
-		UserClient: userClient,
-		MenuClient: menuClient,
+		store:                store,
+		UserClient:           userClient,
+		MenuClient:           menuClient,
+		Hub:                  watch.NewHub(),
+		IdempotencyRetention: DefaultIdempotencyRetention,
 	}
 }
 
 func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (*orderv1.CreateOrderResponse, error) {
+	key, err := idempotencyKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A retry with a known key returns the original order
+	var hash string
+	if key != "" {
+		if hash, err = requestHash(req); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+		}
+		original, err := s.replayedOrder(ctx, uint(req.UserId), key, hash, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if original != nil {
+			return &orderv1.CreateOrderResponse{Order: toProtoOrder(*original)}, nil
+		}
+	}
+
 	// Validate user exists
-	_, err := s.UserClient.GetUser(ctx, &userv1.GetUserRequest{Id: req.UserId})
+	_, err = s.UserClient.GetUser(ctx, &userv1.GetUserRequest{Id: req.UserId})
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "user not found: %v", err)
+		return nil, dependencyError(ctx, err, "cannot look up user")
 	}
 
 	// Create order
 	order := models.Order{
 		UserID: uint(req.UserId),
-		Status: "pending",
+		Status: models.StatusPending,
+	}
+
+	// Resolve every menu item in a single round trip
+	menuItems, err := s.lookupMenuItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAvailability(req.Items, menuItems); err != nil {
+		return nil, err
 	}
 
 	// Process order items
 	for _, item := range req.Items {
-		// Validate menu item exists and get price
-		menuResp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItemId})
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "menu item %d not found: %v", item.MenuItemId, err)
+		menuItem := menuItems[item.MenuItemId]
+
+		currency := menuItem.Currency
+		if currency == "" {
+			currency = models.DefaultCurrency
+		}
+		if order.Currency == "" {
+			order.Currency = currency
+		} else if order.Currency != currency {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"menu item %d is priced in %s but the order is in %s", item.MenuItemId, currency, order.Currency)
 		}
 
 		orderItem := models.OrderItem{
-			MenuItemID:   uint(item.MenuItemId),
-			MenuItemName: menuResp.MenuItem.Name,
-			Quantity:     uint(item.Quantity),
-			Price:        menuResp.MenuItem.Price, // Snapshot the price
+			MenuItemID:     uint(item.MenuItemId),
+			MenuItemName:   menuItem.Name,
+			Quantity:       uint(item.Quantity),
+			UnitPriceCents: menuItemPriceCents(menuItem), // Snapshot the price
 		}
 		order.OrderItems = append(order.OrderItems, orderItem)
 	}
+	order.ApplyTotals(s.TaxRateBasisPoints)
 
-	if err := database.DB.Create(&order).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+	// Hold stock so concurrent orders cannot sell the same units
+	order.StockReservationID, err = s.reserveStock(ctx, req.Items, menuItems)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build response
-	var pbOrderItems []*orderv1.OrderItem
-	for _, item := range order.OrderItems {
-		pbOrderItems = append(pbOrderItems, &orderv1.OrderItem{
-			Id:           uint32(item.ID),
-			MenuItemId:   uint32(item.MenuItemID),
-			MenuItemName: item.MenuItemName,
-			Quantity:     uint32(item.Quantity),
-			Price:        item.Price,
-		})
+	now := time.Now()
+	redeemAttempted := false
+	err = s.store.Transaction(ctx, func(tx store.OrderStore) error {
+		if err := tx.CreateOrder(ctx, &order); err != nil {
+			return err
+		}
+		// Points are redeemed against the order ID, so only once it exists
+		if req.RedeemPoints > 0 {
+			// A call that fails, for instance on a deadline, may still have
+			// been applied, so any attempt must be undone if the order is
+			// not stored
+			redeemAttempted = true
+			if err := s.redeemPoints(ctx, tx, &order, req.RedeemPoints); err != nil {
+				return err
+			}
+		}
+		if err := recordOrderEvent(ctx, tx, outbox.OrderCreated, toProtoOrder(order), ""); err != nil {
+			return err
+		}
+		if key == "" {
+			return nil
+		}
+		return tx.CreateIdempotencyKey(ctx, &models.IdempotencyKey{
+			UserID:      order.UserID,
+			Key:         key,
+			RequestHash: hash,
+			OrderID:     order.ID,
+			ExpiresAt:   now.Add(s.IdempotencyRetention),
+		}, now)
+	})
+	if err != nil {
+		s.releaseStock(order.StockReservationID)
+		if redeemAttempted {
+			s.returnPoints(order.UserID, order.ID)
+		}
+
+		// A concurrent request with the same key may have won the race
+		if key != "" {
+			if original, lookupErr := s.replayedOrder(ctx, order.UserID, key, hash, now); lookupErr != nil {
+				return nil, lookupErr
+			} else if original != nil {
+				return &orderv1.CreateOrderResponse{Order: toProtoOrder(*original)}, nil
+			}
+		}
+		return nil, dberr.Status(err, "order")
 	}
 
-	return &orderv1.CreateOrderResponse{
-		Order: &orderv1.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			Status:     order.Status,
-			OrderItems: pbOrderItems,
-			CreatedAt:  order.CreatedAt.String(),
-			UpdatedAt:  order.UpdatedAt.String(),
-		},
-	}, nil
+	// The order is stored, so its stock must not expire
+	s.confirmStock(order.StockReservationID)
+
+	pbOrder := toProtoOrder(order)
+	s.Hub.Publish(pbOrder)
+
+	return &orderv1.CreateOrderResponse{Order: pbOrder}, nil
 }
 
-func (s *OrderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
-	var order models.Order
-	if err := database.DB.Preload("OrderItems").First(&order, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "order not found")
+// lookupMenuItems fetches the menu items referenced by an order, keyed by ID.
+// Every missing item is reported in a single error.
+func (s *OrderServer) lookupMenuItems(ctx context.Context, items []*orderv1.OrderItemRequest) (map[uint32]*menuv1.MenuItem, error) {
+	ids := make([]uint32, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.MenuItemId)
 	}
 
-	var pbOrderItems []*orderv1.OrderItem
-	for _, item := range order.OrderItems {
-		pbOrderItems = append(pbOrderItems, &orderv1.OrderItem{
-			Id:           uint32(item.ID),
-			MenuItemId:   uint32(item.MenuItemID),
-			MenuItemName: item.MenuItemName,
-			Quantity:     uint32(item.Quantity),
-			Price:        item.Price,
-		})
+	resp, err := s.MenuClient.BatchGetMenuItems(ctx, &menuv1.BatchGetMenuItemsRequest{Ids: ids})
+	if err != nil {
+		return nil, dependencyError(ctx, err, "cannot look up menu items")
+	}
+
+	found := make(map[uint32]*menuv1.MenuItem, len(resp.MenuItems))
+	for _, item := range resp.MenuItems {
+		found[item.Id] = item
+	}
+
+	// Report every missing item at once, in request order
+	var missing []string
+	for _, id := range ids {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		if missingID := strconv.FormatUint(uint64(id), 10); !slices.Contains(missing, missingID) {
+			missing = append(missing, missingID)
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return found, nil
+	case 1:
+		return nil, status.Errorf(codes.FailedPrecondition, "menu item %s not found", missing[0])
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "menu items %s not found", strings.Join(missing, ", "))
+	}
+}
+
+// checkAvailability rejects an order containing items that are outside
+// their availability windows, naming every such item.
+func checkAvailability(items []*orderv1.OrderItemRequest, menuItems map[uint32]*menuv1.MenuItem) error {
+	var unavailable []string
+	for _, item := range items {
+		menuItem := menuItems[item.MenuItemId]
+		if menuItem.Unavailable && !slices.Contains(unavailable, menuItem.Name) {
+			unavailable = append(unavailable, menuItem.Name)
+		}
+	}
+	if len(unavailable) == 0 {
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "not available right now: %s", strings.Join(unavailable, ", "))
+}
+
+func (s *OrderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
+	order, err := s.store.GetOrder(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+	if err := authz.AuthorizeUser(ctx, uint32(order.UserID)); err != nil {
+		return nil, err
 	}
 
-	return &orderv1.GetOrderResponse{
-		Order: &orderv1.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			Status:     order.Status,
-			OrderItems: pbOrderItems,
-			CreatedAt:  order.CreatedAt.String(),
-			UpdatedAt:  order.UpdatedAt.String(),
-		},
-	}, nil
+	return &orderv1.GetOrderResponse{Order: toProtoOrder(order)}, nil
 }
 
 func (s *OrderServer) GetOrders(ctx context.Context, req *orderv1.GetOrdersRequest) (*orderv1.GetOrdersResponse, error) {
-	var orders []models.Order
-	if err := database.DB.Preload("OrderItems").Find(&orders).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get orders: %v", err)
+	filter := store.OrderFilter{UserID: uint(req.UserId)}
+	if req.Status != orderv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		st, ok := fromProtoStatus(req.Status)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order status %s", req.Status)
+		}
+		filter.Status = st
+	}
+	if req.CreatedAfter != nil {
+		createdAfter := req.CreatedAfter.AsTime()
+		filter.CreatedAfter = &createdAfter
+	}
+	if req.CreatedBefore != nil {
+		createdBefore := req.CreatedBefore.AsTime()
+		filter.CreatedBefore = &createdBefore
+	}
+
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.store.ListOrders(ctx, filter, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "orders")
 	}
+	orders, nextToken := pagination.Trim(page, orders, func(o models.Order) uint { return o.ID })
 
 	var pbOrders []*orderv1.Order
 	for _, order := range orders {
-		var pbOrderItems []*orderv1.OrderItem
-		for _, item := range order.OrderItems {
-			pbOrderItems = append(pbOrderItems, &orderv1.OrderItem{
-				Id:           uint32(item.ID),
-				MenuItemId:   uint32(item.MenuItemID),
-				MenuItemName: item.MenuItemName,
-				Quantity:     uint32(item.Quantity),
-				Price:        item.Price,
-			})
-		}
-
-		pbOrders = append(pbOrders, &orderv1.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			Status:     order.Status,
-			OrderItems: pbOrderItems,
-			CreatedAt:  order.CreatedAt.String(),
-			UpdatedAt:  order.UpdatedAt.String(),
-		})
+		pbOrders = append(pbOrders, toProtoOrder(order))
 	}
 
-	return &orderv1.GetOrdersResponse{Orders: pbOrders}, nil
+	return &orderv1.GetOrdersResponse{Orders: pbOrders, NextPageToken: nextToken}, nil
 }
 
 func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *orderv1.UpdateOrderStatusRequest) (*orderv1.UpdateOrderStatusResponse, error) {
-	var order models.Order
-	if err := database.DB.Preload("OrderItems").First(&order, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "order not found")
+	next, err := requestedStatus(req)
+	if err != nil {
+		return nil, err
+	}
+	if next == models.StatusCancelled {
+		return nil, status.Errorf(codes.InvalidArgument, "use CancelOrder to cancel an order")
+	}
+
+	order, err := s.store.GetOrder(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "order")
 	}
 
-	order.Status = req.Status
-	if err := database.DB.Save(&order).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update order status: %v", err)
+	current := order.Status
+	if !current.CanTransitionTo(next) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"cannot move order %d from %s to %s", order.ID, toProtoStatus(current), toProtoStatus(next))
 	}
 
+	var pbOrder *orderv1.Order
+	err = s.store.Transaction(ctx, func(tx store.OrderStore) error {
+		// Only apply the change if nobody else moved the order in the meantime
+		order.Status = next
+		applied, err := tx.TransitionOrder(ctx, &order, current)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return errConcurrentUpdate
+		}
+
+		pbOrder = toProtoOrder(order)
+		return recordOrderEvent(ctx, tx, outbox.OrderStatusChanged, pbOrder, current)
+	})
+	if errors.Is(err, errConcurrentUpdate) {
+		return nil, status.Errorf(codes.Aborted, "order %d changed status concurrently, retry", order.ID)
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "order")
+	}
+
+	s.Hub.Publish(pbOrder)
+
+	return &orderv1.UpdateOrderStatusResponse{Order: pbOrder}, nil
+}
+
+func toProtoOrder(order models.Order) *orderv1.Order {
 	var pbOrderItems []*orderv1.OrderItem
 	for _, item := range order.OrderItems {
 		pbOrderItems = append(pbOrderItems, &orderv1.OrderItem{
-			Id:           uint32(item.ID),
-			MenuItemId:   uint32(item.MenuItemID),
-			MenuItemName: item.MenuItemName,
-			Quantity:     uint32(item.Quantity),
-			Price:        item.Price,
+			Id:              uint32(item.ID),
+			MenuItemId:      uint32(item.MenuItemID),
+			MenuItemName:    item.MenuItemName,
+			Quantity:        uint32(item.Quantity),
+			Price:           models.FloatFromCents(item.UnitPriceCents),
+			UnitPriceCents:  item.UnitPriceCents,
+			LineTotalCents:  item.LineTotalCents,
+			MenuItemDeleted: item.MenuItemDeleted,
 		})
 	}
 
-	return &orderv1.UpdateOrderStatusResponse{
-		Order: &orderv1.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			Status:     order.Status,
-			OrderItems: pbOrderItems,
-			CreatedAt:  order.CreatedAt.String(),
-			UpdatedAt:  order.UpdatedAt.String(),
-		},
-	}, nil
+	pbOrder := &orderv1.Order{
+		Id:              uint32(order.ID),
+		UserId:          uint32(order.UserID),
+		Status:          string(order.Status),
+		State:           toProtoStatus(order.Status),
+		OrderItems:      pbOrderItems,
+		CreatedAt:       prototime.Legacy(order.CreatedAt),
+		UpdatedAt:       prototime.Legacy(order.UpdatedAt),
+		SubtotalCents:   order.SubtotalCents,
+		TaxCents:        order.TaxCents,
+		TotalCents:      order.TotalCents,
+		Currency:        order.Currency,
+		Cancellation:    toProtoCancellation(order),
+		CustomerDeleted: order.CustomerDeleted,
+		DiscountCents:   order.DiscountCents,
+		PointsRedeemed:  order.PointsRedeemed,
+		CreateTime:      prototime.Timestamp(order.CreatedAt),
+		UpdateTime:      prototime.Timestamp(order.UpdatedAt),
+		RefundTime:      prototime.Optional(order.RefundedAt),
+	}
+	if order.RefundedAt != nil {
+		pbOrder.RefundedAt = prototime.Legacy(*order.RefundedAt)
+	}
+	return pbOrder
+}
+
+// menuItemPriceCents prefers the integer price and falls back to the
+// deprecated floating point price reported by older menu-service builds.
+func menuItemPriceCents(item *menuv1.MenuItem) int64 {
+	if item.PriceCents == 0 && item.Price != 0 {
+		return models.CentsFromFloat(item.Price)
+	}
+	return item.PriceCents
+}
+
+var protoStatuses = map[models.OrderStatus]orderv1.OrderStatus{
+	models.StatusPending:   orderv1.OrderStatus_ORDER_STATUS_PENDING,
+	models.StatusConfirmed: orderv1.OrderStatus_ORDER_STATUS_CONFIRMED,
+	models.StatusPreparing: orderv1.OrderStatus_ORDER_STATUS_PREPARING,
+	models.StatusReady:     orderv1.OrderStatus_ORDER_STATUS_READY,
+	models.StatusCompleted: orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	models.StatusCancelled: orderv1.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+func toProtoStatus(s models.OrderStatus) orderv1.OrderStatus {
+	return protoStatuses[s]
+}
+
+// requestedStatus reads the status an UpdateOrderStatus request moves the
+// order to, falling back to the deprecated string sent by older clients.
+func requestedStatus(req *orderv1.UpdateOrderStatusRequest) (models.OrderStatus, error) {
+	if req.State != orderv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		st, ok := fromProtoStatus(req.State)
+		if !ok {
+			return "", status.Errorf(codes.InvalidArgument, "invalid order status %s", req.State)
+		}
+		return st, nil
+	}
+	if req.Status == "" {
+		return "", status.Error(codes.InvalidArgument, "order status is required")
+	}
+	// JSON clients of the enum-typed status field sent its value names
+	if v, ok := orderv1.OrderStatus_value[req.Status]; ok {
+		req.State = orderv1.OrderStatus(v)
+		return requestedStatus(req)
+	}
+	st, ok := models.ParseOrderStatus(req.Status)
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "invalid order status %q", req.Status)
+	}
+	return st, nil
+}
+
+func fromProtoStatus(s orderv1.OrderStatus) (models.OrderStatus, bool) {
+	for model, pb := range protoStatuses {
+		if pb == s {
+			return model, true
+		}
+	}
+	return "", false
 }
@@ -1,20 +1,25 @@
 package grpc
 
 import (
+	"authz"
 	"context"
-	"testing"
-	"order-service/database"
+	menuv1 "menu-service/proto/menuv1"
 	"order-service/models"
 	orderv1 "order-service/proto/orderv1"
-	userv1 "order-service/proto/userv1"
-	menuv1 "order-service/proto/menuv1"
+	"order-service/store"
+	"strconv"
+	"testing"
+	"time"
+	userv1 "user-service/proto/userv1"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -64,6 +69,110 @@ func (m *MockUserServiceClient) DeleteUser(ctx context.Context, req *userv1.Dele
 	return args.Get(0).(*userv1.DeleteUserResponse), args.Error(1)
 }
 
+func (m *MockUserServiceClient) Login(ctx context.Context, req *userv1.LoginRequest, opts ...grpc.CallOption) (*userv1.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest, opts ...grpc.CallOption) (*userv1.RefreshTokenResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.RefreshTokenResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) Logout(ctx context.Context, req *userv1.LogoutRequest, opts ...grpc.CallOption) (*userv1.LogoutResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.LogoutResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ListDeletedUsers(ctx context.Context, req *userv1.ListDeletedUsersRequest, opts ...grpc.CallOption) (*userv1.ListDeletedUsersResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.ListDeletedUsersResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) RestoreUser(ctx context.Context, req *userv1.RestoreUserRequest, opts ...grpc.CallOption) (*userv1.RestoreUserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.RestoreUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) PurgeUser(ctx context.Context, req *userv1.PurgeUserRequest, opts ...grpc.CallOption) (*userv1.PurgeUserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.PurgeUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ListUserEvents(ctx context.Context, req *userv1.ListUserEventsRequest, opts ...grpc.CallOption) (*userv1.ListUserEventsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.ListUserEventsResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) GetPointsLedger(ctx context.Context, req *userv1.GetPointsLedgerRequest, opts ...grpc.CallOption) (*userv1.GetPointsLedgerResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.GetPointsLedgerResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) RedeemPoints(ctx context.Context, req *userv1.RedeemPointsRequest, opts ...grpc.CallOption) (*userv1.RedeemPointsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.RedeemPointsResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) CreditOrderPoints(ctx context.Context, req *userv1.CreditOrderPointsRequest, opts ...grpc.CallOption) (*userv1.CreditOrderPointsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.CreditOrderPointsResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ReverseOrderPoints(ctx context.Context, req *userv1.ReverseOrderPointsRequest, opts ...grpc.CallOption) (*userv1.ReverseOrderPointsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.ReverseOrderPointsResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) GetLoyaltySettings(ctx context.Context, req *userv1.GetLoyaltySettingsRequest, opts ...grpc.CallOption) (*userv1.GetLoyaltySettingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.GetLoyaltySettingsResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) UpdateLoyaltySettings(ctx context.Context, req *userv1.UpdateLoyaltySettingsRequest, opts ...grpc.CallOption) (*userv1.UpdateLoyaltySettingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.UpdateLoyaltySettingsResponse), args.Error(1)
+}
+
 // MockMenuServiceClient simulates the menu service
 type MockMenuServiceClient struct {
 	mock.Mock
@@ -93,6 +202,14 @@ func (m *MockMenuServiceClient) GetMenuItems(ctx context.Context, req *menuv1.Ge
 	return args.Get(0).(*menuv1.GetMenuItemsResponse), args.Error(1)
 }
 
+func (m *MockMenuServiceClient) BatchGetMenuItems(ctx context.Context, req *menuv1.BatchGetMenuItemsRequest, opts ...grpc.CallOption) (*menuv1.BatchGetMenuItemsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.BatchGetMenuItemsResponse), args.Error(1)
+}
+
 func (m *MockMenuServiceClient) UpdateMenuItem(ctx context.Context, req *menuv1.UpdateMenuItemRequest, opts ...grpc.CallOption) (*menuv1.UpdateMenuItemResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -109,11 +226,147 @@ func (m *MockMenuServiceClient) DeleteMenuItem(ctx context.Context, req *menuv1.
 	return args.Get(0).(*menuv1.DeleteMenuItemResponse), args.Error(1)
 }
 
+func (m *MockMenuServiceClient) ListDeletedMenuItems(ctx context.Context, req *menuv1.ListDeletedMenuItemsRequest, opts ...grpc.CallOption) (*menuv1.ListDeletedMenuItemsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ListDeletedMenuItemsResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) RestoreMenuItem(ctx context.Context, req *menuv1.RestoreMenuItemRequest, opts ...grpc.CallOption) (*menuv1.RestoreMenuItemResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.RestoreMenuItemResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) PurgeMenuItem(ctx context.Context, req *menuv1.PurgeMenuItemRequest, opts ...grpc.CallOption) (*menuv1.PurgeMenuItemResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.PurgeMenuItemResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ListMenuItemEvents(ctx context.Context, req *menuv1.ListMenuItemEventsRequest, opts ...grpc.CallOption) (*menuv1.ListMenuItemEventsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ListMenuItemEventsResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) AdjustStock(ctx context.Context, req *menuv1.AdjustStockRequest, opts ...grpc.CallOption) (*menuv1.AdjustStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.AdjustStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ReserveStock(ctx context.Context, req *menuv1.ReserveStockRequest, opts ...grpc.CallOption) (*menuv1.ReserveStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ReserveStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ConfirmStock(ctx context.Context, req *menuv1.ConfirmStockRequest, opts ...grpc.CallOption) (*menuv1.ConfirmStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ConfirmStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ReleaseStock(ctx context.Context, req *menuv1.ReleaseStockRequest, opts ...grpc.CallOption) (*menuv1.ReleaseStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ReleaseStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) CreateCategory(ctx context.Context, req *menuv1.CreateCategoryRequest, opts ...grpc.CallOption) (*menuv1.CreateCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.CreateCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) GetCategory(ctx context.Context, req *menuv1.GetCategoryRequest, opts ...grpc.CallOption) (*menuv1.GetCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) GetCategories(ctx context.Context, req *menuv1.GetCategoriesRequest, opts ...grpc.CallOption) (*menuv1.GetCategoriesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetCategoriesResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) UpdateCategory(ctx context.Context, req *menuv1.UpdateCategoryRequest, opts ...grpc.CallOption) (*menuv1.UpdateCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.UpdateCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) DeleteCategory(ctx context.Context, req *menuv1.DeleteCategoryRequest, opts ...grpc.CallOption) (*menuv1.DeleteCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.DeleteCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) CreateTag(ctx context.Context, req *menuv1.CreateTagRequest, opts ...grpc.CallOption) (*menuv1.CreateTagResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.CreateTagResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) GetTags(ctx context.Context, req *menuv1.GetTagsRequest, opts ...grpc.CallOption) (*menuv1.GetTagsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetTagsResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) UpdateTag(ctx context.Context, req *menuv1.UpdateTagRequest, opts ...grpc.CallOption) (*menuv1.UpdateTagResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.UpdateTagResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) DeleteTag(ctx context.Context, req *menuv1.DeleteTagRequest, opts ...grpc.CallOption) (*menuv1.DeleteTagResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.DeleteTagResponse), args.Error(1)
+}
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&models.Order{}, &models.OrderItem{})
+	err = store.Migrate(db)
 	require.NoError(t, err)
 
 	return db
@@ -125,15 +378,23 @@ func teardownTestDB(t *testing.T, db *gorm.DB) {
 	sqlDB.Close()
 }
 
+// asUser returns a context authenticated as user id, as the authz
+// interceptor would set it.
+func asUser(id uint32, owner bool) context.Context {
+	claims := &authz.Claims{CafeOwner: owner}
+	claims.Subject = strconv.FormatUint(uint64(id), 10)
+	return authz.NewContext(context.Background(), claims)
+}
+
 func TestCreateOrder_Success(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
 	mockUserClient := new(MockUserServiceClient)
 	mockMenuClient := new(MockMenuServiceClient)
 
 	server := &OrderServer{
+		store:      store.NewGormStore(db),
 		UserClient: mockUserClient,
 		MenuClient: mockMenuClient,
 	}
@@ -143,9 +404,9 @@ func TestCreateOrder_Success(t *testing.T) {
 			User: &userv1.User{Id: 1, Name: "Test User"},
 		}, nil)
 
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
-		Return(&menuv1.GetMenuItemResponse{
-			MenuItem: &menuv1.MenuItem{Id: 1, Name: "Coffee", Price: 2.50},
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: 2.50}},
 		}, nil)
 
 	ctx := context.Background()
@@ -169,12 +430,12 @@ func TestCreateOrder_Success(t *testing.T) {
 func TestCreateOrder_InvalidUser(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
 	mockUserClient := new(MockUserServiceClient)
 	mockMenuClient := new(MockMenuServiceClient)
 
 	server := &OrderServer{
+		store:      store.NewGormStore(db),
 		UserClient: mockUserClient,
 		MenuClient: mockMenuClient,
 	}
@@ -194,21 +455,45 @@ func TestCreateOrder_InvalidUser(t *testing.T) {
 	assert.Nil(t, resp)
 	st, ok := status.FromError(err)
 	require.True(t, ok)
-	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
 	assert.Contains(t, st.Message(), "user not found")
 
 	mockUserClient.AssertExpectations(t)
 }
 
+func TestCreateOrder_DependencyDown(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(nil, status.Error(codes.DeadlineExceeded, "context deadline exceeded"))
+
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "cannot look up menu items: context deadline exceeded", st.Message())
+}
+
 func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
 	mockUserClient := new(MockUserServiceClient)
 	mockMenuClient := new(MockMenuServiceClient)
 
 	server := &OrderServer{
+		store:      store.NewGormStore(db),
 		UserClient: mockUserClient,
 		MenuClient: mockMenuClient,
 	}
@@ -218,8 +503,8 @@ func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 			User: &userv1.User{Id: 1, Name: "Test User"},
 		}, nil)
 
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 999}).
-		Return(nil, status.Errorf(codes.NotFound, "menu item not found"))
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{999}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{MissingIds: []uint32{999}}, nil)
 
 	ctx := context.Background()
 	resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
@@ -233,9 +518,503 @@ func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	assert.Nil(t, resp)
 	st, ok := status.FromError(err)
 	require.True(t, ok)
-	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
 	assert.Contains(t, st.Message(), "menu item 999 not found")
 
 	mockUserClient.AssertExpectations(t)
 	mockMenuClient.AssertExpectations(t)
 }
+
+func TestUpdateOrderStatus_Transitions(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		current      models.OrderStatus
+		next         orderv1.OrderStatus
+		expectedCode codes.Code
+	}{
+		{"pending to confirmed", models.StatusPending, orderv1.OrderStatus_ORDER_STATUS_CONFIRMED, codes.OK},
+		{"ready to completed", models.StatusReady, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, codes.OK},
+		{"cancel through status update", models.StatusPreparing, orderv1.OrderStatus_ORDER_STATUS_CANCELLED, codes.InvalidArgument},
+		{"pending to completed", models.StatusPending, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, codes.FailedPrecondition},
+		{"completed back to pending", models.StatusCompleted, orderv1.OrderStatus_ORDER_STATUS_PENDING, codes.FailedPrecondition},
+		{"cancelled to ready", models.StatusCancelled, orderv1.OrderStatus_ORDER_STATUS_READY, codes.FailedPrecondition},
+		{"unspecified status", models.StatusPending, orderv1.OrderStatus_ORDER_STATUS_UNSPECIFIED, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.Order{UserID: 1, Status: tt.current}
+			require.NoError(t, db.Create(&order).Error)
+
+			resp, err := server.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
+				Id:    uint32(order.ID),
+				State: tt.next,
+			})
+
+			if tt.expectedCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, resp.Order.State)
+
+			var stored models.Order
+			require.NoError(t, db.First(&stored, order.ID).Error)
+			assert.Equal(t, tt.next, toProtoStatus(stored.Status))
+		})
+	}
+}
+
+func TestUpdateOrderStatus_RejectionNamesStates(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+
+	order := models.Order{UserID: 1, Status: models.StatusCompleted}
+	require.NoError(t, db.Create(&order).Error)
+
+	_, err := server.UpdateOrderStatus(context.Background(), &orderv1.UpdateOrderStatusRequest{
+		Id:    uint32(order.ID),
+		State: orderv1.OrderStatus_ORDER_STATUS_PENDING,
+	})
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Contains(t, st.Message(), "ORDER_STATUS_COMPLETED")
+	assert.Contains(t, st.Message(), "ORDER_STATUS_PENDING")
+}
+
+func TestUpdateOrderStatus_DeprecatedStatus(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		status       string
+		expectedCode codes.Code
+	}{
+		{"status name", "confirmed", codes.OK},
+		{"enum value name", "ORDER_STATUS_CONFIRMED", codes.OK},
+		{"pre-enum alias", "accepted", codes.OK},
+		{"unknown", "shipped", codes.InvalidArgument},
+		{"neither field set", "", codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.Order{UserID: 1, Status: models.StatusPending}
+			require.NoError(t, db.Create(&order).Error)
+
+			resp, err := server.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{
+				Id:     uint32(order.ID),
+				Status: tt.status,
+			})
+			if tt.expectedCode != codes.OK {
+				assert.Equal(t, tt.expectedCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_CONFIRMED, resp.Order.State)
+			assert.Equal(t, "confirmed", resp.Order.Status)
+		})
+	}
+}
+
+func TestNormalizeOrderStatuses(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	legacy := map[string]models.OrderStatus{
+		"Pending":     models.StatusPending,
+		"in progress": models.StatusPreparing,
+		"done":        models.StatusCompleted,
+		"canceled":    models.StatusCancelled,
+		"banana":      models.StatusPending,
+		"ready":       models.StatusReady,
+	}
+
+	ids := make(map[string]uint)
+	for raw := range legacy {
+		order := models.Order{UserID: 1, Status: models.OrderStatus(raw)}
+		require.NoError(t, db.Create(&order).Error)
+		ids[raw] = order.ID
+	}
+
+	require.NoError(t, store.NormalizeOrderStatuses(db))
+
+	for raw, want := range legacy {
+		var stored models.Order
+		require.NoError(t, db.First(&stored, ids[raw]).Error)
+		assert.Equal(t, want, stored.Status, "legacy status %q", raw)
+	}
+}
+
+func TestCreateOrder_Totals(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		store:              store.NewGormStore(db),
+		UserClient:         mockUserClient,
+		MenuClient:         mockMenuClient,
+		TaxRateBasisPoints: 825,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", PriceCents: 250, Currency: "USD"},
+				{Id: 2, Name: "Muffin", PriceCents: 199, Currency: "USD"},
+			},
+		}, nil)
+
+	ctx := context.Background()
+	resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 2},
+			{MenuItemId: 2, Quantity: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	// 2 x 250 + 3 x 199 = 1097, tax 8.25% = 90.50 rounded half up to 91
+	assert.Equal(t, int64(500), resp.Order.OrderItems[0].LineTotalCents)
+	assert.Equal(t, int64(597), resp.Order.OrderItems[1].LineTotalCents)
+	assert.Equal(t, int64(1097), resp.Order.SubtotalCents)
+	assert.Equal(t, int64(91), resp.Order.TaxCents)
+	assert.Equal(t, int64(1188), resp.Order.TotalCents)
+	assert.Equal(t, "USD", resp.Order.Currency)
+
+	getResp, err := server.GetOrder(asUser(1, true), &orderv1.GetOrderRequest{Id: resp.Order.Id})
+	require.NoError(t, err)
+	assert.Equal(t, resp.Order.SubtotalCents, getResp.Order.SubtotalCents)
+	assert.Equal(t, resp.Order.TaxCents, getResp.Order.TaxCents)
+	assert.Equal(t, resp.Order.TotalCents, getResp.Order.TotalCents)
+	assert.Equal(t, resp.Order.Currency, getResp.Order.Currency)
+}
+
+func TestCreateOrder_MixedCurrencies(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", PriceCents: 250, Currency: "USD"},
+				{Id: 2, Name: "Croissant", PriceCents: 300, Currency: "EUR"},
+			},
+		}, nil)
+
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 2, Quantity: 1},
+		},
+	})
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestGetOrders_PaginationAndFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	ctx := context.Background()
+
+	now := time.Now()
+	orders := []models.Order{
+		{UserID: 1, Status: models.StatusPending},
+		{UserID: 2, Status: models.StatusPending},
+		{UserID: 1, Status: models.StatusCompleted},
+		{UserID: 1, Status: models.StatusPending},
+	}
+	for i := range orders {
+		orders[i].CreatedAt = now.Add(time.Duration(i-len(orders)) * time.Hour)
+		orders[i].OrderItems = []models.OrderItem{{MenuItemID: 1, Quantity: 1, UnitPriceCents: 100}}
+		require.NoError(t, db.Create(&orders[i]).Error)
+	}
+
+	t.Run("pages by user", func(t *testing.T) {
+		first, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{UserId: 1, PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, first.Orders, 2)
+		assert.Len(t, first.Orders[0].OrderItems, 1)
+		require.NotEmpty(t, first.NextPageToken)
+
+		second, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{UserId: 1, PageSize: 2, PageToken: first.NextPageToken})
+		require.NoError(t, err)
+		require.Len(t, second.Orders, 1)
+		assert.Empty(t, second.NextPageToken)
+		assert.Equal(t, uint32(orders[3].ID), second.Orders[0].Id)
+	})
+
+	t.Run("filter by status", func(t *testing.T) {
+		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{Status: orderv1.OrderStatus_ORDER_STATUS_COMPLETED})
+		require.NoError(t, err)
+		require.Len(t, resp.Orders, 1)
+		assert.Equal(t, uint32(orders[2].ID), resp.Orders[0].Id)
+	})
+
+	t.Run("filter by creation time", func(t *testing.T) {
+		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{
+			CreatedAfter:  timestamppb.New(orders[1].CreatedAt),
+			CreatedBefore: timestamppb.New(orders[3].CreatedAt),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Orders, 2)
+		assert.Equal(t, uint32(orders[1].ID), resp.Orders[0].Id)
+		assert.Equal(t, uint32(orders[2].ID), resp.Orders[1].Id)
+	})
+}
+
+func TestCreateOrder_ReportsAllMissingMenuItems(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 7, 8, 7}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems:  []*menuv1.MenuItem{{Id: 1, Name: "Coffee", PriceCents: 250}},
+			MissingIds: []uint32{7, 8},
+		}, nil)
+
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 7, Quantity: 1},
+			{MenuItemId: 8, Quantity: 2},
+			{MenuItemId: 7, Quantity: 1},
+		},
+	})
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, "menu items 7, 8 not found", st.Message())
+	mockMenuClient.AssertNumberOfCalls(t, "BatchGetMenuItems", 1)
+	mockMenuClient.AssertNotCalled(t, "GetMenuItem", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_Idempotency(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", PriceCents: 250}},
+		}, nil)
+
+	ctx := context.Background()
+	request := func(key string, quantity uint32) *orderv1.CreateOrderRequest {
+		return &orderv1.CreateOrderRequest{
+			UserId:         1,
+			Items:          []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: quantity}},
+			IdempotencyKey: key,
+		}
+	}
+
+	original, err := server.CreateOrder(ctx, request("retry-1", 2))
+	require.NoError(t, err)
+
+	t.Run("replay returns the original order", func(t *testing.T) {
+		replay, err := server.CreateOrder(ctx, request("retry-1", 2))
+		require.NoError(t, err)
+		assert.Equal(t, original.Order.Id, replay.Order.Id)
+		assert.Equal(t, original.Order.TotalCents, replay.Order.TotalCents)
+		mockUserClient.AssertNumberOfCalls(t, "GetUser", 1)
+	})
+
+	t.Run("key sent as metadata", func(t *testing.T) {
+		mdCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "retry-1"))
+		replay, err := server.CreateOrder(mdCtx, request("", 2))
+		require.NoError(t, err)
+		assert.Equal(t, original.Order.Id, replay.Order.Id)
+	})
+
+	t.Run("same key different payload", func(t *testing.T) {
+		_, err := server.CreateOrder(ctx, request("retry-1", 3))
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.AlreadyExists, st.Code())
+	})
+
+	t.Run("expired key creates a new order", func(t *testing.T) {
+		require.NoError(t, db.Model(&models.IdempotencyKey{}).
+			Where("key = ?", "retry-1").
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		fresh, err := server.CreateOrder(ctx, request("retry-1", 3))
+		require.NoError(t, err)
+		assert.NotEqual(t, original.Order.Id, fresh.Order.Id)
+
+		var count int64
+		require.NoError(t, db.Model(&models.IdempotencyKey{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("purge removes expired keys", func(t *testing.T) {
+		purged, err := server.store.PurgeExpiredIdempotencyKeys(ctx, time.Now().Add(DefaultIdempotencyRetention+time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+}
+
+func TestCreateOrder_StockReservation(t *testing.T) {
+	stock := int64(5)
+	lookup := &menuv1.BatchGetMenuItemsResponse{
+		MenuItems: []*menuv1.MenuItem{
+			{Id: 1, Name: "Muffin", PriceCents: 250, Stock: &stock},
+			{Id: 2, Name: "Coffee", PriceCents: 300},
+		},
+	}
+	request := &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 2},
+			{MenuItemId: 2, Quantity: 1},
+		},
+	}
+	// Only the stock-tracked muffin is reserved
+	reservation := &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{{MenuItemId: 1, Quantity: 2}}}
+
+	setup := func(t *testing.T) (*OrderServer, *MockMenuServiceClient, *gorm.DB) {
+		db := setupTestDB(t)
+		t.Cleanup(func() { teardownTestDB(t, db) })
+
+		mockUserClient := new(MockUserServiceClient)
+		mockMenuClient := new(MockMenuServiceClient)
+		mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+			Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+		mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).Return(lookup, nil)
+
+		return NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient), mockMenuClient, db
+	}
+
+	t.Run("reserves tracked items", func(t *testing.T) {
+		server, mockMenuClient, db := setup(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, reservation).
+			Return(&menuv1.ReserveStockResponse{ReservationId: "res-1"}, nil)
+		// Once stored, the order keeps its stock past the reservation's expiry
+		mockMenuClient.On("ConfirmStock", mock.Anything, &menuv1.ConfirmStockRequest{ReservationId: "res-1"}).
+			Return(&menuv1.ConfirmStockResponse{}, nil)
+
+		resp, err := server.CreateOrder(context.Background(), request)
+		require.NoError(t, err)
+
+		var stored models.Order
+		require.NoError(t, db.First(&stored, resp.Order.Id).Error)
+		assert.Equal(t, "res-1", stored.StockReservationID)
+		mockMenuClient.AssertExpectations(t)
+	})
+
+	t.Run("sold out", func(t *testing.T) {
+		server, mockMenuClient, _ := setup(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, reservation).
+			Return(nil, status.Errorf(codes.FailedPrecondition, "not enough stock for menu items: 1"))
+
+		_, err := server.CreateOrder(context.Background(), request)
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("failed insert releases the reservation", func(t *testing.T) {
+		server, mockMenuClient, db := setup(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, reservation).
+			Return(&menuv1.ReserveStockResponse{ReservationId: "res-2"}, nil)
+		mockMenuClient.On("ReleaseStock", mock.Anything, &menuv1.ReleaseStockRequest{ReservationId: "res-2"}).
+			Return(&menuv1.ReleaseStockResponse{}, nil)
+		require.NoError(t, db.Migrator().DropTable(&models.OrderItem{}))
+
+		_, err := server.CreateOrder(context.Background(), request)
+		require.Error(t, err)
+		mockMenuClient.AssertExpectations(t)
+	})
+}
+
+func TestCreateOrder_UnavailableItems(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := NewOrderServer(store.NewGormStore(db), mockUserClient, mockMenuClient)
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", PriceCents: 300},
+				{Id: 2, Name: "Pancakes", PriceCents: 650, Unavailable: true},
+				{Id: 3, Name: "Omelette", PriceCents: 700, Unavailable: true},
+			},
+		}, nil)
+
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 2, Quantity: 1},
+			{MenuItemId: 3, Quantity: 1},
+		},
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Contains(t, st.Message(), "Pancakes, Omelette")
+
+	var count int64
+	db.Model(&models.Order{}).Count(&count)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// releaseTimeout bounds how long confirming or returning reserved stock may
+// take once the original request has finished or failed.
+const releaseTimeout = 5 * time.Second
+
+// reserveStock reserves stock for the order lines whose menu items track it
+// and returns the reservation ID, or an empty ID if nothing needed reserving.
+// Only services may hold stock, so the stock calls authenticate with
+// ServiceCallOptions rather than the caller's credentials.
+func (s *OrderServer) reserveStock(ctx context.Context, items []*orderv1.OrderItemRequest, menuItems map[uint32]*menuv1.MenuItem) (string, error) {
+	var lines []*menuv1.StockLine
+	for _, item := range items {
+		if menuItems[item.MenuItemId].Stock == nil {
+			continue
+		}
+		lines = append(lines, &menuv1.StockLine{
+			MenuItemId: item.MenuItemId,
+			Quantity:   int64(item.Quantity),
+		})
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	resp, err := s.MenuClient.ReserveStock(ctx, &menuv1.ReserveStockRequest{Lines: lines}, s.ServiceCallOptions...)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return "", status.Errorf(codes.FailedPrecondition, "sold out: %s", status.Convert(err).Message())
+		}
+		return "", dependencyError(ctx, err, "cannot reserve stock")
+	}
+	return resp.ReservationId, nil
+}
+
+// confirmStock keeps the stock reserved for a placed order. It runs on its
+// own deadline like releaseStock. A reservation that cannot be confirmed
+// expires and its stock goes back on sale, which is preferred to failing an
+// order that is already stored.
+func (s *OrderServer) confirmStock(reservationID string) {
+	if reservationID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	req := &menuv1.ConfirmStockRequest{ReservationId: reservationID}
+	if _, err := s.MenuClient.ConfirmStock(ctx, req, s.ServiceCallOptions...); err != nil {
+		log.Printf("Failed to confirm stock reservation %s: %v", reservationID, err)
+	}
+}
+
+// releaseStock gives reserved stock back to the menu. It runs on its own
+// deadline because the request that reserved the stock may already be gone.
+func (s *OrderServer) releaseStock(reservationID string) {
+	if reservationID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	req := &menuv1.ReleaseStockRequest{ReservationId: reservationID}
+	if _, err := s.MenuClient.ReleaseStock(ctx, req, s.ServiceCallOptions...); err != nil {
+		log.Printf("Failed to release stock reservation %s: %v", reservationID, err)
+	}
+}
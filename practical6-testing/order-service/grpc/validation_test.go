@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+	"testing"
+	userv1 "user-service/proto/userv1"
+	"validation"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRequestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    proto.Message
+		wantFields []string
+	}{
+		{
+			name: "valid order",
+			request: &orderv1.CreateOrderRequest{UserId: 1, Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: 1, Quantity: 2},
+			}},
+		},
+		{
+			name:       "no items",
+			request:    &orderv1.CreateOrderRequest{UserId: 1},
+			wantFields: []string{"items"},
+		},
+		{
+			name: "zero quantity",
+			request: &orderv1.CreateOrderRequest{UserId: 1, Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: 1, Quantity: 1},
+				{MenuItemId: 2},
+			}},
+			wantFields: []string{"items[1].quantity"},
+		},
+		{
+			name:       "undefined state",
+			request:    &orderv1.UpdateOrderStatusRequest{Id: 1, State: 99},
+			wantFields: []string{"state"},
+		},
+		{
+			name:       "cancellation without reason",
+			request:    &orderv1.CancelOrderRequest{Id: 1},
+			wantFields: []string{"reason"},
+		},
+		{
+			name:       "unknown status filter",
+			request:    &orderv1.WatchOrdersRequest{Statuses: []orderv1.OrderStatus{orderv1.OrderStatus(42)}},
+			wantFields: []string{"statuses[0]"},
+		},
+		// The vendored user and menu clients carry the same rules as the
+		// services that own them.
+		{
+			name:       "vendored user request",
+			request:    &userv1.CreateUserRequest{Email: "not-an-email"},
+			wantFields: []string{"name", "email"},
+		},
+		{
+			name:       "vendored stock line",
+			request:    &menuv1.ReserveStockRequest{Lines: []*menuv1.StockLine{{MenuItemId: 1}}},
+			wantFields: []string{"lines[0].quantity"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Check(tt.request)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			var fields []string
+			for _, v := range validation.FieldViolations(err) {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"authz"
+	"dberr"
+	"order-service/models"
+	orderv1 "order-service/proto/orderv1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *OrderServer) WatchOrder(req *orderv1.WatchOrderRequest, stream orderv1.OrderService_WatchOrderServer) error {
+	if s.Hub == nil {
+		return status.Errorf(codes.Unimplemented, "order watching is not enabled")
+	}
+
+	// Subscribe before reading the order so no change is missed in between
+	sub := s.Hub.Subscribe(func(order *orderv1.Order) bool {
+		return order.Id == req.Id
+	})
+	defer sub.Cancel()
+
+	order, err := s.store.GetOrder(stream.Context(), uint(req.Id))
+	if err != nil {
+		return dberr.Status(err, "order")
+	}
+	if err := authz.AuthorizeUser(stream.Context(), uint32(order.UserID)); err != nil {
+		return err
+	}
+
+	if err := stream.Send(&orderv1.WatchOrderResponse{Order: toProtoOrder(order)}); err != nil {
+		return err
+	}
+
+	// Forward every change to the order, not just status moves, so that
+	// watchers also see refunds
+	last, refunded := order.Status, order.RefundedAt != nil
+	lastUpdate := updateTime(order.UpdatedAt)
+	for !watchFinished(last, refunded) {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-sub.Updates:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "watcher fell too far behind")
+			}
+
+			// Anything not newer than what was sent was published before
+			// the order was read
+			updatedAt := updateTime(update.UpdateTime.AsTime())
+			if !updatedAt.After(lastUpdate) {
+				continue
+			}
+
+			if err := stream.Send(&orderv1.WatchOrderResponse{Order: update}); err != nil {
+				return err
+			}
+			lastUpdate = updatedAt
+			last, _ = fromProtoStatus(update.State)
+			refunded = update.RefundTime != nil
+		}
+	}
+	return nil
+}
+
+// watchFinished reports whether an order can no longer change, ending
+// WatchOrder: cancelled orders, and completed orders once refunded.
+func watchFinished(st models.OrderStatus, refunded bool) bool {
+	return st == models.StatusCancelled || st == models.StatusCompleted && refunded
+}
+
+// updateTime truncates t to the microseconds databases keep, so an update
+// time read back from the store compares equal to the published one.
+func updateTime(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
+}
+
+func (s *OrderServer) WatchOrders(req *orderv1.WatchOrdersRequest, stream orderv1.OrderService_WatchOrdersServer) error {
+	if s.Hub == nil {
+		return status.Errorf(codes.Unimplemented, "order watching is not enabled")
+	}
+
+	sub := s.Hub.Subscribe(func(order *orderv1.Order) bool {
+		if req.UserId != 0 && order.UserId != req.UserId {
+			return false
+		}
+		if len(req.Statuses) == 0 {
+			return true
+		}
+		for _, st := range req.Statuses {
+			if order.State == st {
+				return true
+			}
+		}
+		return false
+	})
+	defer sub.Cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-sub.Updates:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "watcher fell too far behind")
+			}
+			if err := stream.Send(&orderv1.WatchOrdersResponse{Order: update}); err != nil {
+				return err
+			}
+		}
+	}
+}
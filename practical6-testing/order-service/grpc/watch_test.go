@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"authz"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"order-service/models"
+	orderv1 "order-service/proto/orderv1"
+	"order-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startWatchServer serves server over an in-memory connection behind the
+// authz interceptor, with the client signed in as a cafe owner.
+func startWatchServer(t *testing.T, server *OrderServer) orderv1.OrderServiceClient {
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	token, err := authz.IssueAccessToken(keys, 1, true, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.StreamInterceptor(authz.NewAuthorizer(keys, Policy).StreamServerInterceptor()))
+	orderv1.RegisterOrderServiceServer(s, server)
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+			return streamer(ctx, desc, cc, method, opts...)
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return orderv1.NewOrderServiceClient(conn)
+}
+
+func TestWatchOrder_StreamsUntilTerminal(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient))
+	client := startWatchServer(t, server)
+	ctx := context.Background()
+
+	order := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+
+	stream, err := client.WatchOrder(ctx, &orderv1.WatchOrderRequest{Id: uint32(order.ID)})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PENDING, resp.Order.State)
+
+	for _, next := range []orderv1.OrderStatus{
+		orderv1.OrderStatus_ORDER_STATUS_CONFIRMED,
+		orderv1.OrderStatus_ORDER_STATUS_PREPARING,
+		orderv1.OrderStatus_ORDER_STATUS_READY,
+		orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	} {
+		_, err := server.UpdateOrderStatus(ctx, &orderv1.UpdateOrderStatusRequest{Id: uint32(order.ID), State: next})
+		require.NoError(t, err)
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, next, resp.Order.State)
+	}
+
+	// A completed order may still be refunded, which keeps the same status
+	_, err = server.RefundOrder(ctx, &orderv1.RefundOrderRequest{Id: uint32(order.ID)})
+	require.NoError(t, err)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, resp.Order.State)
+	assert.NotNil(t, resp.Order.RefundTime)
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestWatchOrder_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	client := startWatchServer(t, NewOrderServer(store.NewGormStore(db), new(MockUserServiceClient), new(MockMenuServiceClient)))
+
+	stream, err := client.WatchOrder(context.Background(), &orderv1.WatchOrderRequest{Id: 999})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
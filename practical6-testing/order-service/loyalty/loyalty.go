@@ -0,0 +1,77 @@
+// Package loyalty keeps customers' loyalty points in step with their orders
+// by following the order events relayed from the outbox.
+package loyalty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Publisher credits points when an order is completed and reverses an
+// order's points when it is cancelled or refunded. user-service ignores
+// repeated credits and reversals, so redelivered events are harmless.
+type Publisher struct {
+	Users userv1.UserServiceClient
+	// CallOptions are passed on every call, typically to authenticate as a
+	// service.
+	CallOptions []grpc.CallOption
+}
+
+func NewPublisher(users userv1.UserServiceClient, opts ...grpc.CallOption) *Publisher {
+	return &Publisher{Users: users, CallOptions: opts}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event outbox.Event) error {
+	var payload struct {
+		Order json.RawMessage `json:"order"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("decode event %d: %w", event.Sequence, err)
+	}
+	order := &orderv1.Order{}
+	if err := protojson.Unmarshal(payload.Order, order); err != nil {
+		return fmt.Errorf("decode order in event %d: %w", event.Sequence, err)
+	}
+
+	var err error
+	// Orders of purged customers have no one left to credit or refund
+	if order.UserId == 0 {
+		return nil
+	}
+
+	switch event.Type {
+	case outbox.OrderStatusChanged:
+		// Events recorded before the state field existed carry the enum
+		// name in status instead
+		completed := orderv1.OrderStatus_ORDER_STATUS_COMPLETED
+		if order.State != completed && order.Status != completed.String() {
+			return nil
+		}
+		// Points are earned on what the customer paid before tax
+		_, err = p.Users.CreditOrderPoints(ctx, &userv1.CreditOrderPointsRequest{
+			UserId:      order.UserId,
+			OrderId:     order.Id,
+			AmountCents: order.SubtotalCents - order.DiscountCents,
+		}, p.CallOptions...)
+	case outbox.OrderCancelled, outbox.OrderRefunded:
+		_, err = p.Users.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: order.Id, UserId: order.UserId}, p.CallOptions...)
+	default:
+		return nil
+	}
+
+	// The customer has been purged along with their points
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
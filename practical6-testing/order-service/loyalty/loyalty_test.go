@@ -0,0 +1,85 @@
+package loyalty
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fakeUsers records the credits and reversals it is asked to make.
+type fakeUsers struct {
+	userv1.UserServiceClient
+	credits   []*userv1.CreditOrderPointsRequest
+	reversals []uint32
+	err       error
+}
+
+func (f *fakeUsers) CreditOrderPoints(ctx context.Context, req *userv1.CreditOrderPointsRequest, opts ...grpc.CallOption) (*userv1.CreditOrderPointsResponse, error) {
+	f.credits = append(f.credits, req)
+	return &userv1.CreditOrderPointsResponse{}, f.err
+}
+
+func (f *fakeUsers) ReverseOrderPoints(ctx context.Context, req *userv1.ReverseOrderPointsRequest, opts ...grpc.CallOption) (*userv1.ReverseOrderPointsResponse, error) {
+	f.reversals = append(f.reversals, req.OrderId)
+	return &userv1.ReverseOrderPointsResponse{}, f.err
+}
+
+func orderEvent(t *testing.T, eventType string, order *orderv1.Order) outbox.Event {
+	pbOrder, err := protojson.Marshal(order)
+	require.NoError(t, err)
+	payload, err := json.Marshal(map[string]json.RawMessage{"order": pbOrder})
+	require.NoError(t, err)
+	return outbox.Event{Type: eventType, OrderID: order.Id, Payload: payload}
+}
+
+func TestPublisher(t *testing.T) {
+	ctx := context.Background()
+	completed := &orderv1.Order{Id: 7, UserId: 3, State: orderv1.OrderStatus_ORDER_STATUS_COMPLETED, SubtotalCents: 1200, DiscountCents: 200}
+
+	t.Run("completed order earns on what was paid before tax", func(t *testing.T) {
+		users := &fakeUsers{}
+		require.NoError(t, NewPublisher(users).Publish(ctx, orderEvent(t, outbox.OrderStatusChanged, completed)))
+		require.Len(t, users.credits, 1)
+		assert.Equal(t, uint32(3), users.credits[0].UserId)
+		assert.Equal(t, uint32(7), users.credits[0].OrderId)
+		assert.Equal(t, int64(1000), users.credits[0].AmountCents)
+	})
+
+	t.Run("other status changes earn nothing", func(t *testing.T) {
+		users := &fakeUsers{}
+		ready := &orderv1.Order{Id: 7, UserId: 3, State: orderv1.OrderStatus_ORDER_STATUS_READY}
+		require.NoError(t, NewPublisher(users).Publish(ctx, orderEvent(t, outbox.OrderStatusChanged, ready)))
+		require.NoError(t, NewPublisher(users).Publish(ctx, orderEvent(t, outbox.OrderCreated, ready)))
+		assert.Empty(t, users.credits)
+		assert.Empty(t, users.reversals)
+	})
+
+	t.Run("cancelled and refunded orders are reversed", func(t *testing.T) {
+		users := &fakeUsers{}
+		publisher := NewPublisher(users)
+		require.NoError(t, publisher.Publish(ctx, orderEvent(t, outbox.OrderCancelled, &orderv1.Order{Id: 8, UserId: 3})))
+		require.NoError(t, publisher.Publish(ctx, orderEvent(t, outbox.OrderRefunded, completed)))
+		assert.Equal(t, []uint32{8, 7}, users.reversals)
+	})
+
+	t.Run("purged customer is skipped", func(t *testing.T) {
+		users := &fakeUsers{err: status.Error(codes.NotFound, "user not found")}
+		assert.NoError(t, NewPublisher(users).Publish(ctx, orderEvent(t, outbox.OrderStatusChanged, completed)))
+	})
+
+	t.Run("unavailable user service is retried", func(t *testing.T) {
+		users := &fakeUsers{err: status.Error(codes.Unavailable, "connection refused")}
+		assert.Error(t, NewPublisher(users).Publish(ctx, orderEvent(t, outbox.OrderRefunded, completed)))
+	})
+}
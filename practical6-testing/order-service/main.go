@@ -1,78 +1,212 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
-	"order-service/database"
+	"authz"
+	"envconfig"
+	"graceful"
+	"healthcheck"
+	menuv1 "menu-service/proto/menuv1"
+	"order-service/consistency"
+	"order-service/downstream"
 	ordergrpc "order-service/grpc"
-	menuv1 "order-service/proto/menuv1"
+	"order-service/loyalty"
+	"order-service/outbox"
 	orderv1 "order-service/proto/orderv1"
-	userv1 "order-service/proto/userv1"
+	"order-service/store"
+	"storedriver"
+	userv1 "user-service/proto/userv1"
+	"validation"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Get database connection string from environment
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "5432")
-	dbUser := getEnv("DB_USER", "postgres")
-	dbPassword := getEnv("DB_PASSWORD", "postgres")
-	dbName := getEnv("DB_NAME", "orderdb")
-
-	dsn := "host=" + dbHost + " port=" + dbPort + " user=" + dbUser + 
-		" password=" + dbPassword + " dbname=" + dbName + " sslmode=disable"
-
-	// Connect to database
-	if err := database.Connect(dsn); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-
-	// Run migrations
-	if err := database.Migrate(); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	// Connect to the store selected by STORE_DRIVER and run migrations
+	storeConfig := storedriver.FromEnv("orderdb", "orders.db")
+	orderStore, err := store.Open(storeConfig)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
 	}
+	log.Printf("Using %s store", storeConfig.Driver)
 
 	// Connect to user service
-	userServiceAddr := getEnv("USER_SERVICE_ADDR", "localhost:50051")
-	userConn, err := grpc.Dial(userServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	userServiceAddr := envconfig.String("USER_SERVICE_ADDR", "localhost:50051")
+	userConn, err := grpc.Dial(userServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(authz.ForwardToken, validation.UnaryClientInterceptor))
 	if err != nil {
 		log.Fatalf("Failed to connect to user service: %v", err)
 	}
-	defer userConn.Close()
 	userClient := userv1.NewUserServiceClient(userConn)
 
 	// Connect to menu service
-	menuServiceAddr := getEnv("MENU_SERVICE_ADDR", "localhost:50052")
-	menuConn, err := grpc.Dial(menuServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	menuServiceAddr := envconfig.String("MENU_SERVICE_ADDR", "localhost:50052")
+	menuConn, err := grpc.Dial(menuServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(authz.ForwardToken, validation.UnaryClientInterceptor))
 	if err != nil {
 		log.Fatalf("Failed to connect to menu service: %v", err)
 	}
-	defer menuConn.Close()
 	menuClient := menuv1.NewMenuServiceClient(menuConn)
 
 	// Create gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50053")
+	grpcPort := envconfig.String("GRPC_PORT", "50053")
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	orderv1.RegisterOrderServiceServer(s, ordergrpc.NewOrderServer(userClient, menuClient))
+	// Tax charged on new orders, in basis points (825 = 8.25%)
+	taxRate, err := strconv.ParseInt(envconfig.String("TAX_RATE_BASIS_POINTS", "0"), 10, 64)
+	if err != nil || taxRate < 0 {
+		log.Fatalf("Invalid TAX_RATE_BASIS_POINTS: %q", os.Getenv("TAX_RATE_BASIS_POINTS"))
+	}
+
+	// How long CreateOrder idempotency keys are honoured
+	retention, err := envconfig.Duration("IDEMPOTENCY_RETENTION", ordergrpc.DefaultIdempotencyRetention)
+	if err != nil {
+		log.Fatal(err)
+	}
+	jobs := graceful.NewJobs()
+	jobs.Every(time.Hour, func(ctx context.Context) { purgeIdempotencyKeys(ctx, orderStore) })
+
+	// Calls made for customers get a deadline, retries and a circuit breaker
+	// per dependency. Redeeming points and reserving stock are not retried,
+	// as repeating them after a lost reply would apply them twice.
+	callTimeout, err := envconfig.Duration("DEPENDENCY_TIMEOUT", downstream.DefaultTimeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	users := downstream.New("user-service")
+	users.Timeout = callTimeout
+	users.NoRetry = map[string]bool{userv1.UserService_RedeemPoints_FullMethodName: true}
+	menu := downstream.New("menu-service")
+	menu.Timeout = callTimeout
+	menu.NoRetry = map[string]bool{menuv1.MenuService_ReserveStock_FullMethodName: true}
+
+	orderServer := ordergrpc.NewOrderServer(orderStore,
+		userv1.NewUserServiceClient(users.Conn(userConn)),
+		menuv1.NewMenuServiceClient(menu.Conn(menuConn)))
+	orderServer.TaxRateBasisPoints = taxRate
+	orderServer.IdempotencyRetention = retention
+
+	// Verify access tokens issued by the user service
+	keys, err := authz.LoadKeySet(os.Getenv("JWT_KEYS_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load JWT_KEYS_FILE: %v", err)
+	}
+	keys.ReloadOnSignal(syscall.SIGHUP)
+	authorizer := authz.NewAuthorizer(keys, healthcheck.AllowProbes(ordergrpc.Policy))
+
+	// Follow user and menu item deletions, authenticating as a service
+	serviceCreds := grpc.PerRPCCredentials(authz.NewServiceCredentials(keys, "order-service"))
+	jobs.Go(consistency.NewConsumer(orderStore, userClient, menuClient, serviceCreds).Run)
+	orderServer.ServiceCallOptions = []grpc.CallOption{serviceCreds}
+
+	// Relay order events written to the outbox, and keep loyalty points in
+	// step with them
+	publisher, err := newEventPublisher(envconfig.String("OUTBOX_PUBLISHER", "log"))
+	if err != nil {
+		log.Fatalf("Failed to create event publisher: %v", err)
+	}
+	jobs.Go(outbox.NewRelay(orderStore, outbox.MultiPublisher{publisher, loyalty.NewPublisher(userClient, serviceCreds)}).Run)
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor(), validation.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(authorizer.StreamServerInterceptor(), validation.StreamServerInterceptor),
+	)
+	orderv1.RegisterOrderServiceServer(s, orderServer)
+
+	// Report health from a periodic database ping and the health of the
+	// user and menu services
+	checker := healthcheck.New(orderv1.OrderService_ServiceDesc.ServiceName, map[string]healthcheck.Check{
+		"database":     orderStore.Ping,
+		"user-service": healthcheck.Remote(userConn, userv1.UserService_ServiceDesc.ServiceName),
+		"menu-service": healthcheck.Remote(menuConn, menuv1.MenuService_ServiceDesc.ServiceName),
+	})
+	checker.Register(s)
+	jobs.Go(checker.Run)
+
+	// Let tools such as grpcurl list the API
+	reflectionEnabled, err := envconfig.Bool("GRPC_REFLECTION", false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if reflectionEnabled {
+		reflection.Register(s)
+	}
+
+	// Serve until SIGINT or SIGTERM, then report NOT_SERVING, drain and
+	// wait for the calls in flight
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	shutdown, err := graceful.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	shutdown.OnShutdown = checker.Shutdown
 
 	log.Printf("Order service listening on port %s", grpcPort)
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	exitCode := 0
+	if err := graceful.Serve(ctx, s, lis, shutdown); err != nil {
+		log.Printf("Failed to serve: %v", err)
+		exitCode = 1
+	}
+	stop()
+
+	// Stop the background jobs before closing what they use
+	jobs.Stop()
+	for name, conn := range map[string]*grpc.ClientConn{"user": userConn, "menu": menuConn} {
+		if err := conn.Close(); err != nil {
+			log.Printf("Failed to close %s service connection: %v", name, err)
+			exitCode = 1
+		}
+	}
+	if err := orderStore.Close(); err != nil {
+		log.Printf("Failed to close store: %v", err)
+		exitCode = 1
+	}
+	log.Println("Order service stopped")
+	os.Exit(exitCode)
+}
+
+// purgeIdempotencyKeys removes expired idempotency keys.
+func purgeIdempotencyKeys(ctx context.Context, orderStore store.OrderStore) {
+	purged, err := orderStore.PurgeExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		log.Printf("Failed to purge expired idempotency keys: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired idempotency keys", purged)
 	}
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// newEventPublisher builds the outbox publisher selected by OUTBOX_PUBLISHER.
+func newEventPublisher(kind string) (outbox.Publisher, error) {
+	switch kind {
+	case "memory":
+		return outbox.NewMemoryPublisher(), nil
+	case "log":
+		return outbox.NewLogPublisher(envconfig.String("OUTBOX_LOG_PATH", "order-events.log"))
+	case "webhook":
+		url := os.Getenv("OUTBOX_WEBHOOK_URL")
+		if url == "" {
+			return nil, fmt.Errorf("OUTBOX_WEBHOOK_URL is required for the webhook publisher")
+		}
+		return outbox.NewWebhookPublisher(url), nil
+	default:
+		return nil, fmt.Errorf("unknown OUTBOX_PUBLISHER %q", kind)
 	}
-	return defaultValue
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// EventCursor is how far order-service has read another service's event
+// feed. It is saved in the transaction that applies each event.
+type EventCursor struct {
+	Source    string `gorm:"primaryKey;size:64"`
+	Sequence  uint64 `gorm:"not null;default:0"`
+	UpdatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// IdempotencyKey records which order a client retry key created so that a
+// replayed CreateOrder returns the original order instead of a duplicate.
+type IdempotencyKey struct {
+	UserID      uint   `gorm:"primaryKey;autoIncrement:false"`
+	Key         string `gorm:"primaryKey;size:255"`
+	RequestHash string `gorm:"size:64;not null"`
+	OrderID     uint   `gorm:"not null"`
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
@@ -1,19 +1,170 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderStatus is the lifecycle state of an order as stored in the database.
+type OrderStatus string
+
+const (
+	StatusPending   OrderStatus = "pending"
+	StatusConfirmed OrderStatus = "confirmed"
+	StatusPreparing OrderStatus = "preparing"
+	StatusReady     OrderStatus = "ready"
+	StatusCompleted OrderStatus = "completed"
+	StatusCancelled OrderStatus = "cancelled"
+)
+
+// orderTransitions lists the statuses each status may move to next.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	StatusPending:   {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusPreparing, StatusCancelled},
+	StatusPreparing: {StatusReady, StatusCancelled},
+	StatusReady:     {StatusCompleted, StatusCancelled},
+	StatusCompleted: {},
+	StatusCancelled: {},
+}
+
+// statusAliases maps free-form strings written before the status enum
+// existed onto their lifecycle state.
+var statusAliases = map[string]OrderStatus{
+	"":            StatusPending,
+	"new":         StatusPending,
+	"placed":      StatusPending,
+	"accepted":    StatusConfirmed,
+	"in progress": StatusPreparing,
+	"in_progress": StatusPreparing,
+	"cooking":     StatusPreparing,
+	"done":        StatusCompleted,
+	"complete":    StatusCompleted,
+	"delivered":   StatusCompleted,
+	"picked up":   StatusCompleted,
+	"canceled":    StatusCancelled,
+}
+
+// IsValid reports whether s is one of the known lifecycle states.
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are allowed from s.
+func (s OrderStatus) IsTerminal() bool {
+	return s.IsValid() && len(orderTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrderStatus maps a stored status string onto a lifecycle state.
+// The second result is false when the string is not recognised.
+func ParseOrderStatus(raw string) (OrderStatus, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	if status := OrderStatus(normalized); status.IsValid() {
+		return status, true
+	}
+	status, ok := statusAliases[normalized]
+	return status, ok
+}
+
+// CancellationReason explains why an order was cancelled.
+type CancellationReason string
+
+const (
+	ReasonCustomerRequest CancellationReason = "customer_request"
+	ReasonItemUnavailable CancellationReason = "item_unavailable"
+	ReasonCafeClosed      CancellationReason = "cafe_closed"
+	ReasonPaymentFailed   CancellationReason = "payment_failed"
+	ReasonDuplicateOrder  CancellationReason = "duplicate_order"
+	ReasonOther           CancellationReason = "other"
+)
+
+// DefaultCurrency is assumed for menu items that do not report a currency.
+const DefaultCurrency = "USD"
 
 type Order struct {
 	gorm.Model
 	UserID     uint        `gorm:"not null"`
-	Status     string      `gorm:"default:'pending'"`
+	Status     OrderStatus `gorm:"default:'pending'"`
 	OrderItems []OrderItem `gorm:"foreignKey:OrderID"`
+
+	// Money amounts are stored in the currency's minor unit.
+	Currency           string `gorm:"size:3;not null;default:'USD'"`
+	SubtotalCents      int64  `gorm:"not null;default:0"`
+	TaxRateBasisPoints int64  `gorm:"not null;default:0"`
+	TaxCents           int64  `gorm:"not null;default:0"`
+	TotalCents         int64  `gorm:"not null;default:0"`
+
+	// DiscountCents is bought with the PointsRedeemed loyalty points.
+	DiscountCents  int64 `gorm:"not null;default:0"`
+	PointsRedeemed int64 `gorm:"not null;default:0"`
+
+	// StockReservationID identifies the menu stock held for this order.
+	StockReservationID string
+
+	// Cancellation details, set when the order is cancelled.
+	CancellationReason CancellationReason
+	CancellationNote   string `gorm:"type:text"`
+	CancelledBy        *uint
+	CancelledAt        *time.Time
+
+	// CustomerDeleted is set when the user has been deleted in user-service.
+	// UserID is zeroed once the user has been purged.
+	CustomerDeleted bool `gorm:"not null;default:false"`
+
+	// RefundedAt is set when a completed order has been refunded.
+	RefundedAt *time.Time
 }
 
 type OrderItem struct {
 	gorm.Model
-	OrderID      uint
-	MenuItemID   uint
-	MenuItemName string
-	Quantity     uint
-	Price        float64
+	OrderID        uint
+	MenuItemID     uint
+	MenuItemName   string
+	Quantity       uint
+	UnitPriceCents int64 `gorm:"not null;default:0"`
+	LineTotalCents int64 `gorm:"not null;default:0"`
+	// MenuItemDeleted is set when the menu item has been deleted in
+	// menu-service.
+	MenuItemDeleted bool `gorm:"not null;default:false"`
+}
+
+// ApplyTotals fills in line totals, subtotal, tax and grand total from the
+// item snapshots. The discount is taken off the subtotal, and tax is charged
+// on the rest at rateBasisPoints (825 = 8.25%) and rounded half up to the
+// nearest minor unit.
+func (o *Order) ApplyTotals(rateBasisPoints int64) {
+	o.SubtotalCents = 0
+	for i := range o.OrderItems {
+		item := &o.OrderItems[i]
+		item.LineTotalCents = item.UnitPriceCents * int64(item.Quantity)
+		o.SubtotalCents += item.LineTotalCents
+	}
+
+	taxable := o.SubtotalCents - o.DiscountCents
+	o.TaxRateBasisPoints = rateBasisPoints
+	o.TaxCents = (taxable*rateBasisPoints + 5000) / 10000
+	o.TotalCents = taxable + o.TaxCents
+}
+
+// CentsFromFloat converts a decimal price such as 2.50 into minor units.
+func CentsFromFloat(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// FloatFromCents converts minor units back into a decimal price.
+func FloatFromCents(cents int64) float64 {
+	return float64(cents) / 100
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event written in the same transaction as the order
+// change it describes and published later by the outbox relay.
+type OutboxEvent struct {
+	ID uint `gorm:"primaryKey"`
+	// Sequence numbers events in the order their transactions committed,
+	// which their IDs do not guarantee.
+	Sequence      uint64 `gorm:"uniqueIndex"`
+	Type          string `gorm:"size:64;not null"`
+	OrderID       uint   `gorm:"index;not null"`
+	Payload       string `gorm:"type:text;not null"`
+	CreatedAt     time.Time
+	PublishedAt   *time.Time `gorm:"index"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time
+	LastError     string `gorm:"type:text"`
+}
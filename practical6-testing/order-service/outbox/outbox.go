@@ -0,0 +1,57 @@
+// Package outbox records order domain events alongside the database changes
+// that cause them and relays them to downstream consumers.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"order-service/models"
+	"order-service/store"
+)
+
+// Event types written by order-service.
+const (
+	OrderCreated       = "OrderCreated"
+	OrderStatusChanged = "OrderStatusChanged"
+	OrderCancelled     = "OrderCancelled"
+	OrderRefunded      = "OrderRefunded"
+)
+
+// Event is the envelope delivered to publishers. Sequence increases in the
+// order the events' transactions committed, so consumers can use it to order
+// and de-duplicate deliveries; an event may be delivered more than once.
+type Event struct {
+	Sequence   uint64          `json:"sequence"`
+	Type       string          `json:"type"`
+	OrderID    uint32          `json:"order_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Publisher delivers events to a downstream consumer. Returning an error
+// leaves the event in the outbox to be retried.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Enqueue writes an event to the outbox. tx should be the transaction that
+// makes the change the event describes, so that both commit or neither does.
+func Enqueue(ctx context.Context, tx store.OrderStore, eventType string, orderID uint, payload []byte) error {
+	return tx.CreateOutboxEvent(ctx, &models.OutboxEvent{
+		Type:    eventType,
+		OrderID: orderID,
+		Payload: string(payload),
+	})
+}
+
+func toEvent(record models.OutboxEvent) Event {
+	return Event{
+		Sequence:   record.Sequence,
+		Type:       record.Type,
+		OrderID:    uint32(record.OrderID),
+		OccurredAt: record.CreatedAt,
+		Payload:    json.RawMessage(record.Payload),
+	}
+}
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"order-service/store"
+)
+
+const (
+	defaultBatchSize  = 100
+	defaultInterval   = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Relay publishes outbox events in sequence order. An event is marked
+// published only after its publisher succeeds, so delivery is at least once.
+// A failed event is retried with exponential backoff and holds back the
+// events after it.
+type Relay struct {
+	Store     store.OrderStore
+	Publisher Publisher
+	// BatchSize is the most events published per poll.
+	BatchSize int
+	// Interval is how often the outbox is polled, and the first retry delay.
+	Interval time.Duration
+	// MaxBackoff caps the delay between retries of a failing event.
+	MaxBackoff time.Duration
+}
+
+func NewRelay(store store.OrderStore, publisher Publisher) *Relay {
+	return &Relay{
+		Store:      store,
+		Publisher:  publisher,
+		BatchSize:  defaultBatchSize,
+		Interval:   defaultInterval,
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx, time.Now()); err != nil {
+			log.Printf("Outbox relay: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publishes pending events that are due at now and returns how many
+// were published. It stops at the first event that is not due or fails.
+func (r *Relay) Flush(ctx context.Context, now time.Time) (int, error) {
+	pending, err := r.Store.PendingOutboxEvents(ctx, r.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	published := 0
+	for _, record := range pending {
+		if record.NextAttemptAt.After(now) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return published, err
+		}
+
+		if err := r.Publisher.Publish(ctx, toEvent(record)); err != nil {
+			attempts := record.Attempts + 1
+			if updateErr := r.Store.RecordOutboxFailure(ctx, record.ID, attempts, err.Error(), now.Add(r.backoff(attempts))); updateErr != nil {
+				return published, fmt.Errorf("failed to record failure of event %d: %w", record.ID, updateErr)
+			}
+			return published, fmt.Errorf("failed to publish event %d (attempt %d): %w", record.ID, attempts, err)
+		}
+
+		if err := r.Store.MarkOutboxEventPublished(ctx, record.ID, now); err != nil {
+			return published, fmt.Errorf("failed to mark event %d published: %w", record.ID, err)
+		}
+		published++
+	}
+	return published, nil
+}
+
+// backoff is the delay before retrying an event that has failed attempts
+// times: Interval, doubling with each failure up to MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.Interval
+	for i := 1; i < attempts && delay < r.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.MaxBackoff)
+}
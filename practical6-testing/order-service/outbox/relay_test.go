@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enqueue(t *testing.T, s store.OrderStore, eventType string, orderID uint) {
+	require.NoError(t, Enqueue(context.Background(), s, eventType, orderID, []byte(`{"order":{}}`)))
+}
+
+// flakyPublisher fails the first failures calls, then records events.
+type flakyPublisher struct {
+	MemoryPublisher
+	failures int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, event Event) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("consumer unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, event)
+}
+
+func TestRelay_PublishesInSequenceOrder(t *testing.T) {
+	s := store.NewMemoryStore()
+	enqueue(t, s, OrderCreated, 1)
+	enqueue(t, s, OrderStatusChanged, 1)
+	enqueue(t, s, OrderCancelled, 1)
+
+	publisher := NewMemoryPublisher()
+	relay := NewRelay(s, publisher)
+
+	published, err := relay.Flush(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	events := publisher.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, []string{OrderCreated, OrderStatusChanged, OrderCancelled},
+		[]string{events[0].Type, events[1].Type, events[2].Type})
+	assert.Less(t, events[0].Sequence, events[1].Sequence)
+	assert.Less(t, events[1].Sequence, events[2].Sequence)
+	assert.JSONEq(t, `{"order":{}}`, string(events[0].Payload))
+
+	// Published events are not delivered again
+	published, err = relay.Flush(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestRelay_RetriesWithBackoff(t *testing.T) {
+	s := store.NewMemoryStore()
+	enqueue(t, s, OrderCreated, 1)
+	enqueue(t, s, OrderCreated, 2)
+
+	publisher := &flakyPublisher{failures: 2}
+	relay := NewRelay(s, publisher)
+	now := time.Now()
+
+	// A failure holds back the events after it
+	published, err := relay.Flush(context.Background(), now)
+	require.Error(t, err)
+	assert.Zero(t, published)
+
+	pending, err := s.PendingOutboxEvents(context.Background(), 1)
+	require.NoError(t, err)
+	failed := pending[0]
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "consumer unavailable", failed.LastError)
+	assert.WithinDuration(t, now.Add(relay.Interval), failed.NextAttemptAt, time.Millisecond)
+
+	// Not retried before the backoff has passed
+	published, err = relay.Flush(context.Background(), now)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// The second failure doubles the delay
+	now = now.Add(relay.Interval)
+	_, err = relay.Flush(context.Background(), now)
+	require.Error(t, err)
+	pending, err = s.PendingOutboxEvents(context.Background(), 1)
+	require.NoError(t, err)
+	failed = pending[0]
+	assert.WithinDuration(t, now.Add(2*relay.Interval), failed.NextAttemptAt, time.Millisecond)
+
+	published, err = relay.Flush(context.Background(), now.Add(2*relay.Interval))
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	require.Len(t, publisher.Events(), 2)
+	assert.Equal(t, uint32(1), publisher.Events()[0].OrderID)
+}
+
+func TestRelay_BackoffIsCapped(t *testing.T) {
+	relay := NewRelay(store.NewMemoryStore(), NewMemoryPublisher())
+	relay.Interval = time.Second
+	relay.MaxBackoff = 10 * time.Second
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(100))
+}
+
+func TestLogPublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	publisher, err := NewLogPublisher(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, Event{Sequence: 1, Type: OrderCreated, Payload: []byte(`{}`)}))
+	require.NoError(t, publisher.Publish(ctx, Event{Sequence: 2, Type: OrderCancelled, Payload: []byte(`{}`)}))
+	require.NoError(t, publisher.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"type":"OrderCreated"`)
+	assert.Contains(t, lines[1], `"sequence":2`)
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	var gotType, gotSequence string
+	statusCode := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotType = r.Header.Get("X-Event-Type")
+		gotSequence = r.Header.Get("X-Event-Sequence")
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL)
+	event := Event{Sequence: 7, Type: OrderStatusChanged, Payload: []byte(`{}`)}
+
+	require.NoError(t, publisher.Publish(context.Background(), event))
+	assert.Equal(t, OrderStatusChanged, gotType)
+	assert.Equal(t, "7", gotSequence)
+
+	statusCode = http.StatusServiceUnavailable
+	assert.Error(t, publisher.Publish(context.Background(), event))
+}
@@ -7,8 +7,10 @@
 package orderv1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,20 +23,234 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	MenuItemId    uint32                 `protobuf:"varint,2,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
-	MenuItemName  string                 `protobuf:"bytes,3,opt,name=menu_item_name,json=menuItemName,proto3" json:"menu_item_name,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
+	OrderStatus_ORDER_STATUS_CONFIRMED   OrderStatus = 2
+	OrderStatus_ORDER_STATUS_PREPARING   OrderStatus = 3
+	OrderStatus_ORDER_STATUS_READY       OrderStatus = 4
+	OrderStatus_ORDER_STATUS_COMPLETED   OrderStatus = 5
+	OrderStatus_ORDER_STATUS_CANCELLED   OrderStatus = 6
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_CONFIRMED",
+		3: "ORDER_STATUS_PREPARING",
+		4: "ORDER_STATUS_READY",
+		5: "ORDER_STATUS_COMPLETED",
+		6: "ORDER_STATUS_CANCELLED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"ORDER_STATUS_PENDING":     1,
+		"ORDER_STATUS_CONFIRMED":   2,
+		"ORDER_STATUS_PREPARING":   3,
+		"ORDER_STATUS_READY":       4,
+		"ORDER_STATUS_COMPLETED":   5,
+		"ORDER_STATUS_CANCELLED":   6,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_proto_order_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{0}
+}
+
+type CancellationReason int32
+
+const (
+	CancellationReason_CANCELLATION_REASON_UNSPECIFIED      CancellationReason = 0
+	CancellationReason_CANCELLATION_REASON_CUSTOMER_REQUEST CancellationReason = 1
+	CancellationReason_CANCELLATION_REASON_ITEM_UNAVAILABLE CancellationReason = 2
+	CancellationReason_CANCELLATION_REASON_CAFE_CLOSED      CancellationReason = 3
+	CancellationReason_CANCELLATION_REASON_PAYMENT_FAILED   CancellationReason = 4
+	CancellationReason_CANCELLATION_REASON_DUPLICATE_ORDER  CancellationReason = 5
+	// Requires a note explaining the reason.
+	CancellationReason_CANCELLATION_REASON_OTHER CancellationReason = 6
+)
+
+// Enum value maps for CancellationReason.
+var (
+	CancellationReason_name = map[int32]string{
+		0: "CANCELLATION_REASON_UNSPECIFIED",
+		1: "CANCELLATION_REASON_CUSTOMER_REQUEST",
+		2: "CANCELLATION_REASON_ITEM_UNAVAILABLE",
+		3: "CANCELLATION_REASON_CAFE_CLOSED",
+		4: "CANCELLATION_REASON_PAYMENT_FAILED",
+		5: "CANCELLATION_REASON_DUPLICATE_ORDER",
+		6: "CANCELLATION_REASON_OTHER",
+	}
+	CancellationReason_value = map[string]int32{
+		"CANCELLATION_REASON_UNSPECIFIED":      0,
+		"CANCELLATION_REASON_CUSTOMER_REQUEST": 1,
+		"CANCELLATION_REASON_ITEM_UNAVAILABLE": 2,
+		"CANCELLATION_REASON_CAFE_CLOSED":      3,
+		"CANCELLATION_REASON_PAYMENT_FAILED":   4,
+		"CANCELLATION_REASON_DUPLICATE_ORDER":  5,
+		"CANCELLATION_REASON_OTHER":            6,
+	}
+)
+
+func (x CancellationReason) Enum() *CancellationReason {
+	p := new(CancellationReason)
+	*p = x
+	return p
+}
+
+func (x CancellationReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancellationReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_order_proto_enumTypes[1].Descriptor()
+}
+
+func (CancellationReason) Type() protoreflect.EnumType {
+	return &file_proto_order_proto_enumTypes[1]
+}
+
+func (x CancellationReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancellationReason.Descriptor instead.
+func (CancellationReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{1}
+}
+
+type Cancellation struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason CancellationReason     `protobuf:"varint,1,opt,name=reason,proto3,enum=order.v1.CancellationReason" json:"reason,omitempty"`
+	Note   string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	// User who cancelled the order.
+	CancelledBy uint32 `protobuf:"varint,3,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	// Deprecated: use cancel_time.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	CancelledAt   string                 `protobuf:"bytes,4,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CancelTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=cancel_time,json=cancelTime,proto3" json:"cancel_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Cancellation) Reset() {
+	*x = Cancellation{}
+	mi := &file_proto_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cancellation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cancellation) ProtoMessage() {}
+
+func (x *Cancellation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cancellation.ProtoReflect.Descriptor instead.
+func (*Cancellation) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cancellation) GetReason() CancellationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CancellationReason_CANCELLATION_REASON_UNSPECIFIED
+}
+
+func (x *Cancellation) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Cancellation) GetCancelledBy() uint32 {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/order.proto.
+func (x *Cancellation) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+func (x *Cancellation) GetCancelTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelTime
+	}
+	return nil
+}
+
+type OrderItem struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MenuItemId   uint32                 `protobuf:"varint,2,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	MenuItemName string                 `protobuf:"bytes,3,opt,name=menu_item_name,json=menuItemName,proto3" json:"menu_item_name,omitempty"`
+	Quantity     uint32                 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Deprecated: use unit_price_cents.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	Price float64 `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	// Menu price snapshot in minor units at the time the order was placed.
+	UnitPriceCents int64 `protobuf:"varint,6,opt,name=unit_price_cents,json=unitPriceCents,proto3" json:"unit_price_cents,omitempty"`
+	// unit_price_cents multiplied by quantity.
+	LineTotalCents int64 `protobuf:"varint,7,opt,name=line_total_cents,json=lineTotalCents,proto3" json:"line_total_cents,omitempty"`
+	// True when the menu item has since been deleted from the menu. The name
+	// and price snapshots stay valid.
+	MenuItemDeleted bool `protobuf:"varint,8,opt,name=menu_item_deleted,json=menuItemDeleted,proto3" json:"menu_item_deleted,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_proto_order_proto_msgTypes[0]
+	mi := &file_proto_order_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +262,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[0]
+	mi := &file_proto_order_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +275,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{0}
+	return file_proto_order_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *OrderItem) GetId() uint32 {
@@ -90,6 +306,7 @@ func (x *OrderItem) GetQuantity() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *OrderItem) GetPrice() float64 {
 	if x != nil {
 		return x.Price
@@ -97,21 +314,74 @@ func (x *OrderItem) GetPrice() float64 {
 	return 0
 }
 
+func (x *OrderItem) GetUnitPriceCents() int64 {
+	if x != nil {
+		return x.UnitPriceCents
+	}
+	return 0
+}
+
+func (x *OrderItem) GetLineTotalCents() int64 {
+	if x != nil {
+		return x.LineTotalCents
+	}
+	return 0
+}
+
+func (x *OrderItem) GetMenuItemDeleted() bool {
+	if x != nil {
+		return x.MenuItemDeleted
+	}
+	return false
+}
+
 type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	OrderItems    []*OrderItem           `protobuf:"bytes,4,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: use state. Holds the lowercase status name, e.g. "pending".
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	Status     string       `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OrderItems []*OrderItem `protobuf:"bytes,4,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
+	// Deprecated: use create_time.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Deprecated: use update_time.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	UpdatedAt string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Money amounts in the currency's minor unit.
+	SubtotalCents int64 `protobuf:"varint,7,opt,name=subtotal_cents,json=subtotalCents,proto3" json:"subtotal_cents,omitempty"`
+	TaxCents      int64 `protobuf:"varint,8,opt,name=tax_cents,json=taxCents,proto3" json:"tax_cents,omitempty"`
+	TotalCents    int64 `protobuf:"varint,9,opt,name=total_cents,json=totalCents,proto3" json:"total_cents,omitempty"`
+	// ISO 4217 currency code shared by every amount on the order.
+	Currency string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Set once the order has been cancelled with CancelOrder.
+	Cancellation *Cancellation `protobuf:"bytes,11,opt,name=cancellation,proto3" json:"cancellation,omitempty"`
+	// True when the customer's account has been deleted. user_id is 0 once
+	// the account has been purged.
+	CustomerDeleted bool `protobuf:"varint,12,opt,name=customer_deleted,json=customerDeleted,proto3" json:"customer_deleted,omitempty"`
+	// Discount bought with loyalty points, taken off the subtotal before tax.
+	DiscountCents  int64 `protobuf:"varint,13,opt,name=discount_cents,json=discountCents,proto3" json:"discount_cents,omitempty"`
+	PointsRedeemed int64 `protobuf:"varint,14,opt,name=points_redeemed,json=pointsRedeemed,proto3" json:"points_redeemed,omitempty"`
+	// Deprecated: use refund_time.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	RefundedAt string                 `protobuf:"bytes,15,opt,name=refunded_at,json=refundedAt,proto3" json:"refunded_at,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Unset unless the order has been refunded.
+	RefundTime    *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=refund_time,json=refundTime,proto3" json:"refund_time,omitempty"`
+	State         OrderStatus            `protobuf:"varint,19,opt,name=state,proto3,enum=order.v1.OrderStatus" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_proto_order_proto_msgTypes[1]
+	mi := &file_proto_order_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -123,7 +393,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[1]
+	mi := &file_proto_order_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -136,7 +406,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{1}
+	return file_proto_order_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Order) GetId() uint32 {
@@ -153,6 +423,7 @@ func (x *Order) GetUserId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *Order) GetStatus() string {
 	if x != nil {
 		return x.Status
@@ -167,6 +438,7 @@ func (x *Order) GetOrderItems() []*OrderItem {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *Order) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
@@ -174,6 +446,7 @@ func (x *Order) GetCreatedAt() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *Order) GetUpdatedAt() string {
 	if x != nil {
 		return x.UpdatedAt
@@ -181,6 +454,98 @@ func (x *Order) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Order) GetSubtotalCents() int64 {
+	if x != nil {
+		return x.SubtotalCents
+	}
+	return 0
+}
+
+func (x *Order) GetTaxCents() int64 {
+	if x != nil {
+		return x.TaxCents
+	}
+	return 0
+}
+
+func (x *Order) GetTotalCents() int64 {
+	if x != nil {
+		return x.TotalCents
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetCancellation() *Cancellation {
+	if x != nil {
+		return x.Cancellation
+	}
+	return nil
+}
+
+func (x *Order) GetCustomerDeleted() bool {
+	if x != nil {
+		return x.CustomerDeleted
+	}
+	return false
+}
+
+func (x *Order) GetDiscountCents() int64 {
+	if x != nil {
+		return x.DiscountCents
+	}
+	return 0
+}
+
+func (x *Order) GetPointsRedeemed() int64 {
+	if x != nil {
+		return x.PointsRedeemed
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/order.proto.
+func (x *Order) GetRefundedAt() string {
+	if x != nil {
+		return x.RefundedAt
+	}
+	return ""
+}
+
+func (x *Order) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Order) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Order) GetRefundTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefundTime
+	}
+	return nil
+}
+
+func (x *Order) GetState() OrderStatus {
+	if x != nil {
+		return x.State
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
 type OrderItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MenuItemId    uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
@@ -191,7 +556,7 @@ type OrderItemRequest struct {
 
 func (x *OrderItemRequest) Reset() {
 	*x = OrderItemRequest{}
-	mi := &file_proto_order_proto_msgTypes[2]
+	mi := &file_proto_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +568,7 @@ func (x *OrderItemRequest) String() string {
 func (*OrderItemRequest) ProtoMessage() {}
 
 func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[2]
+	mi := &file_proto_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +581,7 @@ func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
 func (*OrderItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{2}
+	return file_proto_order_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OrderItemRequest) GetMenuItemId() uint32 {
@@ -234,16 +599,24 @@ func (x *OrderItemRequest) GetQuantity() uint32 {
 }
 
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItemRequest    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*OrderItemRequest    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Client-chosen key that makes retries safe. Repeating a request with the
+	// same key and payload returns the original order; reusing the key with a
+	// different payload fails with ALREADY_EXISTS. May also be sent as the
+	// "idempotency-key" metadata header.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Loyalty points to spend on a discount. Their value may not exceed the
+	// subtotal.
+	RedeemPoints  int64 `protobuf:"varint,4,opt,name=redeem_points,json=redeemPoints,proto3" json:"redeem_points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_proto_order_proto_msgTypes[3]
+	mi := &file_proto_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +628,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[3]
+	mi := &file_proto_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +641,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{3}
+	return file_proto_order_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateOrderRequest) GetUserId() uint32 {
@@ -285,6 +658,20 @@ func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetRedeemPoints() int64 {
+	if x != nil {
+		return x.RedeemPoints
+	}
+	return 0
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -294,7 +681,7 @@ type CreateOrderResponse struct {
 
 func (x *CreateOrderResponse) Reset() {
 	*x = CreateOrderResponse{}
-	mi := &file_proto_order_proto_msgTypes[4]
+	mi := &file_proto_order_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +693,7 @@ func (x *CreateOrderResponse) String() string {
 func (*CreateOrderResponse) ProtoMessage() {}
 
 func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[4]
+	mi := &file_proto_order_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +706,7 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{4}
+	return file_proto_order_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateOrderResponse) GetOrder() *Order {
@@ -338,7 +725,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_proto_order_proto_msgTypes[5]
+	mi := &file_proto_order_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -350,7 +737,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[5]
+	mi := &file_proto_order_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,7 +750,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{5}
+	return file_proto_order_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetOrderRequest) GetId() uint32 {
@@ -382,7 +769,7 @@ type GetOrderResponse struct {
 
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_proto_order_proto_msgTypes[6]
+	mi := &file_proto_order_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -394,7 +781,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[6]
+	mi := &file_proto_order_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,7 +794,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{6}
+	return file_proto_order_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetOrderResponse) GetOrder() *Order {
@@ -418,14 +805,23 @@ func (x *GetOrderResponse) GetOrder() *Order {
 }
 
 type GetOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of orders to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	UserId    uint32      `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status    OrderStatus `protobuf:"varint,4,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`
+	// Inclusive lower and exclusive upper bound on creation time.
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetOrdersRequest) Reset() {
 	*x = GetOrdersRequest{}
-	mi := &file_proto_order_proto_msgTypes[7]
+	mi := &file_proto_order_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +833,7 @@ func (x *GetOrdersRequest) String() string {
 func (*GetOrdersRequest) ProtoMessage() {}
 
 func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[7]
+	mi := &file_proto_order_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,31 +846,75 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{7}
-}
-
-type GetOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return file_proto_order_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetOrdersResponse) Reset() {
-	*x = GetOrdersResponse{}
-	mi := &file_proto_order_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
 
-func (x *GetOrdersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
-func (*GetOrdersResponse) ProtoMessage() {}
+func (x *GetOrdersRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *GetOrdersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *GetOrdersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+type GetOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Empty when there are no more orders.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersResponse) Reset() {
+	*x = GetOrdersResponse{}
+	mi := &file_proto_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersResponse) ProtoMessage() {}
 
 func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[8]
+	mi := &file_proto_order_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +927,7 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{8}
+	return file_proto_order_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetOrdersResponse) GetOrders() []*Order {
@@ -497,17 +937,29 @@ func (x *GetOrdersResponse) GetOrders() []*Order {
 	return nil
 }
 
+func (x *GetOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateOrderStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: use state. Read only when state is unset.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Required unless the deprecated status is set.
+	State         OrderStatus `protobuf:"varint,3,opt,name=state,proto3,enum=order.v1.OrderStatus" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_proto_order_proto_msgTypes[9]
+	mi := &file_proto_order_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -519,7 +971,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[9]
+	mi := &file_proto_order_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -532,7 +984,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{9}
+	return file_proto_order_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateOrderStatusRequest) GetId() uint32 {
@@ -542,6 +994,7 @@ func (x *UpdateOrderStatusRequest) GetId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *UpdateOrderStatusRequest) GetStatus() string {
 	if x != nil {
 		return x.Status
@@ -549,6 +1002,13 @@ func (x *UpdateOrderStatusRequest) GetStatus() string {
 	return ""
 }
 
+func (x *UpdateOrderStatusRequest) GetState() OrderStatus {
+	if x != nil {
+		return x.State
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
 type UpdateOrderStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -558,7 +1018,7 @@ type UpdateOrderStatusResponse struct {
 
 func (x *UpdateOrderStatusResponse) Reset() {
 	*x = UpdateOrderStatusResponse{}
-	mi := &file_proto_order_proto_msgTypes[10]
+	mi := &file_proto_order_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -570,7 +1030,7 @@ func (x *UpdateOrderStatusResponse) String() string {
 func (*UpdateOrderStatusResponse) ProtoMessage() {}
 
 func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[10]
+	mi := &file_proto_order_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -583,7 +1043,7 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{10}
+	return file_proto_order_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateOrderStatusResponse) GetOrder() *Order {
@@ -593,54 +1053,529 @@ func (x *UpdateOrderStatusResponse) GetOrder() *Order {
 	return nil
 }
 
+type CancelOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: ignored. The cancelling user is taken from the caller's
+	// token.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	UserId uint32             `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason CancellationReason `protobuf:"varint,3,opt,name=reason,proto3,enum=order.v1.CancellationReason" json:"reason,omitempty"`
+	// Free-text detail, at most 500 characters.
+	Note          string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/order.proto.
+func (x *CancelOrderRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetReason() CancellationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CancellationReason_CANCELLATION_REASON_UNSPECIFIED
+}
+
+func (x *CancelOrderRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefundOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_proto_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RefundOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type WatchOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderResponse) Reset() {
+	*x = WatchOrderResponse{}
+	mi := &file_proto_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderResponse) ProtoMessage() {}
+
+func (x *WatchOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type WatchOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only send orders placed by this user when set.
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Only send orders currently in one of these statuses when set.
+	Statuses      []OrderStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=order.v1.OrderStatus" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_proto_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersRequest) ProtoMessage() {}
+
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchOrdersRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WatchOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type WatchOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersResponse) Reset() {
+	*x = WatchOrdersResponse{}
+	mi := &file_proto_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersResponse) ProtoMessage() {}
+
+func (x *WatchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchOrdersResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
 var File_proto_order_proto protoreflect.FileDescriptor
 
 const file_proto_order_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/order.proto\x12\border.v1\"\x95\x01\n" +
+	"\x11proto/order.proto\x12\border.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdf\x01\n" +
+	"\fCancellation\x124\n" +
+	"\x06reason\x18\x01 \x01(\x0e2\x1c.order.v1.CancellationReasonR\x06reason\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12!\n" +
+	"\fcancelled_by\x18\x03 \x01(\rR\vcancelledBy\x12%\n" +
+	"\fcancelled_at\x18\x04 \x01(\tB\x02\x18\x01R\vcancelledAt\x12;\n" +
+	"\vcancel_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"cancelTime\"\x99\x02\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12 \n" +
 	"\fmenu_item_id\x18\x02 \x01(\rR\n" +
 	"menuItemId\x12$\n" +
 	"\x0emenu_item_name\x18\x03 \x01(\tR\fmenuItemName\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\rR\bquantity\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\"\xbc\x01\n" +
+	"\bquantity\x18\x04 \x01(\rR\bquantity\x12\x18\n" +
+	"\x05price\x18\x05 \x01(\x01B\x02\x18\x01R\x05price\x12(\n" +
+	"\x10unit_price_cents\x18\x06 \x01(\x03R\x0eunitPriceCents\x12(\n" +
+	"\x10line_total_cents\x18\a \x01(\x03R\x0elineTotalCents\x12*\n" +
+	"\x11menu_item_deleted\x18\b \x01(\bR\x0fmenuItemDeleted\"\x89\x06\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x124\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x1a\n" +
+	"\x06status\x18\x03 \x01(\tB\x02\x18\x01R\x06status\x124\n" +
 	"\vorder_items\x18\x04 \x03(\v2\x13.order.v1.OrderItemR\n" +
-	"orderItems\x12\x1d\n" +
+	"orderItems\x12!\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"created_at\x18\x05 \x01(\tB\x02\x18\x01R\tcreatedAt\x12!\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"P\n" +
-	"\x10OrderItemRequest\x12 \n" +
-	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
-	"menuItemId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\rR\bquantity\"_\n" +
-	"\x12CreateOrderRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\rR\x06userId\x120\n" +
-	"\x05items\x18\x02 \x03(\v2\x1a.order.v1.OrderItemRequestR\x05items\"<\n" +
+	"updated_at\x18\x06 \x01(\tB\x02\x18\x01R\tupdatedAt\x12%\n" +
+	"\x0esubtotal_cents\x18\a \x01(\x03R\rsubtotalCents\x12\x1b\n" +
+	"\ttax_cents\x18\b \x01(\x03R\btaxCents\x12\x1f\n" +
+	"\vtotal_cents\x18\t \x01(\x03R\n" +
+	"totalCents\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12:\n" +
+	"\fcancellation\x18\v \x01(\v2\x16.order.v1.CancellationR\fcancellation\x12)\n" +
+	"\x10customer_deleted\x18\f \x01(\bR\x0fcustomerDeleted\x12%\n" +
+	"\x0ediscount_cents\x18\r \x01(\x03R\rdiscountCents\x12'\n" +
+	"\x0fpoints_redeemed\x18\x0e \x01(\x03R\x0epointsRedeemed\x12#\n" +
+	"\vrefunded_at\x18\x0f \x01(\tB\x02\x18\x01R\n" +
+	"refundedAt\x12;\n" +
+	"\vcreate_time\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12;\n" +
+	"\vrefund_time\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"refundTime\x12+\n" +
+	"\x05state\x18\x13 \x01(\x0e2\x15.order.v1.OrderStatusR\x05state\"b\n" +
+	"\x10OrderItemRequest\x12)\n" +
+	"\fmenu_item_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\n" +
+	"menuItemId\x12#\n" +
+	"\bquantity\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\bquantity\"\xd3\x01\n" +
+	"\x12CreateOrderRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06userId\x12:\n" +
+	"\x05items\x18\x02 \x03(\v2\x1a.order.v1.OrderItemRequestB\b\xbaH\x05\x92\x01\x02\b\x01R\x05items\x121\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x0eidempotencyKey\x12,\n" +
+	"\rredeem_points\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\fredeemPoints\"<\n" +
 	"\x13CreateOrderResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"!\n" +
-	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"9\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"*\n" +
+	"\x0fGetOrderRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"9\n" +
 	"\x10GetOrderResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\x12\n" +
-	"\x10GetOrdersRequest\"<\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xad\x02\n" +
+	"\x10GetOrdersRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\rR\x06userId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x15.order.v1.OrderStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06status\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\"d\n" +
 	"\x11GetOrdersResponse\x12'\n" +
-	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\"B\n" +
-	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"B\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x86\x01\n" +
+	"\x18UpdateOrderStatusRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\x12\x1a\n" +
+	"\x06status\x18\x02 \x01(\tB\x02\x18\x01R\x06status\x125\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x15.order.v1.OrderStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05state\"B\n" +
 	"\x19UpdateOrderStatusResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order2\xc1\x02\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xaa\x01\n" +
+	"\x12CancelOrderRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\x12\x1b\n" +
+	"\auser_id\x18\x02 \x01(\rB\x02\x18\x01R\x06userId\x12@\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1c.order.v1.CancellationReasonB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x06reason\x12\x1c\n" +
+	"\x04note\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\x04note\"<\n" +
+	"\x13CancelOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"-\n" +
+	"\x12RefundOrderRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"<\n" +
+	"\x13RefundOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\",\n" +
+	"\x11WatchOrderRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\";\n" +
+	"\x12WatchOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"o\n" +
+	"\x12WatchOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12@\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x15.order.v1.OrderStatusB\r\xbaH\n" +
+	"\x92\x01\a\"\x05\x82\x01\x02\x10\x01R\bstatuses\"<\n" +
+	"\x13WatchOrdersResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order*\xcd\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16ORDER_STATUS_CONFIRMED\x10\x02\x12\x1a\n" +
+	"\x16ORDER_STATUS_PREPARING\x10\x03\x12\x16\n" +
+	"\x12ORDER_STATUS_READY\x10\x04\x12\x1a\n" +
+	"\x16ORDER_STATUS_COMPLETED\x10\x05\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x06*\xa2\x02\n" +
+	"\x12CancellationReason\x12#\n" +
+	"\x1fCANCELLATION_REASON_UNSPECIFIED\x10\x00\x12(\n" +
+	"$CANCELLATION_REASON_CUSTOMER_REQUEST\x10\x01\x12(\n" +
+	"$CANCELLATION_REASON_ITEM_UNAVAILABLE\x10\x02\x12#\n" +
+	"\x1fCANCELLATION_REASON_CAFE_CLOSED\x10\x03\x12&\n" +
+	"\"CANCELLATION_REASON_PAYMENT_FAILED\x10\x04\x12'\n" +
+	"#CANCELLATION_REASON_DUPLICATE_ORDER\x10\x05\x12\x1d\n" +
+	"\x19CANCELLATION_REASON_OTHER\x10\x062\xf2\x04\n" +
 	"\fOrderService\x12J\n" +
 	"\vCreateOrder\x12\x1c.order.v1.CreateOrderRequest\x1a\x1d.order.v1.CreateOrderResponse\x12A\n" +
 	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x1a.order.v1.GetOrderResponse\x12D\n" +
 	"\tGetOrders\x12\x1a.order.v1.GetOrdersRequest\x1a\x1b.order.v1.GetOrdersResponse\x12\\\n" +
-	"\x11UpdateOrderStatus\x12\".order.v1.UpdateOrderStatusRequest\x1a#.order.v1.UpdateOrderStatusResponseB\x1dZ\x1border-service/proto/orderv1b\x06proto3"
+	"\x11UpdateOrderStatus\x12\".order.v1.UpdateOrderStatusRequest\x1a#.order.v1.UpdateOrderStatusResponse\x12J\n" +
+	"\vCancelOrder\x12\x1c.order.v1.CancelOrderRequest\x1a\x1d.order.v1.CancelOrderResponse\x12J\n" +
+	"\vRefundOrder\x12\x1c.order.v1.RefundOrderRequest\x1a\x1d.order.v1.RefundOrderResponse\x12I\n" +
+	"\n" +
+	"WatchOrder\x12\x1b.order.v1.WatchOrderRequest\x1a\x1c.order.v1.WatchOrderResponse0\x01\x12L\n" +
+	"\vWatchOrders\x12\x1c.order.v1.WatchOrdersRequest\x1a\x1d.order.v1.WatchOrdersResponse0\x01B\x1dZ\x1border-service/proto/orderv1b\x06proto3"
 
 var (
 	file_proto_order_proto_rawDescOnce sync.Once
@@ -654,40 +1589,78 @@ func file_proto_order_proto_rawDescGZIP() []byte {
 	return file_proto_order_proto_rawDescData
 }
 
-var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_order_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_order_proto_goTypes = []any{
-	(*OrderItem)(nil),                 // 0: order.v1.OrderItem
-	(*Order)(nil),                     // 1: order.v1.Order
-	(*OrderItemRequest)(nil),          // 2: order.v1.OrderItemRequest
-	(*CreateOrderRequest)(nil),        // 3: order.v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil),       // 4: order.v1.CreateOrderResponse
-	(*GetOrderRequest)(nil),           // 5: order.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),          // 6: order.v1.GetOrderResponse
-	(*GetOrdersRequest)(nil),          // 7: order.v1.GetOrdersRequest
-	(*GetOrdersResponse)(nil),         // 8: order.v1.GetOrdersResponse
-	(*UpdateOrderStatusRequest)(nil),  // 9: order.v1.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 10: order.v1.UpdateOrderStatusResponse
+	(OrderStatus)(0),                  // 0: order.v1.OrderStatus
+	(CancellationReason)(0),           // 1: order.v1.CancellationReason
+	(*Cancellation)(nil),              // 2: order.v1.Cancellation
+	(*OrderItem)(nil),                 // 3: order.v1.OrderItem
+	(*Order)(nil),                     // 4: order.v1.Order
+	(*OrderItemRequest)(nil),          // 5: order.v1.OrderItemRequest
+	(*CreateOrderRequest)(nil),        // 6: order.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),       // 7: order.v1.CreateOrderResponse
+	(*GetOrderRequest)(nil),           // 8: order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),          // 9: order.v1.GetOrderResponse
+	(*GetOrdersRequest)(nil),          // 10: order.v1.GetOrdersRequest
+	(*GetOrdersResponse)(nil),         // 11: order.v1.GetOrdersResponse
+	(*UpdateOrderStatusRequest)(nil),  // 12: order.v1.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil), // 13: order.v1.UpdateOrderStatusResponse
+	(*CancelOrderRequest)(nil),        // 14: order.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),       // 15: order.v1.CancelOrderResponse
+	(*RefundOrderRequest)(nil),        // 16: order.v1.RefundOrderRequest
+	(*RefundOrderResponse)(nil),       // 17: order.v1.RefundOrderResponse
+	(*WatchOrderRequest)(nil),         // 18: order.v1.WatchOrderRequest
+	(*WatchOrderResponse)(nil),        // 19: order.v1.WatchOrderResponse
+	(*WatchOrdersRequest)(nil),        // 20: order.v1.WatchOrdersRequest
+	(*WatchOrdersResponse)(nil),       // 21: order.v1.WatchOrdersResponse
+	(*timestamppb.Timestamp)(nil),     // 22: google.protobuf.Timestamp
 }
 var file_proto_order_proto_depIdxs = []int32{
-	0,  // 0: order.v1.Order.order_items:type_name -> order.v1.OrderItem
-	2,  // 1: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItemRequest
-	1,  // 2: order.v1.CreateOrderResponse.order:type_name -> order.v1.Order
-	1,  // 3: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
-	1,  // 4: order.v1.GetOrdersResponse.orders:type_name -> order.v1.Order
-	1,  // 5: order.v1.UpdateOrderStatusResponse.order:type_name -> order.v1.Order
-	3,  // 6: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
-	5,  // 7: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
-	7,  // 8: order.v1.OrderService.GetOrders:input_type -> order.v1.GetOrdersRequest
-	9,  // 9: order.v1.OrderService.UpdateOrderStatus:input_type -> order.v1.UpdateOrderStatusRequest
-	4,  // 10: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
-	6,  // 11: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
-	8,  // 12: order.v1.OrderService.GetOrders:output_type -> order.v1.GetOrdersResponse
-	10, // 13: order.v1.OrderService.UpdateOrderStatus:output_type -> order.v1.UpdateOrderStatusResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	1,  // 0: order.v1.Cancellation.reason:type_name -> order.v1.CancellationReason
+	22, // 1: order.v1.Cancellation.cancel_time:type_name -> google.protobuf.Timestamp
+	3,  // 2: order.v1.Order.order_items:type_name -> order.v1.OrderItem
+	2,  // 3: order.v1.Order.cancellation:type_name -> order.v1.Cancellation
+	22, // 4: order.v1.Order.create_time:type_name -> google.protobuf.Timestamp
+	22, // 5: order.v1.Order.update_time:type_name -> google.protobuf.Timestamp
+	22, // 6: order.v1.Order.refund_time:type_name -> google.protobuf.Timestamp
+	0,  // 7: order.v1.Order.state:type_name -> order.v1.OrderStatus
+	5,  // 8: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItemRequest
+	4,  // 9: order.v1.CreateOrderResponse.order:type_name -> order.v1.Order
+	4,  // 10: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
+	0,  // 11: order.v1.GetOrdersRequest.status:type_name -> order.v1.OrderStatus
+	22, // 12: order.v1.GetOrdersRequest.created_after:type_name -> google.protobuf.Timestamp
+	22, // 13: order.v1.GetOrdersRequest.created_before:type_name -> google.protobuf.Timestamp
+	4,  // 14: order.v1.GetOrdersResponse.orders:type_name -> order.v1.Order
+	0,  // 15: order.v1.UpdateOrderStatusRequest.state:type_name -> order.v1.OrderStatus
+	4,  // 16: order.v1.UpdateOrderStatusResponse.order:type_name -> order.v1.Order
+	1,  // 17: order.v1.CancelOrderRequest.reason:type_name -> order.v1.CancellationReason
+	4,  // 18: order.v1.CancelOrderResponse.order:type_name -> order.v1.Order
+	4,  // 19: order.v1.RefundOrderResponse.order:type_name -> order.v1.Order
+	4,  // 20: order.v1.WatchOrderResponse.order:type_name -> order.v1.Order
+	0,  // 21: order.v1.WatchOrdersRequest.statuses:type_name -> order.v1.OrderStatus
+	4,  // 22: order.v1.WatchOrdersResponse.order:type_name -> order.v1.Order
+	6,  // 23: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
+	8,  // 24: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
+	10, // 25: order.v1.OrderService.GetOrders:input_type -> order.v1.GetOrdersRequest
+	12, // 26: order.v1.OrderService.UpdateOrderStatus:input_type -> order.v1.UpdateOrderStatusRequest
+	14, // 27: order.v1.OrderService.CancelOrder:input_type -> order.v1.CancelOrderRequest
+	16, // 28: order.v1.OrderService.RefundOrder:input_type -> order.v1.RefundOrderRequest
+	18, // 29: order.v1.OrderService.WatchOrder:input_type -> order.v1.WatchOrderRequest
+	20, // 30: order.v1.OrderService.WatchOrders:input_type -> order.v1.WatchOrdersRequest
+	7,  // 31: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
+	9,  // 32: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
+	11, // 33: order.v1.OrderService.GetOrders:output_type -> order.v1.GetOrdersResponse
+	13, // 34: order.v1.OrderService.UpdateOrderStatus:output_type -> order.v1.UpdateOrderStatusResponse
+	15, // 35: order.v1.OrderService.CancelOrder:output_type -> order.v1.CancelOrderResponse
+	17, // 36: order.v1.OrderService.RefundOrder:output_type -> order.v1.RefundOrderResponse
+	19, // 37: order.v1.OrderService.WatchOrder:output_type -> order.v1.WatchOrderResponse
+	21, // 38: order.v1.OrderService.WatchOrders:output_type -> order.v1.WatchOrdersResponse
+	31, // [31:39] is the sub-list for method output_type
+	23, // [23:31] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_proto_init() }
@@ -700,13 +1673,14 @@ func file_proto_order_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_proto_rawDesc), len(file_proto_order_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_order_proto_goTypes,
 		DependencyIndexes: file_proto_order_proto_depIdxs,
+		EnumInfos:         file_proto_order_proto_enumTypes,
 		MessageInfos:      file_proto_order_proto_msgTypes,
 	}.Build()
 	File_proto_order_proto = out.File
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"eventseq"
+	"log"
+	"order-service/models"
+	"pagination"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxFeed numbers the outbox events.
+const outboxFeed = "order_events"
+
+// GormStore keeps orders in a SQL database through GORM. It works on both
+// Postgres and SQLite.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore wraps db, which must already be migrated.
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// Migrate creates or updates the tables used by GormStore.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.IdempotencyKey{}, &models.OutboxEvent{},
+		&models.EventCursor{}); err != nil {
+		return err
+	}
+	if err := eventseq.Migrate(db, outboxFeed, &models.OutboxEvent{}); err != nil {
+		return err
+	}
+	if err := migrateFloatPrices(db); err != nil {
+		return err
+	}
+	return NormalizeOrderStatuses(db)
+}
+
+// migrateFloatPrices moves order item prices from the legacy floating point
+// price column into integer cents, drops the old column and fills in the
+// totals of orders placed before totals were stored. Those orders were never
+// taxed, so their total is their subtotal.
+func migrateFloatPrices(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.OrderItem{}, "price") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE order_items
+			SET unit_price_cents = ROUND(price * 100),
+				line_total_cents = ROUND(price * 100) * quantity
+			WHERE price IS NOT NULL`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`UPDATE orders
+			SET subtotal_cents = (
+				SELECT COALESCE(SUM(line_total_cents), 0) FROM order_items
+				WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL
+			)
+			WHERE total_cents = 0`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE orders SET total_cents = subtotal_cents WHERE total_cents = 0").Error; err != nil {
+			return err
+		}
+		log.Println("Migrated order prices to integer cents")
+
+		return tx.Migrator().DropColumn(&models.OrderItem{}, "price")
+	})
+}
+
+// NormalizeOrderStatuses rewrites order statuses stored as free-form strings
+// onto the lifecycle states understood by the status state machine.
+// Unrecognised values are reset to pending so staff can move them on.
+func NormalizeOrderStatuses(db *gorm.DB) error {
+	if err := db.Unscoped().Model(&models.Order{}).Where("status IS NULL").Update("status", models.StatusPending).Error; err != nil {
+		return err
+	}
+
+	var stored []string
+	if err := db.Unscoped().Model(&models.Order{}).Distinct().Pluck("status", &stored).Error; err != nil {
+		return err
+	}
+
+	for _, raw := range stored {
+		status, ok := models.ParseOrderStatus(raw)
+		if ok && string(status) == raw {
+			continue
+		}
+		if !ok {
+			log.Printf("Unknown order status %q, resetting to %q", raw, models.StatusPending)
+			status = models.StatusPending
+		}
+
+		result := db.Unscoped().Model(&models.Order{}).Where("status = ?", raw).Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		log.Printf("Mapped %d orders from status %q to %q", result.RowsAffected, raw, status)
+	}
+	return nil
+}
+
+func (s *GormStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *GormStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (s *GormStore) Transaction(ctx context.Context, fn func(tx OrderStore) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormStore{db: tx})
+	})
+}
+
+func (s *GormStore) CreateOrder(ctx context.Context, order *models.Order) error {
+	return s.db.WithContext(ctx).Create(order).Error
+}
+
+func (s *GormStore) GetOrder(ctx context.Context, id uint) (models.Order, error) {
+	var order models.Order
+	err := s.db.WithContext(ctx).Preload("OrderItems").First(&order, id).Error
+	return order, err
+}
+
+func (s *GormStore) ListOrders(ctx context.Context, filter OrderFilter, page Page) ([]models.Order, error) {
+	query := s.db.WithContext(ctx).Model(&models.Order{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	var orders []models.Order
+	err := pagination.Apply(query, page).Preload("OrderItems").Find(&orders).Error
+	return orders, err
+}
+
+func (s *GormStore) TransitionOrder(ctx context.Context, order *models.Order, from models.OrderStatus) (bool, error) {
+	result := s.db.WithContext(ctx).Model(order).Where("status = ?", from).
+		Select("status", "cancellation_reason", "cancellation_note", "cancelled_by", "cancelled_at", "updated_at").
+		Updates(order)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) MarkOrderRefunded(ctx context.Context, order *models.Order, at time.Time) (bool, error) {
+	result := s.db.WithContext(ctx).Model(order).Where("refunded_at IS NULL").Update("refunded_at", &at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) SaveOrderTotals(ctx context.Context, order *models.Order) error {
+	return s.db.WithContext(ctx).Model(order).
+		Select("discount_cents", "points_redeemed", "tax_cents", "total_cents").Updates(order).Error
+}
+
+func (s *GormStore) FindIdempotencyKey(ctx context.Context, userID uint, key string, now time.Time) (models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := s.db.WithContext(ctx).Where("user_id = ? AND key = ? AND expires_at > ?", userID, key, now).First(&record).Error
+	return record, err
+}
+
+func (s *GormStore) CreateIdempotencyKey(ctx context.Context, record *models.IdempotencyKey, now time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Reusing a key whose retention window has passed starts afresh
+		if err := tx.Where("user_id = ? AND key = ? AND expires_at <= ?", record.UserID, record.Key, now).
+			Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+func (s *GormStore) PurgeExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+func (s *GormStore) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := eventseq.Next(tx, outboxFeed)
+		if err != nil {
+			return err
+		}
+		event.Sequence = sequence
+		return tx.Create(event).Error
+	})
+}
+
+func (s *GormStore) PendingOutboxEvents(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.WithContext(ctx).Where("published_at IS NULL").Order("sequence").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (s *GormStore) MarkOutboxEventPublished(ctx context.Context, id uint, at time.Time) error {
+	return s.db.WithContext(ctx).Model(&models.OutboxEvent{ID: id}).Update("published_at", at).Error
+}
+
+func (s *GormStore) RecordOutboxFailure(ctx context.Context, id uint, attempts int, lastError string, nextAttemptAt time.Time) error {
+	return s.db.WithContext(ctx).Model(&models.OutboxEvent{ID: id}).Updates(map[string]any{
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+}
+
+func (s *GormStore) EventCursor(ctx context.Context, source string) (uint64, error) {
+	var position models.EventCursor
+	err := s.db.WithContext(ctx).Where("source = ?", source).Limit(1).Find(&position).Error
+	return position.Sequence, err
+}
+
+func (s *GormStore) SaveEventCursor(ctx context.Context, source string, sequence uint64) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sequence", "updated_at"}),
+	}).Create(&models.EventCursor{Source: source, Sequence: sequence}).Error
+}
+
+func (s *GormStore) FlagDeletedUser(ctx context.Context, userID uint, deleted bool) error {
+	return s.db.WithContext(ctx).Unscoped().Model(&models.Order{}).Where("user_id = ?", userID).
+		Update("customer_deleted", deleted).Error
+}
+
+func (s *GormStore) AnonymiseUser(ctx context.Context, userID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Order{}).Where("user_id = ?", userID).
+			Updates(map[string]any{"user_id": 0, "customer_deleted": true}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Order{}).Where("cancelled_by = ?", userID).
+			Update("cancelled_by", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.IdempotencyKey{}).Error
+	})
+}
+
+func (s *GormStore) FlagDeletedMenuItem(ctx context.Context, menuItemID uint, deleted bool) error {
+	return s.db.WithContext(ctx).Unscoped().Model(&models.OrderItem{}).Where("menu_item_id = ?", menuItemID).
+		Update("menu_item_deleted", deleted).Error
+}
+
+func (s *GormStore) ReferencedUserIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := s.db.WithContext(ctx).Unscoped().Model(&models.Order{}).
+		Where("user_id <> 0 AND customer_deleted = ?", false).
+		Distinct().Order("user_id").Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (s *GormStore) ReferencedMenuItemIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := s.db.WithContext(ctx).Unscoped().Model(&models.OrderItem{}).
+		Where("menu_item_deleted = ?", false).
+		Distinct().Order("menu_item_id").Pluck("menu_item_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,470 @@
+package store
+
+import (
+	"context"
+	"dberr"
+	"maps"
+	"order-service/models"
+	"slices"
+	"storedriver"
+	"time"
+)
+
+// MemoryStore keeps orders in process memory for development and tests.
+type MemoryStore struct {
+	storedriver.Memory
+	data *memoryData
+}
+
+// memoryData is the content of a MemoryStore. Records are stored by value,
+// orders without their items, and replaced rather than modified, so a
+// shallow copy of the maps is a snapshot.
+type memoryData struct {
+	orders     map[uint]models.Order
+	orderItems map[uint][]models.OrderItem
+	keys       map[idempotencyID]models.IdempotencyKey
+	outbox     map[uint]models.OutboxEvent
+	cursors    map[string]uint64
+	ids        storedriver.MemoryIDs
+}
+
+// idempotencyID is the primary key of an IdempotencyKey.
+type idempotencyID struct {
+	userID uint
+	key    string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Memory: storedriver.NewMemory(),
+		data: &memoryData{
+			orders:     make(map[uint]models.Order),
+			orderItems: make(map[uint][]models.OrderItem),
+			keys:       make(map[idempotencyID]models.IdempotencyKey),
+			outbox:     make(map[uint]models.OutboxEvent),
+			cursors:    make(map[string]uint64),
+			ids:        storedriver.MemoryIDs{},
+		},
+	}
+}
+
+func (d memoryData) Clone() memoryData {
+	return memoryData{
+		orders:     maps.Clone(d.orders),
+		orderItems: maps.Clone(d.orderItems),
+		keys:       maps.Clone(d.keys),
+		outbox:     maps.Clone(d.outbox),
+		cursors:    maps.Clone(d.cursors),
+		ids:        maps.Clone(d.ids),
+	}
+}
+
+func (s *MemoryStore) Transaction(ctx context.Context, fn func(tx OrderStore) error) error {
+	return storedriver.MemoryTransaction(ctx, s.Memory, s.data, func(tx storedriver.Memory) error {
+		return fn(&MemoryStore{Memory: tx, data: s.data})
+	})
+}
+
+func (s *MemoryStore) CreateOrder(ctx context.Context, order *models.Order) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	order.ID = s.data.ids.Next("orders")
+	order.CreatedAt, order.UpdatedAt = now, now
+	if order.Status == "" {
+		order.Status = models.StatusPending
+	}
+	if order.Currency == "" {
+		order.Currency = models.DefaultCurrency
+	}
+
+	items := make([]models.OrderItem, len(order.OrderItems))
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		item.ID = s.data.ids.Next("order_items")
+		item.OrderID = order.ID
+		item.CreatedAt, item.UpdatedAt = now, now
+		items[i] = *item
+	}
+	s.data.orders[order.ID] = stripItems(*order)
+	s.data.orderItems[order.ID] = items
+	return nil
+}
+
+// stripItems copies order for storage without its items or shared pointers.
+func stripItems(order models.Order) models.Order {
+	order.OrderItems = nil
+	order.CancelledBy = copyPtr(order.CancelledBy)
+	order.CancelledAt = copyPtr(order.CancelledAt)
+	order.RefundedAt = copyPtr(order.RefundedAt)
+	return order
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// withItems returns a copy of a stored order with its items.
+func (s *MemoryStore) withItems(order models.Order) models.Order {
+	order = stripItems(order)
+	order.OrderItems = slices.Clone(s.data.orderItems[order.ID])
+	return order
+}
+
+func (s *MemoryStore) GetOrder(ctx context.Context, id uint) (models.Order, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.Order{}, err
+	}
+	defer unlock()
+
+	order, ok := s.data.orders[id]
+	if !ok || order.DeletedAt.Valid {
+		return models.Order{}, ErrNotFound
+	}
+	return s.withItems(order), nil
+}
+
+func (s *MemoryStore) ListOrders(ctx context.Context, filter OrderFilter, page Page) ([]models.Order, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var orders []models.Order
+	for _, id := range slices.Sorted(maps.Keys(s.data.orders)) {
+		order := s.data.orders[id]
+		if id <= page.AfterID || order.DeletedAt.Valid ||
+			filter.UserID != 0 && order.UserID != filter.UserID ||
+			filter.Status != "" && order.Status != filter.Status ||
+			filter.CreatedAfter != nil && order.CreatedAt.Before(*filter.CreatedAfter) ||
+			filter.CreatedBefore != nil && !order.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		orders = append(orders, s.withItems(order))
+		if len(orders) == page.Limit {
+			break
+		}
+	}
+	return orders, nil
+}
+
+func (s *MemoryStore) TransitionOrder(ctx context.Context, order *models.Order, from models.OrderStatus) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	stored, ok := s.data.orders[order.ID]
+	if !ok || stored.DeletedAt.Valid || stored.Status != from {
+		return false, nil
+	}
+	order.UpdatedAt = time.Now()
+	stored.Status = order.Status
+	stored.CancellationReason = order.CancellationReason
+	stored.CancellationNote = order.CancellationNote
+	stored.CancelledBy = copyPtr(order.CancelledBy)
+	stored.CancelledAt = copyPtr(order.CancelledAt)
+	stored.UpdatedAt = order.UpdatedAt
+	s.data.orders[order.ID] = stored
+	return true, nil
+}
+
+func (s *MemoryStore) MarkOrderRefunded(ctx context.Context, order *models.Order, at time.Time) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	stored, ok := s.data.orders[order.ID]
+	if !ok || stored.DeletedAt.Valid || stored.RefundedAt != nil {
+		return false, nil
+	}
+	order.RefundedAt, order.UpdatedAt = &at, time.Now()
+	stored.RefundedAt, stored.UpdatedAt = copyPtr(&at), order.UpdatedAt
+	s.data.orders[order.ID] = stored
+	return true, nil
+}
+
+func (s *MemoryStore) SaveOrderTotals(ctx context.Context, order *models.Order) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	stored, ok := s.data.orders[order.ID]
+	if !ok || stored.DeletedAt.Valid {
+		return nil
+	}
+	order.UpdatedAt = time.Now()
+	stored.DiscountCents = order.DiscountCents
+	stored.PointsRedeemed = order.PointsRedeemed
+	stored.TaxCents = order.TaxCents
+	stored.TotalCents = order.TotalCents
+	stored.UpdatedAt = order.UpdatedAt
+	s.data.orders[order.ID] = stored
+	return nil
+}
+
+func (s *MemoryStore) FindIdempotencyKey(ctx context.Context, userID uint, key string, now time.Time) (models.IdempotencyKey, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.IdempotencyKey{}, err
+	}
+	defer unlock()
+
+	record, ok := s.data.keys[idempotencyID{userID, key}]
+	if !ok || !record.ExpiresAt.After(now) {
+		return models.IdempotencyKey{}, ErrNotFound
+	}
+	return record, nil
+}
+
+func (s *MemoryStore) CreateIdempotencyKey(ctx context.Context, record *models.IdempotencyKey, now time.Time) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	id := idempotencyID{record.UserID, record.Key}
+	if existing, ok := s.data.keys[id]; ok && existing.ExpiresAt.After(now) {
+		return &dberr.UniqueViolation{Field: "key"}
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	s.data.keys[id] = *record
+	return nil
+}
+
+func (s *MemoryStore) PurgeExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var purged int64
+	for id, record := range s.data.keys {
+		if !record.ExpiresAt.After(now) {
+			delete(s.data.keys, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (s *MemoryStore) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Transactions hold the store lock, so IDs already follow commit order
+	event.ID = s.data.ids.Next("outbox_events")
+	event.Sequence = uint64(event.ID)
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	s.data.outbox[event.ID] = *event
+	return nil
+}
+
+func (s *MemoryStore) PendingOutboxEvents(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var events []models.OutboxEvent
+	for _, id := range slices.Sorted(maps.Keys(s.data.outbox)) {
+		if len(events) == limit {
+			break
+		}
+		if event := s.data.outbox[id]; event.PublishedAt == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) MarkOutboxEventPublished(ctx context.Context, id uint, at time.Time) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if event, ok := s.data.outbox[id]; ok {
+		event.PublishedAt = &at
+		s.data.outbox[id] = event
+	}
+	return nil
+}
+
+func (s *MemoryStore) RecordOutboxFailure(ctx context.Context, id uint, attempts int, lastError string, nextAttemptAt time.Time) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if event, ok := s.data.outbox[id]; ok {
+		event.Attempts, event.LastError, event.NextAttemptAt = attempts, lastError, nextAttemptAt
+		s.data.outbox[id] = event
+	}
+	return nil
+}
+
+func (s *MemoryStore) EventCursor(ctx context.Context, source string) (uint64, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	return s.data.cursors[source], nil
+}
+
+func (s *MemoryStore) SaveEventCursor(ctx context.Context, source string, sequence uint64) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.data.cursors[source] = sequence
+	return nil
+}
+
+func (s *MemoryStore) FlagDeletedUser(ctx context.Context, userID uint, deleted bool) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.updateOrders(func(order *models.Order) bool {
+		if order.UserID != userID {
+			return false
+		}
+		order.CustomerDeleted = deleted
+		return true
+	})
+	return nil
+}
+
+func (s *MemoryStore) AnonymiseUser(ctx context.Context, userID uint) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.updateOrders(func(order *models.Order) bool {
+		changed := false
+		if order.UserID == userID {
+			order.UserID, order.CustomerDeleted = 0, true
+			changed = true
+		}
+		if order.CancelledBy != nil && *order.CancelledBy == userID {
+			order.CancelledBy = nil
+			changed = true
+		}
+		return changed
+	})
+	for id := range s.data.keys {
+		if id.userID == userID {
+			delete(s.data.keys, id)
+		}
+	}
+	return nil
+}
+
+// updateOrders applies change to every order, deleted or not, and saves
+// those it reports as changed.
+func (s *MemoryStore) updateOrders(change func(order *models.Order) bool) {
+	now := time.Now()
+	for id, order := range s.data.orders {
+		order = stripItems(order)
+		if change(&order) {
+			order.UpdatedAt = now
+			s.data.orders[id] = order
+		}
+	}
+}
+
+func (s *MemoryStore) FlagDeletedMenuItem(ctx context.Context, menuItemID uint, deleted bool) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	for orderID, items := range s.data.orderItems {
+		if !slices.ContainsFunc(items, func(item models.OrderItem) bool { return item.MenuItemID == menuItemID }) {
+			continue
+		}
+		items = slices.Clone(items)
+		for i := range items {
+			if items[i].MenuItemID == menuItemID {
+				items[i].MenuItemDeleted = deleted
+				items[i].UpdatedAt = now
+			}
+		}
+		s.data.orderItems[orderID] = items
+	}
+	return nil
+}
+
+func (s *MemoryStore) ReferencedUserIDs(ctx context.Context) ([]uint, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var ids []uint
+	for _, order := range s.data.orders {
+		if order.UserID != 0 && !order.CustomerDeleted {
+			ids = append(ids, order.UserID)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+func (s *MemoryStore) ReferencedMenuItemIDs(ctx context.Context) ([]uint, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var ids []uint
+	for _, items := range s.data.orderItems {
+		for _, item := range items {
+			if !item.MenuItemDeleted {
+				ids = append(ids, item.MenuItemID)
+			}
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"storedriver"
+
+	"gorm.io/gorm"
+)
+
+// Backends builds an OrderStore on each store driver.
+var Backends = storedriver.Backends[OrderStore]{
+	Migrate:   Migrate,
+	NewGorm:   func(db *gorm.DB) OrderStore { return NewGormStore(db) },
+	NewMemory: func() OrderStore { return NewMemoryStore() },
+}
+
+// Open opens the order store selected by cfg.
+func Open(cfg storedriver.Config) (OrderStore, error) {
+	return storedriver.Open(cfg, Backends)
+}
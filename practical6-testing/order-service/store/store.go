@@ -0,0 +1,95 @@
+// Package store persists orders with their items, CreateOrder idempotency
+// keys, the event outbox and the state kept to follow other services' event
+// feeds. Handlers, the outbox relay and the consistency consumer depend only
+// on OrderStore.
+package store
+
+import (
+	"context"
+	"order-service/models"
+	"pagination"
+	"storedriver"
+	"time"
+)
+
+// ErrNotFound is returned when no row matches.
+var ErrNotFound = storedriver.ErrNotFound
+
+// Page selects the rows a list reads, in ID order.
+type Page = pagination.Page
+
+// OrderFilter narrows ListOrders. Zero fields match every order.
+type OrderFilter struct {
+	UserID uint
+	Status models.OrderStatus
+	// CreatedAfter and CreatedBefore bound the creation time, inclusive
+	// and exclusive respectively.
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// OrderStore is the persistence used by order-service. Orders are returned
+// with their items.
+type OrderStore interface {
+	storedriver.Store[OrderStore]
+
+	// CreateOrder inserts order with its items and fills in their IDs and
+	// timestamps.
+	CreateOrder(ctx context.Context, order *models.Order) error
+	GetOrder(ctx context.Context, id uint) (models.Order, error)
+	ListOrders(ctx context.Context, filter OrderFilter, page Page) ([]models.Order, error)
+	// TransitionOrder saves the order's status and cancellation details if
+	// it is still in status from, and reports whether it was. Only one of
+	// several concurrent transitions from the same status succeeds.
+	TransitionOrder(ctx context.Context, order *models.Order, from models.OrderStatus) (bool, error)
+	// MarkOrderRefunded sets the order's refund time unless it has already
+	// been refunded, and reports whether it did.
+	MarkOrderRefunded(ctx context.Context, order *models.Order, at time.Time) (bool, error)
+	// SaveOrderTotals saves the order's discount, points redeemed, tax and
+	// total.
+	SaveOrderTotals(ctx context.Context, order *models.Order) error
+
+	// FindIdempotencyKey returns the user's key if it has not expired at
+	// now.
+	FindIdempotencyKey(ctx context.Context, userID uint, key string, now time.Time) (models.IdempotencyKey, error)
+	// CreateIdempotencyKey saves record, replacing a record for the same
+	// key that has expired at now. A key that has not expired is a unique
+	// violation.
+	CreateIdempotencyKey(ctx context.Context, record *models.IdempotencyKey, now time.Time) error
+	// PurgeExpiredIdempotencyKeys deletes keys that have expired at now
+	// and returns how many were removed.
+	PurgeExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+
+	// CreateOutboxEvent writes an event to the outbox, filling in its
+	// sequence number.
+	CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error
+	// PendingOutboxEvents returns up to limit unpublished events in
+	// sequence order.
+	PendingOutboxEvents(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id uint, at time.Time) error
+	// RecordOutboxFailure saves a failed attempt to publish an event and
+	// when to try again.
+	RecordOutboxFailure(ctx context.Context, id uint, attempts int, lastError string, nextAttemptAt time.Time) error
+
+	// EventCursor returns the last sequence applied from source, 0 before
+	// the first.
+	EventCursor(ctx context.Context, source string) (uint64, error)
+	SaveEventCursor(ctx context.Context, source string, sequence uint64) error
+	// FlagDeletedUser marks the orders of a deleted user, or clears the
+	// mark when the user has been restored.
+	FlagDeletedUser(ctx context.Context, userID uint, deleted bool) error
+	// AnonymiseUser removes every reference to a purged user: its orders
+	// keep their contents but no longer name a customer, and its
+	// idempotency keys are dropped.
+	AnonymiseUser(ctx context.Context, userID uint) error
+	// FlagDeletedMenuItem marks the order items of a deleted menu item, or
+	// clears the mark when the item has been restored. The items keep their
+	// name and price snapshots either way.
+	FlagDeletedMenuItem(ctx context.Context, menuItemID uint, deleted bool) error
+	// ReferencedUserIDs returns, in ascending order, the users named by
+	// orders that are not flagged as deleted.
+	ReferencedUserIDs(ctx context.Context) ([]uint, error)
+	// ReferencedMenuItemIDs returns, in ascending order, the menu items of
+	// order items that are not flagged as deleted.
+	ReferencedMenuItemIDs(ctx context.Context) ([]uint, error)
+}
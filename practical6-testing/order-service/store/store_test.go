@@ -0,0 +1,15 @@
+package store_test
+
+import (
+	"order-service/store"
+	"order-service/store/storetest"
+	"storedriver/storedrivertest"
+	"testing"
+)
+
+// TestStore also runs against the database in TEST_POSTGRES_DSN, whose
+// tables it drops.
+func TestStore(t *testing.T) {
+	storedrivertest.Run(t, store.Backends, storetest.Run,
+		"orders", "order_items", "idempotency_keys", "outbox_events", "event_sequences", "event_cursors")
+}
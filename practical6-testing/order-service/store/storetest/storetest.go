@@ -0,0 +1,410 @@
+// Package storetest is the conformance suite every store.OrderStore
+// implementation must pass.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"order-service/models"
+	"order-service/store"
+	"testing"
+	"time"
+
+	"dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Run runs the suite against stores returned by newStore, which must be
+// empty and independent of each other.
+func Run(t *testing.T, newStore func(t *testing.T) store.OrderStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.OrderStore)
+	}{
+		{"Ping", testPing},
+		{"Close", testClose},
+		{"CreateAndGetOrder", testCreateAndGetOrder},
+		{"ListOrders", testListOrders},
+		{"TransitionOrder", testTransitionOrder},
+		{"MarkOrderRefunded", testMarkOrderRefunded},
+		{"SaveOrderTotals", testSaveOrderTotals},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"Outbox", testOutbox},
+		{"EventCursor", testEventCursor},
+		{"DeletedReferences", testDeletedReferences},
+		{"TransactionRollback", testTransactionRollback},
+		{"CancelledContext", testCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func createOrder(t *testing.T, s store.OrderStore, userID uint, menuItemIDs ...uint) models.Order {
+	t.Helper()
+	order := models.Order{UserID: userID, Status: models.StatusPending, Currency: models.DefaultCurrency}
+	for _, id := range menuItemIDs {
+		order.OrderItems = append(order.OrderItems, models.OrderItem{
+			MenuItemID: id, MenuItemName: "Coffee", Quantity: 2, UnitPriceCents: 250, LineTotalCents: 500,
+		})
+	}
+	require.NoError(t, s.CreateOrder(context.Background(), &order))
+	return order
+}
+
+func ids(orders []models.Order) []uint {
+	var ids []uint
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
+
+func testCreateAndGetOrder(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	order := createOrder(t, s, 7, 1, 2)
+	require.NotZero(t, order.ID)
+	require.Len(t, order.OrderItems, 2)
+	assert.NotZero(t, order.OrderItems[0].ID)
+	assert.Equal(t, order.ID, order.OrderItems[0].OrderID)
+
+	got, err := s.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), got.UserID)
+	assert.Equal(t, models.StatusPending, got.Status)
+	require.Len(t, got.OrderItems, 2)
+	assert.Equal(t, uint(1), got.OrderItems[0].MenuItemID)
+	assert.Equal(t, int64(500), got.OrderItems[0].LineTotalCents)
+
+	_, err = s.GetOrder(ctx, order.ID+100)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func testListOrders(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	first := createOrder(t, s, 1, 10)
+	second := createOrder(t, s, 2, 10)
+	third := createOrder(t, s, 1, 11)
+
+	all, err := s.ListOrders(ctx, store.OrderFilter{}, store.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{first.ID, second.ID, third.ID}, ids(all))
+	assert.Len(t, all[0].OrderItems, 1)
+
+	mine, err := s.ListOrders(ctx, store.OrderFilter{UserID: 1}, store.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{first.ID, third.ID}, ids(mine))
+
+	page, err := s.ListOrders(ctx, store.OrderFilter{}, store.Page{AfterID: first.ID, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{second.ID}, ids(page))
+
+	second.Status = models.StatusConfirmed
+	_, err = s.TransitionOrder(ctx, &second, models.StatusPending)
+	require.NoError(t, err)
+	confirmed, err := s.ListOrders(ctx, store.OrderFilter{Status: models.StatusConfirmed}, store.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{second.ID}, ids(confirmed))
+
+	future := time.Now().Add(time.Hour)
+	none, err := s.ListOrders(ctx, store.OrderFilter{CreatedAfter: &future}, store.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+	before, err := s.ListOrders(ctx, store.OrderFilter{CreatedBefore: &future}, store.Page{})
+	require.NoError(t, err)
+	assert.Len(t, before, 3)
+}
+
+func testTransitionOrder(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	order := createOrder(t, s, 1, 10)
+
+	staff := uint(9)
+	cancelledAt := time.Now().Truncate(time.Second)
+	order.Status = models.StatusCancelled
+	order.CancellationReason = models.ReasonCafeClosed
+	order.CancellationNote = "closing early"
+	order.CancelledBy = &staff
+	order.CancelledAt = &cancelledAt
+	applied, err := s.TransitionOrder(ctx, &order, models.StatusPending)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	got, err := s.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCancelled, got.Status)
+	assert.Equal(t, models.ReasonCafeClosed, got.CancellationReason)
+	assert.Equal(t, "closing early", got.CancellationNote)
+	require.NotNil(t, got.CancelledBy)
+	assert.Equal(t, staff, *got.CancelledBy)
+	require.NotNil(t, got.CancelledAt)
+	assert.True(t, cancelledAt.Equal(*got.CancelledAt))
+
+	// A stale transition from the old status is not applied
+	order.Status = models.StatusConfirmed
+	applied, err = s.TransitionOrder(ctx, &order, models.StatusPending)
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	got, err = s.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusCancelled, got.Status)
+}
+
+func testMarkOrderRefunded(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	order := createOrder(t, s, 1, 10)
+
+	refunded, err := s.MarkOrderRefunded(ctx, &order, time.Now())
+	require.NoError(t, err)
+	assert.True(t, refunded)
+	refunded, err = s.MarkOrderRefunded(ctx, &order, time.Now())
+	require.NoError(t, err)
+	assert.False(t, refunded)
+
+	got, err := s.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.RefundedAt)
+}
+
+func testSaveOrderTotals(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	order := createOrder(t, s, 1, 10)
+
+	order.DiscountCents = 100
+	order.PointsRedeemed = 10
+	order.TaxCents = 40
+	order.TotalCents = 440
+	order.Status = models.StatusCompleted
+	require.NoError(t, s.SaveOrderTotals(ctx, &order))
+
+	got, err := s.GetOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), got.DiscountCents)
+	assert.Equal(t, int64(10), got.PointsRedeemed)
+	assert.Equal(t, int64(40), got.TaxCents)
+	assert.Equal(t, int64(440), got.TotalCents)
+	assert.Equal(t, models.StatusPending, got.Status, "only the totals are saved")
+}
+
+func testIdempotencyKeys(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	now := time.Now()
+
+	record := models.IdempotencyKey{UserID: 1, Key: "k1", RequestHash: "h1", OrderID: 5, ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, s.CreateIdempotencyKey(ctx, &record, now))
+
+	got, err := s.FindIdempotencyKey(ctx, 1, "k1", now)
+	require.NoError(t, err)
+	assert.Equal(t, "h1", got.RequestHash)
+	assert.Equal(t, uint(5), got.OrderID)
+
+	_, err = s.FindIdempotencyKey(ctx, 2, "k1", now)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.FindIdempotencyKey(ctx, 1, "k1", now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	duplicate := models.IdempotencyKey{UserID: 1, Key: "k1", RequestHash: "h2", OrderID: 6, ExpiresAt: now.Add(time.Hour)}
+	err = s.CreateIdempotencyKey(ctx, &duplicate, now)
+	assert.Equal(t, codes.AlreadyExists, status.Code(dberr.Status(err, "idempotency key")))
+
+	// Once expired, the key can be used again
+	later := now.Add(2 * time.Hour)
+	reused := models.IdempotencyKey{UserID: 1, Key: "k1", RequestHash: "h3", OrderID: 7, ExpiresAt: later.Add(time.Hour)}
+	require.NoError(t, s.CreateIdempotencyKey(ctx, &reused, later))
+	got, err = s.FindIdempotencyKey(ctx, 1, "k1", later)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), got.OrderID)
+
+	other := models.IdempotencyKey{UserID: 2, Key: "k2", RequestHash: "h", OrderID: 8, ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, s.CreateIdempotencyKey(ctx, &other, now))
+	purged, err := s.PurgeExpiredIdempotencyKeys(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	_, err = s.FindIdempotencyKey(ctx, 1, "k1", later)
+	assert.NoError(t, err)
+}
+
+func testOutbox(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	first := models.OutboxEvent{Type: "OrderCreated", OrderID: 1, Payload: "{}"}
+	second := models.OutboxEvent{Type: "OrderCancelled", OrderID: 1, Payload: "{}"}
+	require.NoError(t, s.CreateOutboxEvent(ctx, &first))
+	require.NoError(t, s.CreateOutboxEvent(ctx, &second))
+	assert.Greater(t, second.ID, first.ID)
+	assert.Equal(t, first.Sequence+1, second.Sequence)
+
+	pending, err := s.PendingOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, first.ID, pending[0].ID)
+	assert.Equal(t, "OrderCreated", pending[0].Type)
+
+	pending, err = s.PendingOutboxEvents(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	retryAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	require.NoError(t, s.RecordOutboxFailure(ctx, first.ID, 1, "unavailable", retryAt))
+	pending, err = s.PendingOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "unavailable", pending[0].LastError)
+	assert.True(t, retryAt.Equal(pending[0].NextAttemptAt))
+
+	require.NoError(t, s.MarkOutboxEventPublished(ctx, first.ID, time.Now()))
+	pending, err = s.PendingOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, second.ID, pending[0].ID)
+
+	// A rolled back event leaves no gap in the sequence
+	errRollback := errors.New("rollback")
+	err = s.Transaction(ctx, func(tx store.OrderStore) error {
+		require.NoError(t, tx.CreateOutboxEvent(ctx, &models.OutboxEvent{Type: "OrderCreated", OrderID: 2, Payload: "{}"}))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	third := models.OutboxEvent{Type: "OrderCreated", OrderID: 3, Payload: "{}"}
+	require.NoError(t, s.CreateOutboxEvent(ctx, &third))
+	assert.Equal(t, second.Sequence+1, third.Sequence)
+}
+
+func testEventCursor(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+
+	sequence, err := s.EventCursor(ctx, "user-service")
+	require.NoError(t, err)
+	assert.Zero(t, sequence)
+
+	require.NoError(t, s.SaveEventCursor(ctx, "user-service", 3))
+	require.NoError(t, s.SaveEventCursor(ctx, "user-service", 5))
+	require.NoError(t, s.SaveEventCursor(ctx, "menu-service", 2))
+
+	sequence, err = s.EventCursor(ctx, "user-service")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), sequence)
+	sequence, err = s.EventCursor(ctx, "menu-service")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), sequence)
+}
+
+func testDeletedReferences(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	first := createOrder(t, s, 1, 10, 11)
+	second := createOrder(t, s, 2, 11)
+	record := models.IdempotencyKey{UserID: 1, Key: "k", RequestHash: "h", OrderID: first.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, s.CreateIdempotencyKey(ctx, &record, time.Now()))
+
+	// User 1 cancelled user 2's order
+	canceller := uint(1)
+	second.Status = models.StatusCancelled
+	second.CancelledBy = &canceller
+	_, err := s.TransitionOrder(ctx, &second, models.StatusPending)
+	require.NoError(t, err)
+
+	users, err := s.ReferencedUserIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, users)
+	menuItems, err := s.ReferencedMenuItemIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{10, 11}, menuItems)
+
+	require.NoError(t, s.FlagDeletedUser(ctx, 2, true))
+	require.NoError(t, s.FlagDeletedMenuItem(ctx, 11, true))
+	users, err = s.ReferencedUserIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1}, users)
+	menuItems, err = s.ReferencedMenuItemIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{10}, menuItems)
+
+	got, err := s.GetOrder(ctx, first.ID)
+	require.NoError(t, err)
+	assert.False(t, got.OrderItems[0].MenuItemDeleted)
+	assert.True(t, got.OrderItems[1].MenuItemDeleted)
+	assert.Equal(t, "Coffee", got.OrderItems[1].MenuItemName)
+
+	require.NoError(t, s.FlagDeletedUser(ctx, 2, false))
+	require.NoError(t, s.FlagDeletedMenuItem(ctx, 11, false))
+	menuItems, err = s.ReferencedMenuItemIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{10, 11}, menuItems)
+
+	require.NoError(t, s.AnonymiseUser(ctx, 1))
+	got, err = s.GetOrder(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Zero(t, got.UserID)
+	assert.True(t, got.CustomerDeleted)
+	got, err = s.GetOrder(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), got.UserID)
+	assert.False(t, got.CustomerDeleted)
+	assert.Nil(t, got.CancelledBy)
+	_, err = s.FindIdempotencyKey(ctx, 1, "k", time.Now())
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	users, err = s.ReferencedUserIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{2}, users)
+}
+
+func testTransactionRollback(t *testing.T, s store.OrderStore) {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+	var created models.Order
+
+	err := s.Transaction(ctx, func(tx store.OrderStore) error {
+		created = models.Order{UserID: 1, Status: models.StatusPending, Currency: models.DefaultCurrency}
+		if err := tx.CreateOrder(ctx, &created); err != nil {
+			return err
+		}
+		if err := tx.CreateOutboxEvent(ctx, &models.OutboxEvent{Type: "OrderCreated", OrderID: created.ID, Payload: "{}"}); err != nil {
+			return err
+		}
+		if err := tx.SaveEventCursor(ctx, "user-service", 4); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	_, err = s.GetOrder(ctx, created.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	pending, err := s.PendingOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	sequence, err := s.EventCursor(ctx, "user-service")
+	require.NoError(t, err)
+	assert.Zero(t, sequence)
+
+	err = s.Transaction(ctx, func(tx store.OrderStore) error {
+		created = models.Order{UserID: 1, Status: models.StatusPending, Currency: models.DefaultCurrency}
+		return tx.CreateOrder(ctx, &created)
+	})
+	require.NoError(t, err)
+	_, err = s.GetOrder(ctx, created.ID)
+	assert.NoError(t, err)
+}
+
+func testCancelledContext(t *testing.T, s store.OrderStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.GetOrder(ctx, 1)
+	assert.Equal(t, codes.Canceled, status.Code(dberr.Status(err, "order")))
+}
+
+func testPing(t *testing.T, s store.OrderStore) {
+	require.NoError(t, s.Ping(context.Background()))
+}
+
+func testClose(t *testing.T, s store.OrderStore) {
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,91 @@
+package watch
+
+import (
+	"sync"
+
+	orderv1 "order-service/proto/orderv1"
+)
+
+// subscriberBuffer is how many updates a watcher may fall behind before it
+// is disconnected.
+const subscriberBuffer = 64
+
+// Filter decides whether a subscriber wants to see an order update.
+type Filter func(order *orderv1.Order) bool
+
+// Subscription receives order updates published to a Hub.
+type Subscription struct {
+	// Updates delivers every matching order. It is closed when the
+	// subscription is cancelled or the subscriber falls too far behind.
+	Updates <-chan *orderv1.Order
+
+	hub     *Hub
+	updates chan *orderv1.Order
+	filter  Filter
+}
+
+// Cancel stops delivery and closes Updates. It is safe to call more than once.
+func (sub *Subscription) Cancel() {
+	sub.hub.remove(sub)
+}
+
+// Hub fans order updates out to in-process watchers so that streams never
+// have to poll the database.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers a watcher for orders matching filter. A nil filter
+// matches every order.
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	updates := make(chan *orderv1.Order, subscriberBuffer)
+	sub := &Subscription{
+		Updates: updates,
+		hub:     h,
+		updates: updates,
+		filter:  filter,
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// Publish delivers order to every matching subscriber without blocking.
+// Published orders are shared between subscribers and must not be modified.
+// A nil Hub ignores updates.
+func (h *Hub) Publish(order *orderv1.Order) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if sub.filter != nil && !sub.filter(order) {
+			continue
+		}
+		select {
+		case sub.updates <- order:
+		default:
+			// Too slow to keep up; drop the watcher rather than the update
+			delete(h.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.updates)
+	}
+}
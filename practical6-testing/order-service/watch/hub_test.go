@@ -0,0 +1,53 @@
+package watch
+
+import (
+	"testing"
+
+	orderv1 "order-service/proto/orderv1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_FanOutAndFilter(t *testing.T) {
+	hub := NewHub()
+
+	all := hub.Subscribe(nil)
+	defer all.Cancel()
+	mine := hub.Subscribe(func(order *orderv1.Order) bool { return order.UserId == 7 })
+	defer mine.Cancel()
+
+	hub.Publish(&orderv1.Order{Id: 1, UserId: 3})
+	hub.Publish(&orderv1.Order{Id: 2, UserId: 7})
+
+	assert.Equal(t, uint32(1), (<-all.Updates).Id)
+	assert.Equal(t, uint32(2), (<-all.Updates).Id)
+	assert.Equal(t, uint32(2), (<-mine.Updates).Id)
+	assert.Empty(t, mine.Updates)
+}
+
+func TestHub_CancelAndSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+
+	cancelled := hub.Subscribe(nil)
+	cancelled.Cancel()
+	cancelled.Cancel()
+	_, open := <-cancelled.Updates
+	assert.False(t, open)
+
+	slow := hub.Subscribe(nil)
+	for i := 0; i <= subscriberBuffer; i++ {
+		hub.Publish(&orderv1.Order{Id: uint32(i)})
+	}
+
+	received := 0
+	for range slow.Updates {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+	slow.Cancel()
+}
+
+func TestHub_NilPublish(t *testing.T) {
+	var hub *Hub
+	assert.NotPanics(t, func() { hub.Publish(&orderv1.Order{Id: 1}) })
+}
@@ -0,0 +1,165 @@
+// Package pagination implements the page_size and page_token fields of the
+// services' list RPCs and the keyset queries behind them.
+//
+// Pages are keyed on primary key, so no row is listed twice, and rows
+// inserted while a client is paging normally land after its cursor. On
+// Postgres, though, IDs are handed out when a row is inserted rather than
+// when it commits, so a row whose transaction was still open when a page
+// was read can commit with an ID below the cursor and be missed by that
+// listing.
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize is used when a request leaves page_size unset.
+	DefaultPageSize = 50
+	// MaxPageSize caps page_size.
+	MaxPageSize = 100
+
+	pageTokenPrefix = "after:"
+)
+
+// Page selects up to Limit rows with an ID greater than AfterID, in ID
+// order. A zero Limit selects every row.
+type Page struct {
+	AfterID uint
+	Limit   int
+}
+
+// Request is the page of a list RPC to read, as returned by Parse.
+type Request struct {
+	// Page is what to read from the store. It asks for one row more than
+	// the page size so that Trim can tell whether another page exists.
+	Page Page
+
+	size   int
+	filter string
+}
+
+// ListRequest is the request message of a list RPC, which has page_size
+// and page_token fields.
+type ListRequest interface {
+	proto.Message
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+// Parse turns the page size and token of req into the page to read. Page
+// tokens are bound to the rest of the request, such as its filters, so a
+// client cannot carry its position over into a different listing.
+func Parse(req ListRequest) (Request, error) {
+	if req.GetPageSize() < 0 {
+		return Request{}, status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	size := int(req.GetPageSize())
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	size = min(size, MaxPageSize)
+
+	fingerprint, err := filterFingerprint(req)
+	if err != nil {
+		return Request{}, status.Errorf(codes.Internal, "failed to encode request: %v", err)
+	}
+
+	page := Request{Page: Page{Limit: size + 1}, size: size, filter: fingerprint}
+	if token := req.GetPageToken(); token != "" {
+		afterID, tokenFilter, err := decodePageToken(token)
+		if err != nil {
+			return Request{}, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+		if tokenFilter != fingerprint {
+			return Request{}, status.Errorf(codes.InvalidArgument, "page_token was issued for a request with different filters")
+		}
+		page.Page.AfterID = afterID
+	}
+	return page, nil
+}
+
+// Trim drops the extra row read for req and returns the token for the
+// following page, or an empty string on the last page.
+func Trim[T any](req Request, rows []T, id func(T) uint) ([]T, string) {
+	if len(rows) <= req.size {
+		return rows, ""
+	}
+	rows = rows[:req.size]
+	return rows, encodePageToken(id(rows[req.size-1]), req.filter)
+}
+
+// Apply restricts query to page, ordered by primary key.
+func Apply(query *gorm.DB, page Page) *gorm.DB {
+	if page.AfterID > 0 {
+		query = query.Where("id > ?", page.AfterID)
+	}
+	query = query.Order("id ASC")
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	return query
+}
+
+// EscapeLike escapes LIKE wildcards so that a filter typed by a user is
+// matched literally. Queries using it must declare ESCAPE '\'.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// filterFingerprint is a short hash of req without its page fields, which
+// identifies the listing in page tokens.
+func filterFingerprint(req ListRequest) (string, error) {
+	filter := proto.Clone(req).ProtoReflect()
+	for _, name := range []protoreflect.Name{"page_size", "page_token"} {
+		if field := filter.Descriptor().Fields().ByName(name); field != nil {
+			filter.Clear(field)
+		}
+	}
+
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(filter.Interface())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func encodePageToken(lastID uint, filter string) string {
+	token := pageTokenPrefix + strconv.FormatUint(uint64(lastID), 10) + ":" + filter
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func decodePageToken(token string) (afterID uint, filter string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", err
+	}
+
+	rest, ok := strings.CutPrefix(string(raw), pageTokenPrefix)
+	if !ok {
+		return 0, "", errors.New("missing page token prefix")
+	}
+	id, filter, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, "", errors.New("missing page token filter")
+	}
+
+	parsed, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return uint(parsed), filter, nil
+}
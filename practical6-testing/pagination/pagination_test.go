@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// listRequest stands in for a list RPC request, with a struct as its
+// filters.
+type listRequest struct {
+	*structpb.Struct
+	pageSize  int32
+	pageToken string
+}
+
+func (r listRequest) GetPageSize() int32   { return r.pageSize }
+func (r listRequest) GetPageToken() string { return r.pageToken }
+
+func newListRequest(t *testing.T, pageSize int32, pageToken string, filter map[string]any) listRequest {
+	s, err := structpb.NewStruct(filter)
+	require.NoError(t, err)
+	return listRequest{Struct: s, pageSize: pageSize, pageToken: pageToken}
+}
+
+func TestParse(t *testing.T) {
+	req, err := Parse(newListRequest(t, 0, "", nil))
+	require.NoError(t, err)
+	assert.Equal(t, Page{Limit: DefaultPageSize + 1}, req.Page)
+
+	req, err = Parse(newListRequest(t, 1000, "", nil))
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageSize+1, req.Page.Limit)
+
+	_, err = Parse(newListRequest(t, -1, "", nil))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = Parse(newListRequest(t, 10, "not-a-token", nil))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTrim_FollowsPages(t *testing.T) {
+	ids := []uint{3, 5, 8, 13, 21}
+	list := func(page Page) []uint {
+		var rows []uint
+		for _, id := range ids {
+			if id > page.AfterID && len(rows) < page.Limit {
+				rows = append(rows, id)
+			}
+		}
+		return rows
+	}
+
+	var seen []uint
+	token := ""
+	for {
+		req, err := Parse(newListRequest(t, 2, token, map[string]any{"prefix": "a"}))
+		require.NoError(t, err)
+
+		var rows []uint
+		rows, token = Trim(req, list(req.Page), func(id uint) uint { return id })
+		assert.LessOrEqual(t, len(rows), 2)
+		seen = append(seen, rows...)
+		if token == "" {
+			break
+		}
+	}
+	assert.Equal(t, ids, seen)
+}
+
+func TestParse_BindsTokenToFilters(t *testing.T) {
+	filter := map[string]any{"owner": true}
+	req, err := Parse(newListRequest(t, 1, "", filter))
+	require.NoError(t, err)
+	_, token := Trim(req, []uint{1, 2}, func(id uint) uint { return id })
+	require.NotEmpty(t, token)
+
+	next, err := Parse(newListRequest(t, 1, token, filter))
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), next.Page.AfterID)
+
+	_, err = Parse(newListRequest(t, 1, token, map[string]any{"owner": false}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `50\% off\_today\\`, EscapeLike(`50% off_today\`))
+}
@@ -0,0 +1,16 @@
+module prototime
+
+go 1.24.0
+
+toolchain go1.24.10
+
+require (
+	github.com/stretchr/testify v1.11.1
+	google.golang.org/protobuf v1.36.10
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,47 @@
+// Package prototime converts the services' database timestamps into
+// google.protobuf.Timestamp message fields.
+package prototime
+
+import (
+	"database/sql"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Timestamp converts t, or returns nil for the zero time so that unset
+// times are left out of messages.
+func Timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// Optional converts a nullable time such as an order's CancelledAt.
+func Optional(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return Timestamp(*t)
+}
+
+// Null converts a nullable column. Pass gorm.DeletedAt as
+// sql.NullTime(record.DeletedAt).
+func Null(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return Timestamp(t.Time)
+}
+
+// Legacy formats t for the deprecated string timestamp fields. Unlike
+// time.Time.String it leaves out the monotonic clock reading, which only
+// times taken from time.Now carry. The zero time formats as an empty
+// string.
+func Legacy(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Round(0).String()
+}
@@ -0,0 +1,33 @@
+package prototime
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamp(t *testing.T) {
+	now := time.Now()
+	assert.True(t, Timestamp(now).AsTime().Equal(now))
+	assert.Nil(t, Timestamp(time.Time{}))
+
+	assert.Nil(t, Optional(nil))
+	assert.True(t, Optional(&now).AsTime().Equal(now))
+
+	assert.Nil(t, Null(sql.NullTime{}))
+	assert.True(t, Null(sql.NullTime{Time: now, Valid: true}).AsTime().Equal(now))
+}
+
+func TestLegacy(t *testing.T) {
+	now := time.Now()
+	assert.Contains(t, now.String(), "m=")
+	assert.NotContains(t, Legacy(now), "m=")
+
+	parsed, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", Legacy(now.UTC()))
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(now))
+
+	assert.Empty(t, Legacy(time.Time{}))
+}
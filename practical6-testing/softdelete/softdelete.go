@@ -0,0 +1,107 @@
+// Package softdelete implements the RPCs shared by services whose records
+// are soft-deleted: restoring and purging a deleted record, and the event
+// feed that tells other services about deletions.
+package softdelete
+
+import (
+	"context"
+	"dberr"
+	"pagination"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Resource is one kind of soft-deleted record, such as users.
+type Resource struct {
+	// Name names a record in errors, such as "user".
+	Name string
+	// IsDeleted reports whether a record is deleted. It fails with
+	// gorm.ErrRecordNotFound when no record, deleted or not, has the ID.
+	IsDeleted func(ctx context.Context, id uint) (bool, error)
+	// Undelete restores a deleted record, recording the event that
+	// announces it in the same transaction, and reports whether a deleted
+	// record was found.
+	Undelete func(ctx context.Context, id uint) (bool, error)
+	// Remove permanently removes a deleted record and returns how many
+	// were removed.
+	Remove func(ctx context.Context, id uint) (int64, error)
+}
+
+// Restore undeletes the record id. It fails with NotFound when the record
+// does not exist at all and with FailedPrecondition when it is not deleted.
+func (r Resource) Restore(ctx context.Context, id uint32) error {
+	if err := r.checkDeleted(ctx, id); err != nil {
+		return err
+	}
+
+	restored, err := r.Undelete(ctx, uint(id))
+	if err != nil {
+		return dberr.Status(err, r.Name)
+	}
+	if !restored {
+		return r.notDeleted()
+	}
+	return nil
+}
+
+// Purge permanently removes the deleted record id, failing like Restore.
+func (r Resource) Purge(ctx context.Context, id uint32) error {
+	if err := r.checkDeleted(ctx, id); err != nil {
+		return err
+	}
+
+	purged, err := r.Remove(ctx, uint(id))
+	if err != nil {
+		return dberr.Status(err, r.Name)
+	}
+	if purged == 0 {
+		return r.notDeleted()
+	}
+	return nil
+}
+
+func (r Resource) checkDeleted(ctx context.Context, id uint32) error {
+	deleted, err := r.IsDeleted(ctx, uint(id))
+	if err != nil {
+		return dberr.Status(err, r.Name)
+	}
+	if !deleted {
+		return r.notDeleted()
+	}
+	return nil
+}
+
+func (r Resource) notDeleted() error {
+	return status.Errorf(codes.FailedPrecondition, "%s is not deleted", r.Name)
+}
+
+// EventsRequest asks for the events of a feed after a sequence number.
+type EventsRequest interface {
+	GetAfterSequence() uint64
+	GetPageSize() int32
+}
+
+// ListEvents reads the events req asks for with list, in sequence order, and
+// converts them with toProto. name names the feed in errors. Events are kept
+// after they are read so that any number of services can follow the feed
+// from their own position.
+func ListEvents[E, P any](ctx context.Context, req EventsRequest, name string,
+	list func(ctx context.Context, afterSequence uint64, limit int) ([]E, error), toProto func(E) P) ([]P, error) {
+	limit := int(req.GetPageSize())
+	if limit == 0 {
+		limit = pagination.DefaultPageSize
+	}
+	limit = min(limit, pagination.MaxPageSize)
+
+	events, err := list(ctx, req.GetAfterSequence(), limit)
+	if err != nil {
+		return nil, dberr.Status(err, name)
+	}
+
+	converted := make([]P, 0, len(events))
+	for _, event := range events {
+		converted = append(converted, toProto(event))
+	}
+	return converted, nil
+}
@@ -0,0 +1,86 @@
+package softdelete
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// records is a soft-deleted table keyed by ID, true once deleted.
+type records map[uint]bool
+
+func (r records) resource() Resource {
+	return Resource{
+		Name: "record",
+		IsDeleted: func(ctx context.Context, id uint) (bool, error) {
+			deleted, ok := r[id]
+			if !ok {
+				return false, gorm.ErrRecordNotFound
+			}
+			return deleted, nil
+		},
+		Undelete: func(ctx context.Context, id uint) (bool, error) {
+			if !r[id] {
+				return false, nil
+			}
+			r[id] = false
+			return true, nil
+		},
+		Remove: func(ctx context.Context, id uint) (int64, error) {
+			if !r[id] {
+				return 0, nil
+			}
+			delete(r, id)
+			return 1, nil
+		},
+	}
+}
+
+func TestResource(t *testing.T) {
+	ctx := context.Background()
+	r := records{1: true, 2: false, 3: true}
+	resource := r.resource()
+
+	require.NoError(t, resource.Restore(ctx, 1))
+	assert.False(t, r[1])
+	assert.Equal(t, codes.FailedPrecondition, status.Code(resource.Restore(ctx, 1)), "restoring twice")
+	assert.Equal(t, codes.NotFound, status.Code(resource.Restore(ctx, 9)))
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(resource.Purge(ctx, 2)), "only deleted records are purged")
+	require.NoError(t, resource.Purge(ctx, 3))
+	assert.NotContains(t, r, uint(3))
+	assert.Equal(t, codes.NotFound, status.Code(resource.Purge(ctx, 3)))
+}
+
+type eventsRequest struct {
+	after    uint64
+	pageSize int32
+}
+
+func (r eventsRequest) GetAfterSequence() uint64 { return r.after }
+func (r eventsRequest) GetPageSize() int32       { return r.pageSize }
+
+func TestListEvents(t *testing.T) {
+	var gotAfter uint64
+	var gotLimit int
+	list := func(ctx context.Context, after uint64, limit int) ([]uint64, error) {
+		gotAfter, gotLimit = after, limit
+		return []uint64{after + 1, after + 2}, nil
+	}
+	toProto := func(sequence uint64) string { return "event" }
+
+	events, err := ListEvents(context.Background(), eventsRequest{after: 4}, "events", list, toProto)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event", "event"}, events)
+	assert.Equal(t, uint64(4), gotAfter)
+	assert.Equal(t, 50, gotLimit, "default page size")
+
+	_, err = ListEvents(context.Background(), eventsRequest{pageSize: 1000}, "events", list, toProto)
+	require.NoError(t, err)
+	assert.Equal(t, 100, gotLimit, "page size is capped")
+}
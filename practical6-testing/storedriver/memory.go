@@ -0,0 +1,78 @@
+package storedriver
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is the locking shared by the in-memory stores, meant for
+// development and tests: nothing survives a restart, and a transaction
+// holds a lock on the whole store until it finishes. A store embeds Memory
+// next to a pointer to its data.
+type Memory struct {
+	mu *sync.Mutex
+	// inTx is set for the store passed to a transaction, which already
+	// holds mu.
+	inTx bool
+}
+
+func NewMemory() Memory {
+	return Memory{mu: &sync.Mutex{}}
+}
+
+// Lock takes the store lock unless the store belongs to a transaction, and
+// fails if ctx is already done.
+func (m Memory) Lock(ctx context.Context) (unlock func(), err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if m.inTx {
+		return func() {}, nil
+	}
+	m.mu.Lock()
+	return m.mu.Unlock, nil
+}
+
+// Ping always succeeds unless ctx is done, as there is nothing to reach.
+func (m Memory) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Close does nothing, the data lives as long as the store.
+func (m Memory) Close() error {
+	return nil
+}
+
+// MemoryData is the content of an in-memory store. Clone returns a
+// snapshot that later changes to the store do not affect.
+type MemoryData[D any] interface {
+	Clone() D
+}
+
+// MemoryTransaction runs fn holding m's lock. fn receives the Memory for
+// the store it should work on, which shares *data. If fn fails, *data is
+// restored to what it was before.
+func MemoryTransaction[D MemoryData[D]](ctx context.Context, m Memory, data *D, fn func(tx Memory) error) error {
+	unlock, err := m.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	snapshot := (*data).Clone()
+	if err := fn(Memory{mu: m.mu, inTx: true}); err != nil {
+		*data = snapshot
+		return err
+	}
+	return nil
+}
+
+// MemoryIDs hands out the primary keys of an in-memory store, counting
+// from 1 in each table.
+type MemoryIDs map[string]uint
+
+// Next returns the next primary key of table.
+func (ids MemoryIDs) Next(table string) uint {
+	ids[table]++
+	return ids[table]
+}
@@ -0,0 +1,63 @@
+package storedriver
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counters struct {
+	counts map[string]int
+}
+
+func (c counters) Clone() counters {
+	return counters{counts: maps.Clone(c.counts)}
+}
+
+func TestMemoryTransaction(t *testing.T) {
+	ctx := context.Background()
+	mem := NewMemory()
+	data := &counters{counts: map[string]int{"coffee": 1}}
+
+	t.Run("commits when fn succeeds", func(t *testing.T) {
+		err := MemoryTransaction(ctx, mem, data, func(tx Memory) error {
+			// The transaction already holds the lock
+			unlock, err := tx.Lock(ctx)
+			require.NoError(t, err)
+			defer unlock()
+			data.counts["coffee"]++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, data.counts["coffee"])
+	})
+
+	t.Run("rolls back when fn fails", func(t *testing.T) {
+		failed := errors.New("failed")
+		err := MemoryTransaction(ctx, mem, data, func(tx Memory) error {
+			data.counts["coffee"] = 10
+			data.counts["tea"] = 1
+			return failed
+		})
+		assert.ErrorIs(t, err, failed)
+		assert.Equal(t, map[string]int{"coffee": 2}, data.counts)
+	})
+
+	t.Run("refuses a done context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		err := MemoryTransaction(cancelled, mem, data, func(tx Memory) error { return nil })
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMemoryIDs(t *testing.T) {
+	ids := MemoryIDs{}
+	assert.Equal(t, uint(1), ids.Next("users"))
+	assert.Equal(t, uint(2), ids.Next("users"))
+	assert.Equal(t, uint(1), ids.Next("orders"))
+}
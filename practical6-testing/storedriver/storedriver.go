@@ -0,0 +1,100 @@
+// Package storedriver selects and opens the store a service keeps its data
+// in: Postgres through GORM in production, SQLite or an in-memory store for
+// development and tests.
+package storedriver
+
+import (
+	"context"
+	"envconfig"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Store drivers selectable with Config.Driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+// Config selects and locates the store.
+type Config struct {
+	// Driver is DriverPostgres, DriverSQLite or DriverMemory.
+	Driver string
+	// DSN is the Postgres connection string or SQLite file name. The
+	// memory driver ignores it.
+	DSN string
+}
+
+// FromEnv selects the store from STORE_DRIVER: postgres, configured by the
+// DB_* variables with dbName as the default database, sqlite, in the
+// SQLITE_PATH file defaulting to sqlitePath, or memory.
+func FromEnv(dbName, sqlitePath string) Config {
+	cfg := Config{Driver: envconfig.String("STORE_DRIVER", DriverPostgres)}
+	switch cfg.Driver {
+	case DriverPostgres:
+		cfg.DSN = "host=" + envconfig.String("DB_HOST", "localhost") + " port=" + envconfig.String("DB_PORT", "5432") +
+			" user=" + envconfig.String("DB_USER", "postgres") + " password=" + envconfig.String("DB_PASSWORD", "postgres") +
+			" dbname=" + envconfig.String("DB_NAME", dbName) + " sslmode=disable"
+	case DriverSQLite:
+		cfg.DSN = envconfig.String("SQLITE_PATH", sqlitePath)
+	}
+	return cfg
+}
+
+// ErrNotFound is returned by every store when no row matches. It is gorm's
+// error so that dberr.Status reports NotFound whichever driver is in use.
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// Store is what a service's store S provides on every driver besides its
+// own queries.
+type Store[S any] interface {
+	// Ping checks that the store can be reached.
+	Ping(ctx context.Context) error
+	// Close releases the connections of the store. It must not be used
+	// afterwards.
+	Close() error
+
+	// Transaction runs fn with a store whose changes are committed when fn
+	// returns nil and rolled back otherwise.
+	Transaction(ctx context.Context, fn func(tx S) error) error
+}
+
+// Backends builds a service's store S on each kind of driver.
+type Backends[S any] struct {
+	// Migrate creates or updates the tables of a GORM store.
+	Migrate func(db *gorm.DB) error
+	// NewGorm wraps a migrated database.
+	NewGorm func(db *gorm.DB) S
+	// NewMemory returns an empty in-memory store.
+	NewMemory func() S
+}
+
+// Open connects to the configured store and migrates it. Memory stores
+// start empty and are lost on restart.
+func Open[S any](cfg Config, backends Backends[S]) (S, error) {
+	var none S
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case DriverPostgres:
+		dialector = postgres.Open(cfg.DSN)
+	case DriverSQLite:
+		dialector = sqlite.Open(cfg.DSN)
+	case DriverMemory:
+		return backends.NewMemory(), nil
+	default:
+		return none, fmt.Errorf("unknown store driver %q", cfg.Driver)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return none, err
+	}
+	if err := backends.Migrate(db); err != nil {
+		return none, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return backends.NewGorm(db), nil
+}
@@ -0,0 +1,64 @@
+package storedriver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// testStore records which backend built it.
+type testStore struct {
+	db *gorm.DB
+}
+
+func testBackends(migrated *bool) Backends[*testStore] {
+	return Backends[*testStore]{
+		Migrate: func(db *gorm.DB) error {
+			*migrated = true
+			return nil
+		},
+		NewGorm:   func(db *gorm.DB) *testStore { return &testStore{db: db} },
+		NewMemory: func() *testStore { return &testStore{} },
+	}
+}
+
+func TestOpen(t *testing.T) {
+	var migrated bool
+	s, err := Open(Config{Driver: DriverMemory}, testBackends(&migrated))
+	require.NoError(t, err)
+	assert.Nil(t, s.db)
+	assert.False(t, migrated)
+
+	s, err = Open(Config{Driver: DriverSQLite, DSN: ":memory:"}, testBackends(&migrated))
+	require.NoError(t, err)
+	assert.NotNil(t, s.db)
+	assert.True(t, migrated)
+
+	_, err = Open(Config{Driver: "mysql"}, testBackends(&migrated))
+	assert.EqualError(t, err, `unknown store driver "mysql"`)
+}
+
+func TestOpen_MigrationFails(t *testing.T) {
+	backends := testBackends(new(bool))
+	backends.Migrate = func(db *gorm.DB) error { return errors.New("boom") }
+
+	_, err := Open(Config{Driver: DriverSQLite, DSN: ":memory:"}, backends)
+	assert.EqualError(t, err, "failed to migrate database: boom")
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("STORE_DRIVER", "")
+	t.Setenv("DB_NAME", "")
+	assert.Equal(t, "host=localhost port=5432 user=postgres password=postgres dbname=userdb sslmode=disable",
+		FromEnv("userdb", "users.db").DSN)
+
+	t.Setenv("STORE_DRIVER", DriverSQLite)
+	t.Setenv("SQLITE_PATH", "")
+	assert.Equal(t, Config{Driver: DriverSQLite, DSN: "users.db"}, FromEnv("userdb", "users.db"))
+
+	t.Setenv("STORE_DRIVER", DriverMemory)
+	assert.Equal(t, Config{Driver: DriverMemory}, FromEnv("userdb", "users.db"))
+}
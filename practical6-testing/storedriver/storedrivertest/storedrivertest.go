@@ -0,0 +1,61 @@
+// Package storedrivertest runs a service's store conformance suite against
+// every store driver.
+package storedrivertest
+
+import (
+	"fmt"
+	"os"
+	"storedriver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Run runs suite against memory and SQLite stores built by backends, and
+// against the Postgres database in TEST_POSTGRES_DSN when it is set. Each
+// Postgres store starts by dropping tables, which must list every table the
+// store creates.
+func Run[S any](t *testing.T, backends storedriver.Backends[S], suite func(t *testing.T, newStore func(t *testing.T) S), tables ...string) {
+	t.Run("Memory", func(t *testing.T) {
+		suite(t, func(t *testing.T) S {
+			return backends.NewMemory()
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		suite(t, func(t *testing.T) S {
+			dsn := fmt.Sprintf("file:store_%d?mode=memory&cache=shared", time.Now().UnixNano())
+			return openGorm(t, backends, sqlite.Open(dsn), nil)
+		})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		dsn := os.Getenv("TEST_POSTGRES_DSN")
+		if dsn == "" {
+			t.Skip("TEST_POSTGRES_DSN is not set")
+		}
+		suite(t, func(t *testing.T) S {
+			return openGorm(t, backends, postgres.Open(dsn), tables)
+		})
+	})
+}
+
+func openGorm[S any](t *testing.T, backends storedriver.Backends[S], dialector gorm.Dialector, tables []string) S {
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	require.NoError(t, err)
+	if len(tables) > 0 {
+		require.NoError(t, db.Exec("DROP TABLE IF EXISTS "+strings.Join(tables, ", ")).Error)
+	}
+	require.NoError(t, backends.Migrate(db))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return backends.NewGorm(db)
+}
@@ -0,0 +1,227 @@
+// Package harness runs the whole cafe in the test process for end-to-end
+// tests: user-service, menu-service and order-service on bufconn listeners,
+// each with its own SQLite database, and the API gateway on a loopback HTTP
+// port. The services are wired together the way their main packages wire
+// them, including authorization, validation and service credentials.
+//
+// Start boots a fresh cafe for one test and tears it down when the test
+// ends:
+//
+//	env := harness.Start(t)
+//	owner := env.RegisterOwner(t, "Owner", "owner@example.com", "password1")
+//	resp, err := env.Menu.CreateMenuItem(owner.Context(ctx), ...)
+package harness
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"api-gateway/gateway"
+	"authz"
+	menugrpc "menu-service/grpc"
+	menuv1 "menu-service/proto/menuv1"
+	menustore "menu-service/store"
+	"order-service/consistency"
+	"order-service/downstream"
+	ordergrpc "order-service/grpc"
+	"order-service/loyalty"
+	"order-service/outbox"
+	orderv1 "order-service/proto/orderv1"
+	orderstore "order-service/store"
+	usergrpc "user-service/grpc"
+	userv1 "user-service/proto/userv1"
+	userstore "user-service/store"
+	"validation"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const bufSize = 1024 * 1024
+
+// Env is a running cafe. Its clients call the services as an outside caller
+// would; use Session.Context to call as a logged-in user.
+type Env struct {
+	Keys *authz.KeySet
+
+	Users  userv1.UserServiceClient
+	Menu   menuv1.MenuServiceClient
+	Orders orderv1.OrderServiceClient
+
+	// GatewayURL is the base URL of the API gateway, e.g.
+	// "http://127.0.0.1:41234".
+	GatewayURL string
+
+	// Relay publishes order events from the outbox to Events and to the
+	// loyalty publisher. It does not run on its own; call Relay.Flush to
+	// deliver the events written so far.
+	Relay  *outbox.Relay
+	Events *outbox.MemoryPublisher
+	// Consumer applies user and menu item deletions to orders. Like Relay it
+	// only runs when Consumer.Poll is called.
+	Consumer *consistency.Consumer
+
+	userServer *usergrpc.UserServer
+}
+
+// Session is a logged-in user.
+type Session struct {
+	UserID uint32
+	Token  string
+}
+
+// Context returns ctx carrying the session's access token.
+func (s Session) Context(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.Token)
+}
+
+// Start boots the services for t and registers their teardown with
+// t.Cleanup.
+func Start(t testing.TB) *Env {
+	t.Helper()
+
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	serviceCreds := grpc.PerRPCCredentials(authz.NewServiceCredentials(keys, "order-service"))
+	dir := t.TempDir()
+
+	env := &Env{Keys: keys}
+
+	// User service
+	userDB := openDB(t, filepath.Join(dir, "users.db"))
+	require.NoError(t, userstore.Migrate(userDB))
+	env.userServer = usergrpc.NewUserServer(userstore.NewGormStore(userDB))
+	env.userServer.Keys = keys
+	userConn := serve(t, usergrpc.Policy, keys, func(s *grpc.Server) {
+		userv1.RegisterUserServiceServer(s, env.userServer)
+	})
+	env.Users = userv1.NewUserServiceClient(userConn)
+
+	// Menu service
+	menuDB := openDB(t, filepath.Join(dir, "menu.db"))
+	require.NoError(t, menustore.Migrate(menuDB))
+	menuConn := serve(t, menugrpc.Policy, keys, func(s *grpc.Server) {
+		menuv1.RegisterMenuServiceServer(s, menugrpc.NewMenuServer(menustore.NewGormStore(menuDB)))
+	})
+	env.Menu = menuv1.NewMenuServiceClient(menuConn)
+
+	// Order service, calling the real user and menu services
+	orderDB := openDB(t, filepath.Join(dir, "orders.db"))
+	require.NoError(t, orderstore.Migrate(orderDB))
+	orders := orderstore.NewGormStore(orderDB)
+	orderServer := ordergrpc.NewOrderServer(orders,
+		userv1.NewUserServiceClient(downstream.New("user-service").Conn(userConn)),
+		menuv1.NewMenuServiceClient(downstream.New("menu-service").Conn(menuConn)))
+	orderServer.ServiceCallOptions = []grpc.CallOption{serviceCreds}
+	orderConn := serve(t, ordergrpc.Policy, keys, func(s *grpc.Server) {
+		orderv1.RegisterOrderServiceServer(s, orderServer)
+	})
+	env.Orders = orderv1.NewOrderServiceClient(orderConn)
+
+	env.Events = outbox.NewMemoryPublisher()
+	env.Relay = outbox.NewRelay(orders, outbox.MultiPublisher{env.Events, loyalty.NewPublisher(env.Users, serviceCreds)})
+	env.Consumer = consistency.NewConsumer(orders, env.Users, env.Menu, serviceCreds)
+
+	// API gateway
+	gw := httptest.NewServer(gateway.New(env.Users, env.Menu, env.Orders))
+	t.Cleanup(gw.Close)
+	env.GatewayURL = gw.URL
+
+	return env
+}
+
+// Register signs up a customer and logs them in.
+func (e *Env) Register(t testing.TB, name, email, password string) Session {
+	t.Helper()
+
+	_, err := e.Users.CreateUser(context.Background(), &userv1.CreateUserRequest{
+		Name:     name,
+		Email:    email,
+		Password: password,
+	})
+	require.NoError(t, err)
+	return e.Login(t, email, password)
+}
+
+// RegisterOwner creates a cafe owner and logs them in. Only owners may
+// create owners, so the account is created directly on the server, as the
+// user service does for BOOTSTRAP_OWNER_EMAIL.
+func (e *Env) RegisterOwner(t testing.TB, name, email, password string) Session {
+	t.Helper()
+
+	_, err := e.userServer.CreateUser(context.Background(), &userv1.CreateUserRequest{
+		Name:        name,
+		Email:       email,
+		IsCafeOwner: true,
+		Password:    password,
+	})
+	require.NoError(t, err)
+	return e.Login(t, email, password)
+}
+
+// Login logs in an existing user.
+func (e *Env) Login(t testing.TB, email, password string) Session {
+	t.Helper()
+
+	resp, err := e.Users.Login(context.Background(), &userv1.LoginRequest{Email: email, Password: password})
+	require.NoError(t, err)
+	return Session{UserID: resp.User.Id, Token: resp.AccessToken}
+}
+
+// openDB opens a SQLite database in path that is closed when t ends.
+// Writers wait for each other rather than failing, as the services write
+// from several goroutines.
+func openDB(t testing.TB, path string) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(path+"?_busy_timeout=5000&_txlock=immediate"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// serve starts a gRPC server with the interceptors the services use in
+// production, registered by register, and returns a connection to it. Calls
+// on the connection forward the caller's token like the service clients in
+// order-service do.
+func serve(t testing.TB, policy authz.Policy, keys *authz.KeySet, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	authorizer := authz.NewAuthorizer(keys, policy)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor(), validation.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(authorizer.StreamServerInterceptor(), validation.StreamServerInterceptor),
+	)
+	register(server)
+
+	listener := bufconn.Listen(bufSize)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(authz.ForwardToken, validation.UnaryClientInterceptor))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		listener.Close()
+	})
+	return conn
+}
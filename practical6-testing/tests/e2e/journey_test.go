@@ -0,0 +1,274 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"e2e-tests/harness"
+	menuv1 "menu-service/proto/menuv1"
+	orderv1 "order-service/proto/orderv1"
+	userv1 "user-service/proto/userv1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stockOf returns a pointer for the optional stock field.
+func stockOf(n int64) *int64 { return &n }
+
+func TestCustomerJourney(t *testing.T) {
+	env := harness.Start(t)
+	ctx := context.Background()
+
+	// The owner stocks the menu
+	owner := env.RegisterOwner(t, "Owner", "owner@cafe.test", "owner-password")
+	latte, err := env.Menu.CreateMenuItem(owner.Context(ctx), &menuv1.CreateMenuItemRequest{
+		Name: "Latte", PriceCents: 450, Currency: "USD", Stock: stockOf(10),
+	})
+	require.NoError(t, err)
+	_, err = env.Menu.CreateMenuItem(owner.Context(ctx), &menuv1.CreateMenuItemRequest{
+		Name: "Croissant", PriceCents: 300, Currency: "USD",
+	})
+	require.NoError(t, err)
+
+	// A customer signs up and browses without logging in
+	customer := env.Register(t, "Ada", "ada@cafe.test", "ada-password")
+	menu, err := env.Menu.GetMenuItems(ctx, &menuv1.GetMenuItemsRequest{})
+	require.NoError(t, err)
+	require.Len(t, menu.MenuItems, 2)
+
+	// They order two lattes, which are taken off the stock
+	created, err := env.Orders.CreateOrder(customer.Context(ctx), &orderv1.CreateOrderRequest{
+		UserId: customer.UserID,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: latte.MenuItem.Id, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	order := created.Order
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_PENDING, order.State)
+	assert.Equal(t, int64(900), order.TotalCents)
+	item, err := env.Menu.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: latte.MenuItem.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), item.MenuItem.GetStock())
+
+	// Customers cannot move their own orders along
+	_, err = env.Orders.UpdateOrderStatus(customer.Context(ctx), &orderv1.UpdateOrderStatusRequest{
+		Id: order.Id, State: orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The owner takes the order through its lifecycle
+	for _, next := range []orderv1.OrderStatus{
+		orderv1.OrderStatus_ORDER_STATUS_CONFIRMED,
+		orderv1.OrderStatus_ORDER_STATUS_PREPARING,
+		orderv1.OrderStatus_ORDER_STATUS_READY,
+		orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+	} {
+		resp, err := env.Orders.UpdateOrderStatus(owner.Context(ctx), &orderv1.UpdateOrderStatusRequest{Id: order.Id, State: next})
+		require.NoError(t, err, next.String())
+		assert.Equal(t, next, resp.Order.State)
+	}
+
+	// The customer sees the completed order in their history
+	history, err := env.Orders.GetOrders(customer.Context(ctx), &orderv1.GetOrdersRequest{UserId: customer.UserID})
+	require.NoError(t, err)
+	require.Len(t, history.Orders, 1)
+	assert.Equal(t, order.Id, history.Orders[0].Id)
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_COMPLETED, history.Orders[0].State)
+
+	// Relaying the order events credits their loyalty points
+	_, err = env.Relay.Flush(ctx, time.Now())
+	require.NoError(t, err)
+	ledger, err := env.Users.GetPointsLedger(customer.Context(ctx), &userv1.GetPointsLedgerRequest{UserId: customer.UserID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), ledger.Balance)
+}
+
+func TestCustomersOnlySeeTheirOwnOrders(t *testing.T) {
+	env := harness.Start(t)
+	ctx := context.Background()
+
+	owner := env.RegisterOwner(t, "Owner", "owner@cafe.test", "owner-password")
+	tea, err := env.Menu.CreateMenuItem(owner.Context(ctx), &menuv1.CreateMenuItemRequest{Name: "Tea", PriceCents: 250})
+	require.NoError(t, err)
+
+	ada := env.Register(t, "Ada", "ada@cafe.test", "ada-password")
+	bob := env.Register(t, "Bob", "bob@cafe.test", "bob-password")
+	created, err := env.Orders.CreateOrder(ada.Context(ctx), &orderv1.CreateOrderRequest{
+		UserId: ada.UserID,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: tea.MenuItem.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	_, err = env.Orders.GetOrder(bob.Context(ctx), &orderv1.GetOrderRequest{Id: created.Order.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = env.Orders.GetOrders(bob.Context(ctx), &orderv1.GetOrdersRequest{UserId: ada.UserID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = env.Orders.CreateOrder(bob.Context(ctx), &orderv1.CreateOrderRequest{
+		UserId: ada.UserID,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: tea.MenuItem.Id, Quantity: 1}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestDeletedMenuItemsAreFlaggedOnOrders(t *testing.T) {
+	env := harness.Start(t)
+	ctx := context.Background()
+
+	owner := env.RegisterOwner(t, "Owner", "owner@cafe.test", "owner-password")
+	scone, err := env.Menu.CreateMenuItem(owner.Context(ctx), &menuv1.CreateMenuItemRequest{Name: "Scone", PriceCents: 325})
+	require.NoError(t, err)
+	customer := env.Register(t, "Ada", "ada@cafe.test", "ada-password")
+	created, err := env.Orders.CreateOrder(customer.Context(ctx), &orderv1.CreateOrderRequest{
+		UserId: customer.UserID,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: scone.MenuItem.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	_, err = env.Menu.DeleteMenuItem(owner.Context(ctx), &menuv1.DeleteMenuItemRequest{Id: scone.MenuItem.Id})
+	require.NoError(t, err)
+	applied, err := env.Consumer.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	resp, err := env.Orders.GetOrder(customer.Context(ctx), &orderv1.GetOrderRequest{Id: created.Order.Id})
+	require.NoError(t, err)
+	require.Len(t, resp.Order.OrderItems, 1)
+	assert.True(t, resp.Order.OrderItems[0].MenuItemDeleted)
+	assert.Equal(t, "Scone", resp.Order.OrderItems[0].MenuItemName)
+}
+
+// client calls the API gateway as a web client would.
+type client struct {
+	t     *testing.T
+	base  string
+	token string
+}
+
+// do sends body as JSON and decodes the JSON response into out, returning
+// the status code.
+func (c *client) do(method, path string, body any, out any) int {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.base+path, reader)
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(c.t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestCustomerJourneyOverHTTP(t *testing.T) {
+	env := harness.Start(t)
+	owner := env.RegisterOwner(t, "Owner", "owner@cafe.test", "owner-password")
+	ownerAPI := &client{t: t, base: env.GatewayURL, token: owner.Token}
+	anon := &client{t: t, base: env.GatewayURL}
+
+	// Owner adds an item
+	var item struct {
+		MenuItem struct {
+			ID uint32 `json:"id"`
+		} `json:"menuItem"`
+	}
+	require.Equal(t, http.StatusCreated, ownerAPI.do(http.MethodPost, "/api/menu",
+		map[string]any{"name": "Mocha", "priceCents": 500, "currency": "USD"}, &item))
+
+	// Customer signs up, logs in and browses
+	require.Equal(t, http.StatusCreated, anon.do(http.MethodPost, "/api/users",
+		map[string]any{"name": "Ada", "email": "ada@cafe.test", "password": "ada-password"}, nil))
+	var login struct {
+		AccessToken string `json:"accessToken"`
+		User        struct {
+			ID uint32 `json:"id"`
+		} `json:"user"`
+	}
+	require.Equal(t, http.StatusOK, anon.do(http.MethodPost, "/api/users/login",
+		map[string]any{"email": "ada@cafe.test", "password": "ada-password"}, &login))
+	customerAPI := &client{t: t, base: env.GatewayURL, token: login.AccessToken}
+
+	var menu struct {
+		MenuItems []struct {
+			Name string `json:"name"`
+		} `json:"menuItems"`
+	}
+	require.Equal(t, http.StatusOK, anon.do(http.MethodGet, "/api/menu?name_contains=moc", nil, &menu))
+	require.Len(t, menu.MenuItems, 1)
+	assert.Equal(t, "Mocha", menu.MenuItems[0].Name)
+
+	// Customer places an order
+	var placed struct {
+		Order struct {
+			ID         uint32 `json:"id"`
+			State      string `json:"state"`
+			TotalCents string `json:"totalCents"`
+		} `json:"order"`
+	}
+	require.Equal(t, http.StatusCreated, customerAPI.do(http.MethodPost, "/api/orders", map[string]any{
+		"userId": login.User.ID,
+		"items":  []map[string]any{{"menuItemId": item.MenuItem.ID, "quantity": 3}},
+	}, &placed))
+	assert.Equal(t, "ORDER_STATUS_PENDING", placed.Order.State)
+	assert.Equal(t, "1500", placed.Order.TotalCents)
+	orderPath := fmt.Sprintf("/api/orders/%d", placed.Order.ID)
+
+	// Only the owner advances it
+	var apiErr struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	require.Equal(t, http.StatusForbidden, customerAPI.do(http.MethodPatch, orderPath,
+		map[string]any{"state": "ORDER_STATUS_CONFIRMED"}, &apiErr))
+	assert.Equal(t, "PERMISSION_DENIED", apiErr.Error.Status)
+	for _, next := range []string{"ORDER_STATUS_CONFIRMED", "ORDER_STATUS_PREPARING", "ORDER_STATUS_READY"} {
+		require.Equal(t, http.StatusOK, ownerAPI.do(http.MethodPatch, orderPath, map[string]any{"state": next}, nil), next)
+	}
+
+	// An illegal transition is rejected
+	require.Equal(t, http.StatusBadRequest, ownerAPI.do(http.MethodPatch, orderPath,
+		map[string]any{"state": "ORDER_STATUS_PENDING"}, &apiErr))
+	assert.Equal(t, "FAILED_PRECONDITION", apiErr.Error.Status)
+
+	// Customer fetches their history
+	var history struct {
+		Orders []struct {
+			ID    uint32 `json:"id"`
+			State string `json:"state"`
+		} `json:"orders"`
+	}
+	require.Equal(t, http.StatusOK, customerAPI.do(http.MethodGet,
+		fmt.Sprintf("/api/orders?user_id=%d", login.User.ID), nil, &history))
+	require.Len(t, history.Orders, 1)
+	assert.Equal(t, placed.Order.ID, history.Orders[0].ID)
+	assert.Equal(t, "ORDER_STATUS_READY", history.Orders[0].State)
+
+	// Missing resources map to 404
+	require.Equal(t, http.StatusNotFound, customerAPI.do(http.MethodGet, "/api/orders/999", nil, &apiErr))
+	assert.Equal(t, "NOT_FOUND", apiErr.Error.Status)
+
+	// The gRPC API sees the same order
+	resp, err := env.Orders.GetOrder(owner.Context(context.Background()), &orderv1.GetOrderRequest{Id: placed.Order.ID})
+	require.NoError(t, err)
+	assert.Equal(t, orderv1.OrderStatus_ORDER_STATUS_READY, resp.Order.State)
+}
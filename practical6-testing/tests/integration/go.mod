@@ -8,6 +8,30 @@ replace menu-service => ../../menu-service
 
 replace order-service => ../../order-service
 
+replace authz => ../../authz
+
+replace dberr => ../../dberr
+
+replace validation => ../../validation
+
+replace prototime => ../../prototime
+
+replace healthcheck => ../../healthcheck
+
+replace graceful => ../../graceful
+
+replace pagination => ../../pagination
+
+replace eventseq => ../../eventseq
+
+replace fieldmask => ../../fieldmask
+
+replace softdelete => ../../softdelete
+
+replace storedriver => ../../storedriver
+
+replace envconfig => ../../envconfig
+
 require (
 	github.com/stretchr/testify v1.11.1
 	google.golang.org/grpc v1.77.0
@@ -15,10 +39,22 @@ require (
 	gorm.io/gorm v1.31.1
 	menu-service v0.0.0
 	user-service v0.0.0
+	validation v0.0.0
 )
 
 require (
+	authz v0.0.0 // indirect
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.10-20251209175733-2a1774d88802.1 // indirect
+	buf.build/go/protovalidate v1.1.0 // indirect
+	cel.dev/expr v0.24.0 // indirect
+	dberr v0.0.0 // indirect
+	envconfig v0.0.0 // indirect
+	eventseq v0.0.0 // indirect
+	fieldmask v0.0.0 // indirect
+	github.com/antlr4-go/antlr/v4 v4.13.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.1 // indirect
+	github.com/google/cel-go v0.26.1 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/pgx/v5 v5.6.0 // indirect
@@ -27,13 +63,20 @@ require (
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stoewer/go-strcase v1.3.1 // indirect
 	golang.org/x/crypto v0.43.0 // indirect
+	golang.org/x/exp v0.0.0-20250813145105-42675adae3e6 // indirect
 	golang.org/x/net v0.46.1-0.20251013234738-63d1a5100f82 // indirect
 	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20251022142026-3a174f9686a8 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gorm.io/driver/postgres v1.6.0 // indirect
+	pagination v0.0.0 // indirect
+	prototime v0.0.0 // indirect
+	softdelete v0.0.0 // indirect
+	storedriver v0.0.0 // indirect
 )
@@ -9,19 +9,21 @@ import (
 	"time"
 
 	usergrpc "user-service/grpc"
-	usermodels "user-service/models"
-	userdatabase "user-service/database"
 	userv1 "user-service/proto/userv1"
+	userstore "user-service/store"
 
 	menugrpc "menu-service/grpc"
-	menumodels "menu-service/models"
-	menudatabase "menu-service/database"
 	menuv1 "menu-service/proto/menuv1"
+	menustore "menu-service/store"
+
+	"validation"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -41,14 +43,12 @@ func setupUserService(t *testing.T) (*bufconn.Listener, *grpc.Server) {
 	db, err := gorm.Open(sqlite.Open(dbName), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&usermodels.User{})
+	err = userstore.Migrate(db)
 	require.NoError(t, err)
 
-	userdatabase.DB = db
-
 	listener := bufconn.Listen(bufSize)
-	server := grpc.NewServer()
-	userv1.RegisterUserServiceServer(server, usergrpc.NewUserServer())
+	server := grpc.NewServer(grpc.UnaryInterceptor(validation.UnaryServerInterceptor))
+	userv1.RegisterUserServiceServer(server, usergrpc.NewUserServer(userstore.NewGormStore(db)))
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -68,14 +68,12 @@ func setupMenuService(t *testing.T) (*bufconn.Listener, *grpc.Server) {
 	db, err := gorm.Open(sqlite.Open(dbName), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&menumodels.MenuItem{})
+	err = menustore.Migrate(db)
 	require.NoError(t, err)
 
-	menudatabase.DB = db
-
 	listener := bufconn.Listen(bufSize)
-	server := grpc.NewServer()
-	menuv1.RegisterMenuServiceServer(server, menugrpc.NewMenuServer())
+	server := grpc.NewServer(grpc.UnaryInterceptor(validation.UnaryServerInterceptor))
+	menuv1.RegisterMenuServiceServer(server, menugrpc.NewMenuServer(menustore.NewGormStore(db)))
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -145,6 +143,54 @@ func TestIntegration_CreateMenuItem(t *testing.T) {
 	assert.InDelta(t, 2.50, resp.MenuItem.Price, 0.001)
 }
 
+func TestIntegration_ValidationErrors(t *testing.T) {
+	userListener, userServer := setupUserService(t)
+	menuListener, menuServer := setupMenuService(t)
+	defer func() {
+		userServer.Stop()
+		userListener.Close()
+		menuServer.Stop()
+		menuListener.Close()
+	}()
+
+	ctx := context.Background()
+	userConn, err := grpc.DialContext(ctx, "user-bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "menu-bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	fields := func(err error) []string {
+		var names []string
+		for _, v := range validation.FieldViolations(err) {
+			names = append(names, v.Field)
+		}
+		return names
+	}
+
+	_, err = userv1.NewUserServiceClient(userConn).CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "",
+		Email: "not-an-email",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"name", "email"}, fields(err))
+
+	_, err = menuv1.NewMenuServiceClient(menuConn).CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:       "Refund",
+		PriceCents: -100,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"price_cents"}, fields(err))
+}
+
 func TestIntegration_UserAndMenuServices(t *testing.T) {
 	userListener, userServer := setupUserService(t)
 	defer func() {
@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"authz"
+	"dberr"
+	"user-service/models"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Defaults for UserServer.AccessTokenTTL and RefreshTokenTTL.
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	minPasswordLength = 8
+	// bcrypt ignores everything after the first 72 bytes
+	maxPasswordLength = 72
+)
+
+// errInvalidCredentials is returned for every failed login so that callers
+// cannot tell which emails are registered.
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid email or password")
+
+// dummyHash is compared against when no user matches, so that failed logins
+// take as long whether or not the email exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+
+func (s *UserServer) Login(ctx context.Context, req *userv1.LoginRequest) (*userv1.LoginResponse, error) {
+	user, err := s.store.FindUserByEmail(ctx, normalizeEmail(req.Email))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, dberr.Status(err, "user")
+	}
+
+	hash := []byte(user.PasswordHash)
+	if user.PasswordHash == "" {
+		hash = dummyHash
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(req.Password)) != nil || user.PasswordHash == "" {
+		return nil, errInvalidCredentials
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(ctx, s.store, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.LoginResponse{
+		AccessToken:  accessToken,
+		ExpiresIn:    int64(s.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		User:         toProtoUser(user),
+	}, nil
+}
+
+func (s *UserServer) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest) (*userv1.RefreshTokenResponse, error) {
+	var accessToken, refreshToken string
+	err := s.store.Transaction(ctx, func(tx store.UserStore) error {
+		// Revoking the old token first means a token can be used only once
+		// even by concurrent requests
+		hash := authz.HashRefreshToken(req.RefreshToken)
+		revoked, err := tx.RevokeRefreshToken(ctx, hash, time.Now())
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return store.ErrNotFound
+		}
+		stored, err := tx.FindRefreshToken(ctx, hash)
+		if err != nil {
+			return err
+		}
+
+		user, err := tx.GetUser(ctx, stored.UserID)
+		if err != nil {
+			return err
+		}
+
+		accessToken, refreshToken, err = s.issueTokens(ctx, tx, user)
+		return err
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token is invalid, expired or revoked")
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "refresh token")
+	}
+
+	return &userv1.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		ExpiresIn:    int64(s.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (s *UserServer) Logout(ctx context.Context, req *userv1.LogoutRequest) (*userv1.LogoutResponse, error) {
+	if _, err := s.store.RevokeRefreshToken(ctx, authz.HashRefreshToken(req.RefreshToken), time.Now()); err != nil {
+		return nil, dberr.Status(err, "refresh token")
+	}
+
+	return &userv1.LogoutResponse{}, nil
+}
+
+// issueTokens signs an access token for user and saves a new refresh token
+// in st.
+func (s *UserServer) issueTokens(ctx context.Context, st store.UserStore, user models.User) (accessToken, refreshToken string, err error) {
+	if s.Keys == nil {
+		return "", "", status.Errorf(codes.FailedPrecondition, "token signing is not configured")
+	}
+
+	now := time.Now()
+	accessToken, err = authz.IssueAccessToken(s.Keys, user.ID, user.IsCafeOwner, now, s.AccessTokenTTL)
+	if err != nil {
+		return "", "", status.Errorf(codes.Internal, "failed to sign access token: %v", err)
+	}
+
+	refreshToken, hash, err := authz.NewRefreshToken()
+	if err != nil {
+		return "", "", status.Errorf(codes.Internal, "failed to create refresh token: %v", err)
+	}
+	if err := st.CreateRefreshToken(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(s.RefreshTokenTTL),
+	}); err != nil {
+		return "", "", dberr.Status(err, "refresh token")
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return "", status.Errorf(codes.InvalidArgument, "password must be at most %d bytes", maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+	return string(hash), nil
+}
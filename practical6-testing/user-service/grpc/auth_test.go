@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"authz"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, code, st.Code())
+}
+
+func TestPasswordValidation(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ann", Email: "ann@example.com", Password: "short"})
+	requireCode(t, err, codes.InvalidArgument)
+
+	created, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ann", Email: "ann@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	var hash string
+	require.NoError(t, db.Table("users").Where("id = ?", created.User.Id).Pluck("password_hash", &hash).Error)
+	assert.NotEmpty(t, hash)
+	assert.NotContains(t, hash, "correct horse")
+}
+
+func TestLoginRefreshLogout(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	server := NewUserServer(store.NewGormStore(db))
+	server.Keys = keys
+	ctx := context.Background()
+
+	owner, err := server.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:        "Olive",
+		Email:       "olive@example.com",
+		IsCafeOwner: true,
+		Password:    "correct horse",
+	})
+	require.NoError(t, err)
+	_, err = server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Nopass", Email: "nopass@example.com"})
+	require.NoError(t, err)
+
+	t.Run("bad credentials", func(t *testing.T) {
+		_, err := server.Login(ctx, &userv1.LoginRequest{Email: "olive@example.com", Password: "wrong horse"})
+		requireCode(t, err, codes.Unauthenticated)
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "nobody@example.com", Password: "correct horse"})
+		requireCode(t, err, codes.Unauthenticated)
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "nopass@example.com", Password: ""})
+		requireCode(t, err, codes.Unauthenticated)
+	})
+
+	login, err := server.Login(ctx, &userv1.LoginRequest{Email: "Olive@Example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, owner.User.Id, login.User.Id)
+	assert.Equal(t, int64(DefaultAccessTokenTTL.Seconds()), login.ExpiresIn)
+
+	claims, err := authz.VerifyAccessToken(keys, login.AccessToken)
+	require.NoError(t, err)
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, owner.User.Id, userID)
+	assert.True(t, claims.CafeOwner)
+
+	t.Run("refresh rotates the token", func(t *testing.T) {
+		refreshed, err := server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+		require.NoError(t, err)
+		assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+		_, err = authz.VerifyAccessToken(keys, refreshed.AccessToken)
+		require.NoError(t, err)
+
+		// The old refresh token was used up
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+		requireCode(t, err, codes.Unauthenticated)
+
+		_, err = server.Logout(ctx, &userv1.LogoutRequest{RefreshToken: refreshed.RefreshToken})
+		require.NoError(t, err)
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken})
+		requireCode(t, err, codes.Unauthenticated)
+
+		// Logging out twice is harmless
+		_, err = server.Logout(ctx, &userv1.LogoutRequest{RefreshToken: refreshed.RefreshToken})
+		require.NoError(t, err)
+	})
+
+	t.Run("password change", func(t *testing.T) {
+		_, err := server.UpdateUser(ctx, &userv1.UpdateUserRequest{
+			Id:          owner.User.Id,
+			Name:        "Olive",
+			Email:       "olive@example.com",
+			IsCafeOwner: true,
+			Password:    "battery staple",
+		})
+		require.NoError(t, err)
+
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "olive@example.com", Password: "correct horse"})
+		requireCode(t, err, codes.Unauthenticated)
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "olive@example.com", Password: "battery staple"})
+		require.NoError(t, err)
+	})
+
+	t.Run("without signing keys", func(t *testing.T) {
+		_, err := NewUserServer(server.store).Login(ctx, &userv1.LoginRequest{Email: "olive@example.com", Password: "battery staple"})
+		requireCode(t, err, codes.FailedPrecondition)
+	})
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"prototime"
+	"softdelete"
+	"user-service/models"
+	userv1 "user-service/proto/userv1"
+)
+
+// userEventTypes maps the outbox event types onto their proto enum.
+var userEventTypes = map[string]userv1.UserEventType{
+	models.EventUserDeleted:  userv1.UserEventType_USER_EVENT_TYPE_DELETED,
+	models.EventUserRestored: userv1.UserEventType_USER_EVENT_TYPE_RESTORED,
+	models.EventUserPurged:   userv1.UserEventType_USER_EVENT_TYPE_PURGED,
+}
+
+// ListUserEvents returns the outbox events after req.AfterSequence in
+// sequence order.
+func (s *UserServer) ListUserEvents(ctx context.Context, req *userv1.ListUserEventsRequest) (*userv1.ListUserEventsResponse, error) {
+	events, err := softdelete.ListEvents(ctx, req, "user events", s.store.ListEvents, toProtoUserEvent)
+	if err != nil {
+		return nil, err
+	}
+	return &userv1.ListUserEventsResponse{Events: events}, nil
+}
+
+func toProtoUserEvent(event models.OutboxEvent) *userv1.UserEvent {
+	return &userv1.UserEvent{
+		Sequence:   event.Sequence,
+		Type:       userEventTypes[event.Type],
+		UserId:     uint32(event.UserID),
+		OccurredAt: prototime.Legacy(event.CreatedAt),
+		EventTime:  prototime.Timestamp(event.CreatedAt),
+	}
+}
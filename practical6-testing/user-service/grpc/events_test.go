@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	ada, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"})
+	require.NoError(t, err)
+	id := ada.User.Id
+
+	_, err = server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.RestoreUser(ctx, &userv1.RestoreUserRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: id})
+	require.NoError(t, err)
+	_, err = server.store.PurgeDeletedUsers(ctx, time.Now())
+	require.NoError(t, err)
+
+	resp, err := server.ListUserEvents(ctx, &userv1.ListUserEventsRequest{})
+	require.NoError(t, err)
+	var types []userv1.UserEventType
+	for _, event := range resp.Events {
+		assert.Equal(t, id, event.UserId)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []userv1.UserEventType{
+		userv1.UserEventType_USER_EVENT_TYPE_DELETED,
+		userv1.UserEventType_USER_EVENT_TYPE_RESTORED,
+		userv1.UserEventType_USER_EVENT_TYPE_DELETED,
+		userv1.UserEventType_USER_EVENT_TYPE_PURGED,
+	}, types)
+
+	t.Run("resumes after a sequence", func(t *testing.T) {
+		next, err := server.ListUserEvents(ctx, &userv1.ListUserEventsRequest{AfterSequence: resp.Events[1].Sequence, PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, next.Events, 1)
+		assert.Equal(t, resp.Events[2].Sequence, next.Events[0].Sequence)
+	})
+
+	t.Run("failed delete records nothing", func(t *testing.T) {
+		_, err := server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: 9999})
+		require.Error(t, err)
+
+		after, err := server.ListUserEvents(ctx, &userv1.ListUserEventsRequest{AfterSequence: resp.Events[3].Sequence})
+		require.NoError(t, err)
+		assert.Empty(t, after.Events)
+	})
+}
@@ -0,0 +1,214 @@
+package grpc
+
+import (
+	"context"
+	"dberr"
+	"errors"
+	"pagination"
+	"prototime"
+	"user-service/models"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pointsReasons maps the ledger entry reasons onto their proto enum.
+var pointsReasons = map[string]userv1.PointsReason{
+	models.PointsEarned:   userv1.PointsReason_POINTS_REASON_EARNED,
+	models.PointsRedeemed: userv1.PointsReason_POINTS_REASON_REDEEMED,
+	models.PointsReversed: userv1.PointsReason_POINTS_REASON_REVERSED,
+}
+
+func (s *UserServer) GetPointsLedger(ctx context.Context, req *userv1.GetPointsLedgerRequest) (*userv1.GetPointsLedgerResponse, error) {
+	user, err := s.store.GetUser(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.store.ListPointsEntries(ctx, user.ID, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "points entries")
+	}
+	entries, nextToken := pagination.Trim(page, entries, func(e models.PointsEntry) uint { return e.ID })
+
+	resp := &userv1.GetPointsLedgerResponse{NextPageToken: nextToken, Balance: user.PointsBalance}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toProtoPointsEntry(entry))
+	}
+	return resp, nil
+}
+
+func (s *UserServer) RedeemPoints(ctx context.Context, req *userv1.RedeemPointsRequest) (*userv1.RedeemPointsResponse, error) {
+	settings, err := s.store.LoyaltySettings(ctx)
+	if err != nil {
+		return nil, dberr.Status(err, "loyalty settings")
+	}
+	// Compared by division so that a huge number of points cannot overflow
+	if req.Points > req.MaxDiscountCents/settings.PointValueCents {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"%d points are worth more than the largest discount of %d", req.Points, req.MaxDiscountCents)
+	}
+
+	orderID := uint(req.OrderId)
+	entry := models.PointsEntry{
+		UserID:  uint(req.UserId),
+		Delta:   -req.Points,
+		Reason:  models.PointsRedeemed,
+		OrderID: &orderID,
+	}
+	err = s.store.Transaction(ctx, func(tx store.UserStore) error {
+		if _, err := tx.GetUser(ctx, uint(req.UserId)); err != nil {
+			return err
+		}
+		if used, err := tx.OrderPointsEntry(ctx, entry.UserID, orderID, models.PointsRedeemed); err != nil {
+			return err
+		} else if used != nil {
+			return status.Errorf(codes.AlreadyExists, "order %d has already redeemed points", req.OrderId)
+		}
+		return tx.AppendPoints(ctx, &entry)
+	})
+	if errors.Is(err, store.ErrInsufficientPoints) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %d does not have %d points", req.UserId, req.Points)
+	}
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	return &userv1.RedeemPointsResponse{
+		Entry:         toProtoPointsEntry(entry),
+		DiscountCents: req.Points * settings.PointValueCents,
+	}, nil
+}
+
+func (s *UserServer) CreditOrderPoints(ctx context.Context, req *userv1.CreditOrderPointsRequest) (*userv1.CreditOrderPointsResponse, error) {
+	userID, orderID := uint(req.UserId), uint(req.OrderId)
+	var credited *models.PointsEntry
+	err := s.store.Transaction(ctx, func(tx store.UserStore) error {
+		// Repeated deliveries return the original credit, and an order that
+		// has been reversed must not earn again
+		earned, err := tx.OrderPointsEntry(ctx, userID, orderID, models.PointsEarned)
+		if err != nil || earned != nil {
+			credited = earned
+			return err
+		}
+		if reversed, err := tx.OrderPointsEntry(ctx, userID, orderID, models.PointsReversed); err != nil || reversed != nil {
+			return err
+		}
+
+		settings, err := tx.LoyaltySettings(ctx)
+		if err != nil {
+			return err
+		}
+		points := req.AmountCents * settings.PointsPerCurrencyUnit / 100
+		if points == 0 {
+			return nil
+		}
+
+		credited = &models.PointsEntry{
+			UserID:  userID,
+			Delta:   points,
+			Reason:  models.PointsEarned,
+			OrderID: &orderID,
+		}
+		return tx.AppendPoints(ctx, credited)
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	resp := &userv1.CreditOrderPointsResponse{}
+	if credited != nil {
+		resp.Entry = toProtoPointsEntry(*credited)
+	}
+	return resp, nil
+}
+
+func (s *UserServer) ReverseOrderPoints(ctx context.Context, req *userv1.ReverseOrderPointsRequest) (*userv1.ReverseOrderPointsResponse, error) {
+	userID, orderID := uint(req.UserId), uint(req.OrderId)
+	var reversal *models.PointsEntry
+	err := s.store.Transaction(ctx, func(tx store.UserStore) error {
+		reversed, err := tx.OrderPointsEntry(ctx, userID, orderID, models.PointsReversed)
+		if err != nil || reversed != nil {
+			reversal = reversed
+			return err
+		}
+
+		entries, err := tx.ListOrderPointsEntries(ctx, userID, orderID)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		reversal = &models.PointsEntry{
+			UserID:  userID,
+			Reason:  models.PointsReversed,
+			OrderID: &orderID,
+		}
+		for _, entry := range entries {
+			reversal.Delta -= entry.Delta
+		}
+		return tx.AppendPoints(ctx, reversal)
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	resp := &userv1.ReverseOrderPointsResponse{}
+	if reversal != nil {
+		resp.Entry = toProtoPointsEntry(*reversal)
+	}
+	return resp, nil
+}
+
+func (s *UserServer) GetLoyaltySettings(ctx context.Context, req *userv1.GetLoyaltySettingsRequest) (*userv1.GetLoyaltySettingsResponse, error) {
+	settings, err := s.store.LoyaltySettings(ctx)
+	if err != nil {
+		return nil, dberr.Status(err, "loyalty settings")
+	}
+	return &userv1.GetLoyaltySettingsResponse{Settings: toProtoLoyaltySettings(settings)}, nil
+}
+
+func (s *UserServer) UpdateLoyaltySettings(ctx context.Context, req *userv1.UpdateLoyaltySettingsRequest) (*userv1.UpdateLoyaltySettingsResponse, error) {
+	settings := models.LoyaltySettings{
+		PointsPerCurrencyUnit: req.Settings.PointsPerCurrencyUnit,
+		PointValueCents:       req.Settings.PointValueCents,
+	}
+	if err := s.store.SaveLoyaltySettings(ctx, &settings); err != nil {
+		return nil, dberr.Status(err, "loyalty settings")
+	}
+	return &userv1.UpdateLoyaltySettingsResponse{Settings: toProtoLoyaltySettings(settings)}, nil
+}
+
+func toProtoPointsEntry(entry models.PointsEntry) *userv1.PointsEntry {
+	pbEntry := &userv1.PointsEntry{
+		Id:           uint64(entry.ID),
+		UserId:       uint32(entry.UserID),
+		Delta:        entry.Delta,
+		Reason:       pointsReasons[entry.Reason],
+		BalanceAfter: entry.BalanceAfter,
+		CreatedAt:    prototime.Legacy(entry.CreatedAt),
+		CreateTime:   prototime.Timestamp(entry.CreatedAt),
+	}
+	if entry.OrderID != nil {
+		pbEntry.OrderId = uint32(*entry.OrderID)
+	}
+	return pbEntry
+}
+
+func toProtoLoyaltySettings(settings models.LoyaltySettings) *userv1.LoyaltySettings {
+	return &userv1.LoyaltySettings{
+		PointsPerCurrencyUnit: settings.PointsPerCurrencyUnit,
+		PointValueCents:       settings.PointValueCents,
+		UpdatedAt:             prototime.Legacy(settings.UpdatedAt),
+		UpdateTime:            prototime.Timestamp(settings.UpdatedAt),
+	}
+}
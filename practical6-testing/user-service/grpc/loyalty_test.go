@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoyaltyPoints(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	ada, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"})
+	require.NoError(t, err)
+	id := ada.User.Id
+
+	balance := func() int64 {
+		resp, err := server.GetUser(ctx, &userv1.GetUserRequest{Id: id})
+		require.NoError(t, err)
+		return resp.User.PointsBalance
+	}
+
+	_, err = server.UpdateLoyaltySettings(ctx, &userv1.UpdateLoyaltySettingsRequest{
+		Settings: &userv1.LoyaltySettings{PointsPerCurrencyUnit: 10, PointValueCents: 5},
+	})
+	require.NoError(t, err)
+
+	t.Run("completed order earns points once", func(t *testing.T) {
+		req := &userv1.CreditOrderPointsRequest{UserId: id, OrderId: 1, AmountCents: 1250}
+		first, err := server.CreditOrderPoints(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(125), first.Entry.Delta)
+		assert.Equal(t, int64(125), first.Entry.BalanceAfter)
+
+		again, err := server.CreditOrderPoints(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, first.Entry.Id, again.Entry.Id)
+		assert.Equal(t, int64(125), balance())
+	})
+
+	t.Run("redeem points for a discount", func(t *testing.T) {
+		resp, err := server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: id, OrderId: 2, Points: 100, MaxDiscountCents: 800})
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), resp.DiscountCents)
+		assert.Equal(t, int64(25), resp.Entry.BalanceAfter)
+
+		_, err = server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: id, OrderId: 2, Points: 1, MaxDiscountCents: 800})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("another customer's order ID does not block a redemption", func(t *testing.T) {
+		bob, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Bob", Email: "bob@example.com"})
+		require.NoError(t, err)
+		_, err = server.CreditOrderPoints(ctx, &userv1.CreditOrderPointsRequest{UserId: bob.User.Id, OrderId: 6, AmountCents: 100})
+		require.NoError(t, err)
+		_, err = server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: bob.User.Id, OrderId: 2, Points: 1, MaxDiscountCents: 800})
+		require.NoError(t, err)
+
+		// Reversing Bob's redemption leaves Ada's entries for order 2 alone
+		resp, err := server.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: 2, UserId: bob.User.Id})
+		require.NoError(t, err)
+		assert.Equal(t, bob.User.Id, resp.Entry.UserId)
+		assert.Equal(t, int64(1), resp.Entry.Delta)
+		assert.Equal(t, int64(25), balance())
+	})
+
+	t.Run("cannot redeem more than the balance", func(t *testing.T) {
+		_, err := server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: id, OrderId: 3, Points: 26, MaxDiscountCents: 800})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, int64(25), balance())
+	})
+
+	t.Run("discount may not exceed the order", func(t *testing.T) {
+		_, err := server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: id, OrderId: 3, Points: 20, MaxDiscountCents: 99})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("reversal returns redeemed points once", func(t *testing.T) {
+		resp, err := server.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: 2, UserId: id})
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), resp.Entry.Delta)
+
+		_, err = server.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: 2, UserId: id})
+		require.NoError(t, err)
+		assert.Equal(t, int64(125), balance())
+	})
+
+	t.Run("reversing spent points goes negative", func(t *testing.T) {
+		_, err := server.RedeemPoints(ctx, &userv1.RedeemPointsRequest{UserId: id, OrderId: 4, Points: 125, MaxDiscountCents: 1000})
+		require.NoError(t, err)
+
+		resp, err := server.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: 1, UserId: id})
+		require.NoError(t, err)
+		assert.Equal(t, int64(-125), resp.Entry.BalanceAfter)
+	})
+
+	t.Run("crediting a reversed order changes nothing", func(t *testing.T) {
+		_, err := server.CreditOrderPoints(ctx, &userv1.CreditOrderPointsRequest{UserId: id, OrderId: 1, AmountCents: 1250})
+		require.NoError(t, err)
+		assert.Equal(t, int64(-125), balance())
+	})
+
+	t.Run("order without points has nothing to reverse", func(t *testing.T) {
+		resp, err := server.ReverseOrderPoints(ctx, &userv1.ReverseOrderPointsRequest{OrderId: 99, UserId: id})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Entry)
+	})
+
+	t.Run("ledger lists every entry", func(t *testing.T) {
+		var reasons []userv1.PointsReason
+		token := ""
+		for {
+			resp, err := server.GetPointsLedger(ctx, &userv1.GetPointsLedgerRequest{UserId: id, PageSize: 2, PageToken: token})
+			require.NoError(t, err)
+			assert.Equal(t, int64(-125), resp.Balance)
+			for _, entry := range resp.Entries {
+				reasons = append(reasons, entry.Reason)
+			}
+			if token = resp.NextPageToken; token == "" {
+				break
+			}
+		}
+		assert.Equal(t, []userv1.PointsReason{
+			userv1.PointsReason_POINTS_REASON_EARNED,
+			userv1.PointsReason_POINTS_REASON_REDEEMED,
+			userv1.PointsReason_POINTS_REASON_REVERSED,
+			userv1.PointsReason_POINTS_REASON_REDEEMED,
+			userv1.PointsReason_POINTS_REASON_REVERSED,
+		}, reasons)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := server.CreditOrderPoints(ctx, &userv1.CreditOrderPointsRequest{UserId: 999, OrderId: 5, AmountCents: 1000})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestLoyaltySettings_Defaults(t *testing.T) {
+	resp, err := NewUserServer(store.NewMemoryStore()).GetLoyaltySettings(context.Background(), &userv1.GetLoyaltySettingsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Settings.PointsPerCurrencyUnit)
+	assert.Equal(t, int64(1), resp.Settings.PointValueCents)
+	assert.Nil(t, resp.Settings.UpdateTime)
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"authz"
+	"slices"
+	userv1 "user-service/proto/userv1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy is the authorization policy of the user service. Anyone may sign
+// up and log in; users may read and update only themselves, and only cafe
+// owners may list, delete, restore or purge users or grant the cafe owner
+// role. Users may read their own points ledger, and only cafe owners may
+// change the loyalty rates. The event feed and redeeming, crediting or
+// reversing order points are only for other cafe services, since
+// order-service is what ties points to an order the customer placed.
+var Policy = authz.Policy{
+	userv1.UserService_CreateUser_FullMethodName: {Access: authz.Public, Check: onlyOwnersGrantOwner},
+	userv1.UserService_GetUser_FullMethodName: {
+		Access: authz.Authenticated,
+		Check:  authz.SelfOrOwner(func(req *userv1.GetUserRequest) uint32 { return req.Id }),
+	},
+	userv1.UserService_GetUsers_FullMethodName: {Access: authz.Owner},
+	userv1.UserService_UpdateUser_FullMethodName: {
+		Access: authz.Authenticated,
+		Check: func(claims *authz.Claims, req any) error {
+			update := req.(*userv1.UpdateUserRequest)
+			if err := authz.CheckSelfOrOwner(claims, update.Id); err != nil {
+				return err
+			}
+			return onlyOwnersGrantOwner(claims, req)
+		},
+	},
+	userv1.UserService_DeleteUser_FullMethodName:       {Access: authz.Owner},
+	userv1.UserService_Login_FullMethodName:            {Access: authz.Public},
+	userv1.UserService_RefreshToken_FullMethodName:     {Access: authz.Public},
+	userv1.UserService_Logout_FullMethodName:           {Access: authz.Public},
+	userv1.UserService_ListDeletedUsers_FullMethodName: {Access: authz.Owner},
+	userv1.UserService_RestoreUser_FullMethodName:      {Access: authz.Owner},
+	userv1.UserService_PurgeUser_FullMethodName:        {Access: authz.Owner},
+	userv1.UserService_ListUserEvents_FullMethodName:   {Access: authz.Service},
+	userv1.UserService_GetPointsLedger_FullMethodName: {
+		Access: authz.Authenticated,
+		Check:  authz.SelfOrOwner(func(req *userv1.GetPointsLedgerRequest) uint32 { return req.UserId }),
+	},
+	userv1.UserService_RedeemPoints_FullMethodName:          {Access: authz.Service},
+	userv1.UserService_CreditOrderPoints_FullMethodName:     {Access: authz.Service},
+	userv1.UserService_ReverseOrderPoints_FullMethodName:    {Access: authz.Service},
+	userv1.UserService_GetLoyaltySettings_FullMethodName:    {Access: authz.Authenticated},
+	userv1.UserService_UpdateLoyaltySettings_FullMethodName: {Access: authz.Owner},
+}
+
+// onlyOwnersGrantOwner stops anyone but a cafe owner from creating or
+// updating a user into a cafe owner.
+func onlyOwnersGrantOwner(claims *authz.Claims, req any) error {
+	grantsOwner := false
+	switch r := req.(type) {
+	case *userv1.CreateUserRequest:
+		grantsOwner = r.IsCafeOwner
+	case *userv1.UpdateUserRequest:
+		paths := r.GetUpdateMask().GetPaths()
+		grantsOwner = r.IsCafeOwner && (len(paths) == 0 || slices.Contains(paths, "is_cafe_owner"))
+	}
+	if grantsOwner && (claims == nil || !claims.CafeOwner) {
+		return status.Error(codes.PermissionDenied, "only cafe owners may grant the cafe owner role")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"authz"
+	"context"
+	"testing"
+	"time"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestPolicy(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	keys, err := authz.NewEphemeralKeySet()
+	require.NoError(t, err)
+	server := NewUserServer(store.NewGormStore(db))
+	interceptor := authz.NewAuthorizer(keys, Policy).UnaryServerInterceptor()
+
+	owner, err := server.CreateUser(context.Background(), &userv1.CreateUserRequest{Name: "Olive", Email: "olive@example.com", IsCafeOwner: true})
+	require.NoError(t, err)
+	customer, err := server.CreateUser(context.Background(), &userv1.CreateUserRequest{Name: "Carl", Email: "carl@example.com"})
+	require.NoError(t, err)
+
+	tokenFor := func(user *userv1.User) string {
+		token, err := authz.IssueAccessToken(keys, uint(user.Id), user.IsCafeOwner, time.Now(), time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+	ownerToken, customerToken := tokenFor(owner.User), tokenFor(customer.User)
+	serviceToken, err := authz.IssueServiceToken(keys, "order-service", time.Now(), time.Minute)
+	require.NoError(t, err)
+
+	call := func(token, method string, req any, handler grpc.UnaryHandler) codes.Code {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+	getUser := func(ctx context.Context, req any) (any, error) {
+		return server.GetUser(ctx, req.(*userv1.GetUserRequest))
+	}
+	updateUser := func(ctx context.Context, req any) (any, error) {
+		return server.UpdateUser(ctx, req.(*userv1.UpdateUserRequest))
+	}
+	createUser := func(ctx context.Context, req any) (any, error) {
+		return server.CreateUser(ctx, req.(*userv1.CreateUserRequest))
+	}
+	deleteUser := func(ctx context.Context, req any) (any, error) {
+		return server.DeleteUser(ctx, req.(*userv1.DeleteUserRequest))
+	}
+	getLedger := func(ctx context.Context, req any) (any, error) {
+		return server.GetPointsLedger(ctx, req.(*userv1.GetPointsLedgerRequest))
+	}
+	redeemPoints := func(ctx context.Context, req any) (any, error) {
+		return server.RedeemPoints(ctx, req.(*userv1.RedeemPointsRequest))
+	}
+	creditPoints := func(ctx context.Context, req any) (any, error) {
+		return server.CreditOrderPoints(ctx, req.(*userv1.CreditOrderPointsRequest))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		method  string
+		req     any
+		handler grpc.UnaryHandler
+		want    codes.Code
+	}{
+		{"anonymous sign up", "", userv1.UserService_CreateUser_FullMethodName,
+			&userv1.CreateUserRequest{Name: "Ann", Email: "ann@example.com"}, createUser, codes.OK},
+		{"anonymous owner sign up", "", userv1.UserService_CreateUser_FullMethodName,
+			&userv1.CreateUserRequest{Name: "Eve", Email: "eve@example.com", IsCafeOwner: true}, createUser, codes.PermissionDenied},
+		{"owner creates owner", ownerToken, userv1.UserService_CreateUser_FullMethodName,
+			&userv1.CreateUserRequest{Name: "Otto", Email: "otto@example.com", IsCafeOwner: true}, createUser, codes.OK},
+		{"anonymous read", "", userv1.UserService_GetUser_FullMethodName,
+			&userv1.GetUserRequest{Id: customer.User.Id}, getUser, codes.Unauthenticated},
+		{"customer reads self", customerToken, userv1.UserService_GetUser_FullMethodName,
+			&userv1.GetUserRequest{Id: customer.User.Id}, getUser, codes.OK},
+		{"customer reads owner", customerToken, userv1.UserService_GetUser_FullMethodName,
+			&userv1.GetUserRequest{Id: owner.User.Id}, getUser, codes.PermissionDenied},
+		{"owner reads customer", ownerToken, userv1.UserService_GetUser_FullMethodName,
+			&userv1.GetUserRequest{Id: customer.User.Id}, getUser, codes.OK},
+		{"customer updates self", customerToken, userv1.UserService_UpdateUser_FullMethodName,
+			&userv1.UpdateUserRequest{Id: customer.User.Id, Name: "Carl", Email: "carl@example.com"}, updateUser, codes.OK},
+		{"customer promotes self", customerToken, userv1.UserService_UpdateUser_FullMethodName,
+			&userv1.UpdateUserRequest{Id: customer.User.Id, Name: "Carl", Email: "carl@example.com", IsCafeOwner: true}, updateUser, codes.PermissionDenied},
+		{"customer promotes self through mask", customerToken, userv1.UserService_UpdateUser_FullMethodName,
+			&userv1.UpdateUserRequest{Id: customer.User.Id, IsCafeOwner: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"is_cafe_owner"}}}, updateUser, codes.PermissionDenied},
+		{"owner flag outside mask is ignored", customerToken, userv1.UserService_UpdateUser_FullMethodName,
+			&userv1.UpdateUserRequest{Id: customer.User.Id, Name: "Carl", IsCafeOwner: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}}}, updateUser, codes.OK},
+		{"customer updates owner", customerToken, userv1.UserService_UpdateUser_FullMethodName,
+			&userv1.UpdateUserRequest{Id: owner.User.Id, Name: "Mallory"}, updateUser, codes.PermissionDenied},
+		{"customer reads own ledger", customerToken, userv1.UserService_GetPointsLedger_FullMethodName,
+			&userv1.GetPointsLedgerRequest{UserId: customer.User.Id}, getLedger, codes.OK},
+		{"customer reads owner ledger", customerToken, userv1.UserService_GetPointsLedger_FullMethodName,
+			&userv1.GetPointsLedgerRequest{UserId: owner.User.Id}, getLedger, codes.PermissionDenied},
+		{"customer redeems own points", customerToken, userv1.UserService_RedeemPoints_FullMethodName,
+			&userv1.RedeemPointsRequest{UserId: customer.User.Id, OrderId: 1, Points: 1}, redeemPoints, codes.PermissionDenied},
+		{"service redeems points", serviceToken, userv1.UserService_RedeemPoints_FullMethodName,
+			&userv1.RedeemPointsRequest{UserId: customer.User.Id, OrderId: 1, Points: 1, MaxDiscountCents: 100}, redeemPoints, codes.FailedPrecondition},
+		{"owner credits points", ownerToken, userv1.UserService_CreditOrderPoints_FullMethodName,
+			&userv1.CreditOrderPointsRequest{UserId: customer.User.Id, OrderId: 1, AmountCents: 500}, creditPoints, codes.PermissionDenied},
+		{"customer deletes user", customerToken, userv1.UserService_DeleteUser_FullMethodName,
+			&userv1.DeleteUserRequest{Id: customer.User.Id}, deleteUser, codes.PermissionDenied},
+		{"owner deletes user", ownerToken, userv1.UserService_DeleteUser_FullMethodName,
+			&userv1.DeleteUserRequest{Id: customer.User.Id}, deleteUser, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, call(tt.token, tt.method, tt.req, tt.handler))
+		})
+	}
+}
+
+func TestPolicy_CoversEveryMethod(t *testing.T) {
+	for _, method := range userv1.UserService_ServiceDesc.Methods {
+		fullMethod := "/" + userv1.UserService_ServiceDesc.ServiceName + "/" + method.MethodName
+		assert.Contains(t, Policy, fullMethod)
+	}
+}
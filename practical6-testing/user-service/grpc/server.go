@@ -1,10 +1,18 @@
 package grpc
 
 import (
+	"authz"
 	"context"
-	"user-service/database"
+	"database/sql"
+	"dberr"
+	"fieldmask"
+	"pagination"
+	"prototime"
+	"strings"
+	"time"
 	"user-service/models"
 	userv1 "user-service/proto/userv1"
+	"user-service/store"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,109 +20,152 @@ import (
 
 type UserServer struct {
 	userv1.UnimplementedUserServiceServer
+	store store.UserStore
+	// Keys signs access tokens; Login and RefreshToken fail without it.
+	Keys *authz.KeySet
+	// AccessTokenTTL and RefreshTokenTTL are how long issued tokens stay
+	// valid.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
-func NewUserServer() *UserServer {
-	return &UserServer{}
+// updatableUserFields are the UpdateUserRequest fields an update_mask may
+// list.
+var updatableUserFields = []string{"name", "email", "is_cafe_owner", "password"}
+
+func NewUserServer(store store.UserStore) *UserServer {
+	return &UserServer{
+		store:           store,
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+	}
+}
+
+// normalizeEmail lower-cases an email so that the unique index on stored
+// emails treats addresses that differ only in case as the same.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
 
 func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
 	user := models.User{
 		Name:        req.Name,
-		Email:       req.Email,
+		Email:       normalizeEmail(req.Email),
 		IsCafeOwner: req.IsCafeOwner,
 	}
+	if req.Password != "" {
+		hash, err := hashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.PasswordHash = hash
+	}
 
-	if err := database.DB.Create(&user).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+	if err := s.store.CreateUser(ctx, &user); err != nil {
+		return nil, dberr.Status(err, "user")
 	}
 
-	return &userv1.CreateUserResponse{
-		User: &userv1.User{
-			Id:          uint32(user.ID),
-			Name:        user.Name,
-			Email:       user.Email,
-			IsCafeOwner: user.IsCafeOwner,
-			CreatedAt:   user.CreatedAt.String(),
-			UpdatedAt:   user.UpdatedAt.String(),
-		},
-	}, nil
+	return &userv1.CreateUserResponse{User: toProtoUser(user)}, nil
 }
 
 func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
-	var user models.User
-	if err := database.DB.First(&user, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "user not found")
-	}
-
-	return &userv1.GetUserResponse{
-		User: &userv1.User{
-			Id:          uint32(user.ID),
-			Name:        user.Name,
-			Email:       user.Email,
-			IsCafeOwner: user.IsCafeOwner,
-			CreatedAt:   user.CreatedAt.String(),
-			UpdatedAt:   user.UpdatedAt.String(),
-		},
-	}, nil
+	user, err := s.store.GetUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	return &userv1.GetUserResponse{User: toProtoUser(user)}, nil
 }
 
 func (s *UserServer) GetUsers(ctx context.Context, req *userv1.GetUsersRequest) (*userv1.GetUsersResponse, error) {
-	var users []models.User
-	if err := database.DB.Find(&users).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get users: %v", err)
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := store.UserFilter{IsCafeOwner: req.IsCafeOwner, EmailPrefix: req.EmailPrefix}
+	users, err := s.store.ListUsers(ctx, filter, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "users")
 	}
+	users, nextToken := pagination.Trim(page, users, func(u models.User) uint { return u.ID })
 
 	var pbUsers []*userv1.User
 	for _, user := range users {
-		pbUsers = append(pbUsers, &userv1.User{
-			Id:          uint32(user.ID),
-			Name:        user.Name,
-			Email:       user.Email,
-			IsCafeOwner: user.IsCafeOwner,
-			CreatedAt:   user.CreatedAt.String(),
-			UpdatedAt:   user.UpdatedAt.String(),
-		})
+		pbUsers = append(pbUsers, toProtoUser(user))
 	}
 
-	return &userv1.GetUsersResponse{Users: pbUsers}, nil
+	return &userv1.GetUsersResponse{Users: pbUsers, NextPageToken: nextToken}, nil
 }
 
 func (s *UserServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
-	var user models.User
-	if err := database.DB.First(&user, req.Id).Error; err != nil {
-		return nil, status.Errorf(codes.NotFound, "user not found")
+	fields, err := fieldmask.UpdatePaths(req.UpdateMask, updatableUserFields)
+	if err != nil {
+		return nil, err
 	}
 
-	user.Name = req.Name
-	user.Email = req.Email
-	user.IsCafeOwner = req.IsCafeOwner
+	user, err := s.store.GetUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
 
-	if err := database.DB.Save(&user).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	if fields["name"] {
+		user.Name = req.Name
+	}
+	if fields["email"] {
+		user.Email = normalizeEmail(req.Email)
+	}
+	if fields["is_cafe_owner"] {
+		user.IsCafeOwner = req.IsCafeOwner
+	}
+	if fields["password"] && req.Password != "" {
+		hash, err := hashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.PasswordHash = hash
 	}
 
-	return &userv1.UpdateUserResponse{
-		User: &userv1.User{
-			Id:          uint32(user.ID),
-			Name:        user.Name,
-			Email:       user.Email,
-			IsCafeOwner: user.IsCafeOwner,
-			CreatedAt:   user.CreatedAt.String(),
-			UpdatedAt:   user.UpdatedAt.String(),
-		},
-	}, nil
+	if err := s.store.UpdateUser(ctx, &user); err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+
+	return &userv1.UpdateUserResponse{User: toProtoUser(user)}, nil
 }
 
 func (s *UserServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
-	result := database.DB.Delete(&models.User{}, req.Id)
-	if result.Error != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", result.Error)
-	}
-
-	if result.RowsAffected == 0 {
-		return nil, status.Errorf(codes.NotFound, "user not found")
+	err := s.store.Transaction(ctx, func(tx store.UserStore) error {
+		deleted, err := tx.DeleteUser(ctx, uint(req.Id))
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return status.Errorf(codes.NotFound, "user not found")
+		}
+		return tx.RecordEvent(ctx, models.EventUserDeleted, uint(req.Id))
+	})
+	if err != nil {
+		return nil, dberr.Status(err, "user")
 	}
 
 	return &userv1.DeleteUserResponse{Success: true}, nil
 }
+
+func toProtoUser(user models.User) *userv1.User {
+	pbUser := &userv1.User{
+		Id:            uint32(user.ID),
+		Name:          user.Name,
+		Email:         user.Email,
+		IsCafeOwner:   user.IsCafeOwner,
+		CreatedAt:     prototime.Legacy(user.CreatedAt),
+		UpdatedAt:     prototime.Legacy(user.UpdatedAt),
+		PointsBalance: user.PointsBalance,
+		CreateTime:    prototime.Timestamp(user.CreatedAt),
+		UpdateTime:    prototime.Timestamp(user.UpdatedAt),
+		DeleteTime:    prototime.Null(sql.NullTime(user.DeletedAt)),
+	}
+	if user.DeletedAt.Valid {
+		pbUser.DeletedAt = prototime.Legacy(user.DeletedAt.Time)
+	}
+	return pbUser
+}
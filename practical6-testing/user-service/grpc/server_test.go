@@ -2,15 +2,17 @@ package grpc
 
 import (
 	"context"
+	"dberr"
 	"testing"
-	"user-service/database"
-	"user-service/models"
+	"time"
 	userv1 "user-service/proto/userv1"
+	"user-service/store"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -19,7 +21,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&models.User{})
+	err = store.Migrate(db)
 	require.NoError(t, err)
 
 	return db
@@ -34,9 +36,8 @@ func teardownTestDB(t *testing.T, db *gorm.DB) {
 func TestCreateUser(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewUserServer()
+	server := NewUserServer(store.NewGormStore(db))
 
 	tests := []struct {
 		name        string
@@ -77,17 +78,42 @@ func TestCreateUser(t *testing.T) {
 				assert.Equal(t, tt.request.Name, resp.User.Name)
 				assert.Equal(t, tt.request.Email, resp.User.Email)
 				assert.Equal(t, tt.request.IsCafeOwner, resp.User.IsCafeOwner)
+				assert.WithinDuration(t, time.Now(), resp.User.CreateTime.AsTime(), time.Minute)
+				assert.NotContains(t, resp.User.CreatedAt, "m=")
 			}
 		})
 	}
 }
 
+func TestCreateUser_EmailIgnoresCase(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	created, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada", Email: "Ada@Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", created.User.Email)
+
+	_, err = server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Other Ada", Email: "ADA@example.com"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	other, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+	_, err = server.UpdateUser(ctx, &userv1.UpdateUserRequest{
+		Id:         other.User.Id,
+		Email:      "ADA@EXAMPLE.COM",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
 func TestGetUser(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewUserServer()
+	server := NewUserServer(store.NewGormStore(db))
 	ctx := context.Background()
 
 	// Create a test user
@@ -134,12 +160,83 @@ func TestGetUser(t *testing.T) {
 	}
 }
 
+func TestUpdateUser_FieldMask(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	created, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Jane", Email: "jane@cafeshop.com", IsCafeOwner: true})
+	require.NoError(t, err)
+	id := created.User.Id
+
+	t.Run("renaming keeps owner flag", func(t *testing.T) {
+		resp, err := server.UpdateUser(ctx, &userv1.UpdateUserRequest{
+			Id:         id,
+			Name:       "Jane Owner",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Owner", resp.User.Name)
+		assert.Equal(t, "jane@cafeshop.com", resp.User.Email)
+		assert.True(t, resp.User.IsCafeOwner)
+	})
+
+	t.Run("empty mask replaces every field", func(t *testing.T) {
+		resp, err := server.UpdateUser(ctx, &userv1.UpdateUserRequest{Id: id, Name: "Jane", Email: "jane@cafeshop.com"})
+		require.NoError(t, err)
+		assert.False(t, resp.User.IsCafeOwner)
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		_, err := server.UpdateUser(ctx, &userv1.UpdateUserRequest{
+			Id:         id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestDatabaseErrors(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"})
+	require.NoError(t, err)
+
+	t.Run("duplicate email", func(t *testing.T) {
+		_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Other Ada", Email: "ada@example.com"})
+		require.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		assert.Equal(t, "email", dberr.ConflictingField(err))
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := server.GetUser(cancelled, &userv1.GetUserRequest{Id: 1})
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("database down is not NotFound", func(t *testing.T) {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		require.NoError(t, sqlDB.Close())
+
+		_, err = server.GetUser(ctx, &userv1.GetUserRequest{Id: 1})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
 func TestGetUsers(t *testing.T) {
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
-	database.DB = db
 
-	server := NewUserServer()
+	server := NewUserServer(store.NewGormStore(db))
 	ctx := context.Background()
 
 	// Create multiple users
@@ -165,3 +262,83 @@ func TestGetUsers(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, resp.Users, len(users))
 }
+
+func TestGetUsers_PaginationAndFilters(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	users := []struct {
+		name        string
+		email       string
+		isCafeOwner bool
+	}{
+		{"Owner 1", "owner1@cafe.com", true},
+		{"Student 1", "student1@uni.edu", false},
+		{"Owner 2", "owner2@cafe.com", true},
+		{"Student 2", "student2@uni.edu", false},
+		{"Student 3", "student_3@uni.edu", false},
+	}
+
+	for _, u := range users {
+		_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{
+			Name:        u.name,
+			Email:       u.email,
+			IsCafeOwner: u.isCafeOwner,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("pages cover every user once", func(t *testing.T) {
+		var seen []uint32
+		token := ""
+		for {
+			resp, err := server.GetUsers(ctx, &userv1.GetUsersRequest{PageSize: 2, PageToken: token})
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(resp.Users), 2)
+			for _, u := range resp.Users {
+				seen = append(seen, u.Id)
+			}
+
+			// A user created mid-listing must not shift the remaining pages
+			if token == "" {
+				_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Late", Email: "late@uni.edu"})
+				require.NoError(t, err)
+			}
+
+			token = resp.NextPageToken
+			if token == "" {
+				break
+			}
+		}
+		assert.Len(t, seen, len(users)+1)
+		assert.IsIncreasing(t, seen)
+	})
+
+	t.Run("filter by cafe owner", func(t *testing.T) {
+		owner := true
+		resp, err := server.GetUsers(ctx, &userv1.GetUsersRequest{IsCafeOwner: &owner})
+		require.NoError(t, err)
+		assert.Len(t, resp.Users, 2)
+		for _, u := range resp.Users {
+			assert.True(t, u.IsCafeOwner)
+		}
+	})
+
+	t.Run("filter by email prefix treats wildcards literally", func(t *testing.T) {
+		resp, err := server.GetUsers(ctx, &userv1.GetUsersRequest{EmailPrefix: "student_"})
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 1)
+		assert.Equal(t, "student_3@uni.edu", resp.Users[0].Email)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := server.GetUsers(ctx, &userv1.GetUsersRequest{PageToken: "not-a-token"})
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"dberr"
+	"pagination"
+	"softdelete"
+	"user-service/models"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+)
+
+// ListDeletedUsers lists soft-deleted users that have not been purged yet.
+func (s *UserServer) ListDeletedUsers(ctx context.Context, req *userv1.ListDeletedUsersRequest) (*userv1.ListDeletedUsersResponse, error) {
+	page, err := pagination.Parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.store.ListDeletedUsers(ctx, page.Page)
+	if err != nil {
+		return nil, dberr.Status(err, "users")
+	}
+	users, nextToken := pagination.Trim(page, users, func(u models.User) uint { return u.ID })
+
+	var pbUsers []*userv1.User
+	for _, user := range users {
+		pbUsers = append(pbUsers, toProtoUser(user))
+	}
+
+	return &userv1.ListDeletedUsersResponse{Users: pbUsers, NextPageToken: nextToken}, nil
+}
+
+func (s *UserServer) RestoreUser(ctx context.Context, req *userv1.RestoreUserRequest) (*userv1.RestoreUserResponse, error) {
+	if err := s.deletedUsers().Restore(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, dberr.Status(err, "user")
+	}
+	return &userv1.RestoreUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) PurgeUser(ctx context.Context, req *userv1.PurgeUserRequest) (*userv1.PurgeUserResponse, error) {
+	if err := s.deletedUsers().Purge(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	return &userv1.PurgeUserResponse{Success: true}, nil
+}
+
+// deletedUsers describes soft-deleted users to the softdelete package.
+func (s *UserServer) deletedUsers() softdelete.Resource {
+	return softdelete.Resource{
+		Name: "user",
+		IsDeleted: func(ctx context.Context, id uint) (bool, error) {
+			user, err := s.store.GetUserIncludingDeleted(ctx, id)
+			return user.DeletedAt.Valid, err
+		},
+		Undelete: func(ctx context.Context, id uint) (restored bool, err error) {
+			err = s.store.Transaction(ctx, func(tx store.UserStore) error {
+				restored, err = tx.RestoreUser(ctx, id)
+				if err != nil || !restored {
+					return err
+				}
+				return tx.RecordEvent(ctx, models.EventUserRestored, id)
+			})
+			return restored, err
+		},
+		Remove: s.store.PurgeUser,
+	}
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+	"user-service/models"
+	userv1 "user-service/proto/userv1"
+	"user-service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSoftDeleteRecovery(t *testing.T) {
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+
+	server := NewUserServer(store.NewGormStore(db))
+	ctx := context.Background()
+
+	ada, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"})
+	require.NoError(t, err)
+	_, err = server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: ada.User.Id})
+	require.NoError(t, err)
+
+	t.Run("deleted users are listed", func(t *testing.T) {
+		resp, err := server.ListDeletedUsers(ctx, &userv1.ListDeletedUsersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 1)
+		assert.Equal(t, ada.User.Id, resp.Users[0].Id)
+		assert.NotNil(t, resp.Users[0].DeleteTime)
+	})
+
+	t.Run("email can be registered again", func(t *testing.T) {
+		again, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Ada Again", Email: "ada@example.com"})
+		require.NoError(t, err)
+
+		_, err = server.RestoreUser(ctx, &userv1.RestoreUserRequest{Id: ada.User.Id})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		_, err = server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: again.User.Id})
+		require.NoError(t, err)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		resp, err := server.RestoreUser(ctx, &userv1.RestoreUserRequest{Id: ada.User.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "ada@example.com", resp.User.Email)
+		assert.Nil(t, resp.User.DeleteTime)
+
+		_, err = server.GetUser(ctx, &userv1.GetUserRequest{Id: ada.User.Id})
+		assert.NoError(t, err)
+
+		_, err = server.RestoreUser(ctx, &userv1.RestoreUserRequest{Id: ada.User.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		_, err := server.PurgeUser(ctx, &userv1.PurgeUserRequest{Id: ada.User.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "only deleted users can be purged")
+
+		require.NoError(t, db.Create(&models.RefreshToken{UserID: uint(ada.User.Id), TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+		_, err = server.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: ada.User.Id})
+		require.NoError(t, err)
+		_, err = server.PurgeUser(ctx, &userv1.PurgeUserRequest{Id: ada.User.Id})
+		require.NoError(t, err)
+
+		var remaining int64
+		require.NoError(t, db.Unscoped().Model(&models.User{}).Where("id = ?", ada.User.Id).Count(&remaining).Error)
+		assert.Zero(t, remaining)
+		require.NoError(t, db.Model(&models.RefreshToken{}).Where("user_id = ?", ada.User.Id).Count(&remaining).Error)
+		assert.Zero(t, remaining)
+
+		_, err = server.PurgeUser(ctx, &userv1.PurgeUserRequest{Id: ada.User.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		purged, err := server.store.PurgeDeletedUsers(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, purged, "recently deleted users are kept")
+
+		purged, err = server.store.PurgeDeletedUsers(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		resp, err := server.ListDeletedUsers(ctx, &userv1.ListDeletedUsersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Users)
+	})
+}
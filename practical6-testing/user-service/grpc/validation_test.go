@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+	userv1 "user-service/proto/userv1"
+	"validation"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestRequestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    proto.Message
+		wantFields []string
+	}{
+		{
+			name:    "valid create",
+			request: &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"},
+		},
+		{
+			name:       "empty name and malformed email",
+			request:    &userv1.CreateUserRequest{Name: "", Email: "not-an-email"},
+			wantFields: []string{"name", "email"},
+		},
+		{
+			name:       "short password",
+			request:    &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com", Password: "short"},
+			wantFields: []string{"password"},
+		},
+		{
+			name:       "password longer than bcrypt accepts",
+			request:    &userv1.UpdateUserRequest{Id: 1, Name: "Ada", Email: "ada@example.com", Password: strings.Repeat("x", 73)},
+			wantFields: []string{"password"},
+		},
+		{
+			name:    "update keeps password when empty",
+			request: &userv1.UpdateUserRequest{Id: 1, Name: "Ada", Email: "ada@example.com"},
+		},
+		{
+			name:    "masked update skips unlisted fields",
+			request: &userv1.UpdateUserRequest{Id: 1, IsCafeOwner: true, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"is_cafe_owner"}}},
+		},
+		{
+			name:       "masked update checks listed fields",
+			request:    &userv1.UpdateUserRequest{Id: 1, Email: "not-an-email", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}}},
+			wantFields: []string{"email"},
+		},
+		{
+			name:       "zero id",
+			request:    &userv1.GetUserRequest{},
+			wantFields: []string{"id"},
+		},
+		{
+			name:       "negative page size",
+			request:    &userv1.GetUsersRequest{PageSize: -1},
+			wantFields: []string{"page_size"},
+		},
+		{
+			name:       "login without credentials",
+			request:    &userv1.LoginRequest{},
+			wantFields: []string{"email", "password"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Check(tt.request)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			var fields []string
+			for _, v := range validation.FieldViolations(err) {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -1,57 +1,170 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"user-service/database"
+	"authz"
+	"envconfig"
+	"graceful"
+	"healthcheck"
+	"storedriver"
 	usergrpc "user-service/grpc"
 	userv1 "user-service/proto/userv1"
+	"user-service/store"
+	"validation"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Get database connection string from environment
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "5432")
-	dbUser := getEnv("DB_USER", "postgres")
-	dbPassword := getEnv("DB_PASSWORD", "postgres")
-	dbName := getEnv("DB_NAME", "userdb")
+	// Connect to the store selected by STORE_DRIVER and run migrations
+	storeConfig := storedriver.FromEnv("userdb", "users.db")
+	userStore, err := store.Open(storeConfig)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	log.Printf("Using %s store", storeConfig.Driver)
 
-	dsn := "host=" + dbHost + " port=" + dbPort + " user=" + dbUser + 
-		" password=" + dbPassword + " dbname=" + dbName + " sslmode=disable"
+	// Create gRPC server
+	grpcPort := envconfig.String("GRPC_PORT", "50051")
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
 
-	// Connect to database
-	if err := database.Connect(dsn); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	keys, err := loadKeys(os.Getenv("JWT_KEYS_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
 	}
 
-	// Run migrations
-	if err := database.Migrate(); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	userServer := usergrpc.NewUserServer(userStore)
+	userServer.Keys = keys
+	if userServer.AccessTokenTTL, err = envconfig.Duration("ACCESS_TOKEN_TTL", usergrpc.DefaultAccessTokenTTL); err != nil {
+		log.Fatal(err)
+	}
+	if userServer.RefreshTokenTTL, err = envconfig.Duration("REFRESH_TOKEN_TTL", usergrpc.DefaultRefreshTokenTTL); err != nil {
+		log.Fatal(err)
 	}
 
-	// Create gRPC server
-	grpcPort := getEnv("GRPC_PORT", "50051")
-	lis, err := net.Listen("tcp", ":"+grpcPort)
+	// Only owners can create owners, so the first one is created here
+	if email := os.Getenv("BOOTSTRAP_OWNER_EMAIL"); email != "" {
+		bootstrapOwner(userStore, userServer, email, os.Getenv("BOOTSTRAP_OWNER_PASSWORD"))
+	}
+
+	// Deleted users can be restored until they are purged
+	retention, err := envconfig.Duration("DELETED_RETENTION", store.DefaultDeletedRetention)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatal(err)
 	}
+	jobs := graceful.NewJobs()
+	jobs.Every(time.Hour, func(ctx context.Context) { purgeDeletedUsers(ctx, userStore, retention) })
+
+	authorizer := authz.NewAuthorizer(keys, healthcheck.AllowProbes(usergrpc.Policy))
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor(), validation.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(authorizer.StreamServerInterceptor(), validation.StreamServerInterceptor),
+	)
+	userv1.RegisterUserServiceServer(s, userServer)
 
-	s := grpc.NewServer()
-	userv1.RegisterUserServiceServer(s, usergrpc.NewUserServer())
+	// Report health from a periodic database ping
+	checker := healthcheck.New(userv1.UserService_ServiceDesc.ServiceName, map[string]healthcheck.Check{
+		"database": userStore.Ping,
+	})
+	checker.Register(s)
+	jobs.Go(checker.Run)
+
+	// Let tools such as grpcurl list the API
+	reflectionEnabled, err := envconfig.Bool("GRPC_REFLECTION", false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if reflectionEnabled {
+		reflection.Register(s)
+	}
+
+	// Serve until SIGINT or SIGTERM, then report NOT_SERVING, drain and
+	// wait for the calls in flight
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	shutdown, err := graceful.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	shutdown.OnShutdown = checker.Shutdown
 
 	log.Printf("User service listening on port %s", grpcPort)
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	exitCode := 0
+	if err := graceful.Serve(ctx, s, lis, shutdown); err != nil {
+		log.Printf("Failed to serve: %v", err)
+		exitCode = 1
+	}
+	stop()
+
+	// Stop the background jobs before closing what they use
+	jobs.Stop()
+	if err := userStore.Close(); err != nil {
+		log.Printf("Failed to close store: %v", err)
+		exitCode = 1
+	}
+	log.Println("User service stopped")
+	os.Exit(exitCode)
+}
+
+// purgeDeletedUsers removes users deleted longer than retention ago.
+func purgeDeletedUsers(ctx context.Context, userStore store.UserStore, retention time.Duration) {
+	purged, err := userStore.PurgeDeletedUsers(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.Printf("Failed to purge deleted users: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted users", purged)
 	}
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// bootstrapOwner creates a cafe owner account unless a user with the email
+// already exists.
+func bootstrapOwner(userStore store.UserStore, server *usergrpc.UserServer, email, password string) {
+	_, err := userStore.FindUserByEmail(context.Background(), email)
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		log.Fatalf("Failed to look up bootstrap owner: %v", err)
+	}
+
+	_, err = server.CreateUser(context.Background(), &userv1.CreateUserRequest{
+		Name:        "Cafe Owner",
+		Email:       email,
+		IsCafeOwner: true,
+		Password:    password,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create bootstrap owner: %v", err)
+	}
+	log.Printf("Created cafe owner %s", email)
+}
+
+// loadKeys loads the token signing keys from path and reloads them on
+// SIGHUP so keys can be rotated without a restart. Without a path a random
+// key is generated, which other services cannot verify.
+func loadKeys(path string) (*authz.KeySet, error) {
+	if path == "" {
+		log.Println("JWT_KEYS_FILE is not set, signing tokens with a temporary key")
+		return authz.NewEphemeralKeySet()
+	}
+
+	keys, err := authz.LoadKeySet(path)
+	if err != nil {
+		return nil, err
 	}
-	return defaultValue
+	keys.ReloadOnSignal(syscall.SIGHUP)
+	return keys, nil
 }
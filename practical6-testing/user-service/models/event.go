@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Event types recorded in the outbox.
+const (
+	EventUserDeleted  = "UserDeleted"
+	EventUserRestored = "UserRestored"
+	EventUserPurged   = "UserPurged"
+)
+
+// OutboxEvent is a user lifecycle event written in the same transaction as
+// the change it describes, for other services to read with ListUserEvents.
+type OutboxEvent struct {
+	ID uint `gorm:"primaryKey"`
+	// Sequence numbers events in the order their transactions committed,
+	// which their IDs do not guarantee. Readers page through the feed by it.
+	Sequence  uint64 `gorm:"uniqueIndex"`
+	Type      string `gorm:"size:64;not null"`
+	UserID    uint   `gorm:"index;not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Reasons a points entry was recorded.
+const (
+	PointsEarned   = "earned"
+	PointsRedeemed = "redeemed"
+	PointsReversed = "reversed"
+)
+
+// PointsEntry is one change to a user's loyalty points balance. Entries are
+// only ever appended; a mistake is undone with a compensating entry. A
+// user's order has at most one entry for each reason.
+type PointsEntry struct {
+	ID      uint   `gorm:"primaryKey"`
+	UserID  uint   `gorm:"index;not null;uniqueIndex:idx_points_entries_user_order_reason"`
+	Delta   int64  `gorm:"not null"`
+	Reason  string `gorm:"size:32;not null;uniqueIndex:idx_points_entries_user_order_reason"`
+	OrderID *uint  `gorm:"uniqueIndex:idx_points_entries_user_order_reason"`
+	// BalanceAfter is the user's balance once the entry was applied.
+	BalanceAfter int64 `gorm:"not null"`
+	CreatedAt    time.Time
+}
+
+// LoyaltySettings is the single row holding the loyalty programme's rates.
+type LoyaltySettings struct {
+	ID uint `gorm:"primaryKey"`
+	// PointsPerCurrencyUnit is how many points each whole currency unit
+	// spent earns.
+	PointsPerCurrencyUnit int64 `gorm:"not null"`
+	// PointValueCents is the discount one point buys, in minor units.
+	PointValueCents int64 `gorm:"not null"`
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RefreshToken is an issued refresh token. Only a SHA-256 hash of the token
+// is stored, so a database leak does not leak usable tokens.
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index;not null"`
+	TokenHash string `gorm:"size:64;uniqueIndex;not null"`
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
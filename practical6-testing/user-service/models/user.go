@@ -4,7 +4,14 @@ import "gorm.io/gorm"
 
 type User struct {
 	gorm.Model
-	Name        string `gorm:"not null"`
-	Email       string `gorm:"uniqueIndex;not null"`
+	Name string `gorm:"not null"`
+	// Email is unique among users that have not been deleted, so a deleted
+	// user's address can be registered again.
+	Email       string `gorm:"uniqueIndex:idx_users_email_active,where:deleted_at IS NULL;not null"`
 	IsCafeOwner bool   `gorm:"default:false"`
+	// PasswordHash is the bcrypt hash of the user's password, empty for
+	// users who cannot log in.
+	PasswordHash string `gorm:"size:60"`
+	// PointsBalance is the sum of the user's points entries.
+	PointsBalance int64 `gorm:"not null;default:0"`
 }
@@ -7,8 +7,11 @@
 package userv1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,14 +24,139 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UserEventType int32
+
+const (
+	UserEventType_USER_EVENT_TYPE_UNSPECIFIED UserEventType = 0
+	UserEventType_USER_EVENT_TYPE_DELETED     UserEventType = 1
+	UserEventType_USER_EVENT_TYPE_RESTORED    UserEventType = 2
+	// The user was removed permanently and will not come back.
+	UserEventType_USER_EVENT_TYPE_PURGED UserEventType = 3
+)
+
+// Enum value maps for UserEventType.
+var (
+	UserEventType_name = map[int32]string{
+		0: "USER_EVENT_TYPE_UNSPECIFIED",
+		1: "USER_EVENT_TYPE_DELETED",
+		2: "USER_EVENT_TYPE_RESTORED",
+		3: "USER_EVENT_TYPE_PURGED",
+	}
+	UserEventType_value = map[string]int32{
+		"USER_EVENT_TYPE_UNSPECIFIED": 0,
+		"USER_EVENT_TYPE_DELETED":     1,
+		"USER_EVENT_TYPE_RESTORED":    2,
+		"USER_EVENT_TYPE_PURGED":      3,
+	}
+)
+
+func (x UserEventType) Enum() *UserEventType {
+	p := new(UserEventType)
+	*p = x
+	return p
+}
+
+func (x UserEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[0].Descriptor()
+}
+
+func (UserEventType) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[0]
+}
+
+func (x UserEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserEventType.Descriptor instead.
+func (UserEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{0}
+}
+
+type PointsReason int32
+
+const (
+	PointsReason_POINTS_REASON_UNSPECIFIED PointsReason = 0
+	// Points earned by a completed order.
+	PointsReason_POINTS_REASON_EARNED PointsReason = 1
+	// Points spent on an order discount.
+	PointsReason_POINTS_REASON_REDEEMED PointsReason = 2
+	// Undoes an order's earned and redeemed points.
+	PointsReason_POINTS_REASON_REVERSED PointsReason = 3
+)
+
+// Enum value maps for PointsReason.
+var (
+	PointsReason_name = map[int32]string{
+		0: "POINTS_REASON_UNSPECIFIED",
+		1: "POINTS_REASON_EARNED",
+		2: "POINTS_REASON_REDEEMED",
+		3: "POINTS_REASON_REVERSED",
+	}
+	PointsReason_value = map[string]int32{
+		"POINTS_REASON_UNSPECIFIED": 0,
+		"POINTS_REASON_EARNED":      1,
+		"POINTS_REASON_REDEEMED":    2,
+		"POINTS_REASON_REVERSED":    3,
+	}
+)
+
+func (x PointsReason) Enum() *PointsReason {
+	p := new(PointsReason)
+	*p = x
+	return p
+}
+
+func (x PointsReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PointsReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_proto_enumTypes[1].Descriptor()
+}
+
+func (PointsReason) Type() protoreflect.EnumType {
+	return &file_proto_user_proto_enumTypes[1]
+}
+
+func (x PointsReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PointsReason.Descriptor instead.
+func (PointsReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{1}
+}
+
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	IsCafeOwner   bool                   `protobuf:"varint,4,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Lower-cased; no two active users share an email, whatever its case.
+	Email       string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	IsCafeOwner bool   `protobuf:"varint,4,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
+	// Deprecated: use create_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	CreatedAt string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Deprecated: use update_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	UpdatedAt string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Deprecated: use delete_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	DeletedAt string `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Loyalty points available to redeem.
+	PointsBalance int64                  `protobuf:"varint,8,opt,name=points_balance,json=pointsBalance,proto3" json:"points_balance,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Unset unless the user has been deleted.
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,6 +219,7 @@ func (x *User) GetIsCafeOwner() bool {
 	return false
 }
 
+// Deprecated: Marked as deprecated in proto/user.proto.
 func (x *User) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
@@ -98,6 +227,7 @@ func (x *User) GetCreatedAt() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/user.proto.
 func (x *User) GetUpdatedAt() string {
 	if x != nil {
 		return x.UpdatedAt
@@ -105,11 +235,49 @@ func (x *User) GetUpdatedAt() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/user.proto.
+func (x *User) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *User) GetPointsBalance() int64 {
+	if x != nil {
+		return x.PointsBalance
+	}
+	return 0
+}
+
+func (x *User) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *User) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *User) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
 type CreateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	IsCafeOwner   bool                   `protobuf:"varint,3,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email       string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	IsCafeOwner bool                   `protobuf:"varint,3,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
+	// At least 8 characters. Users created without a password cannot log in.
+	Password      string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -165,6 +333,13 @@ func (x *CreateUserRequest) GetIsCafeOwner() bool {
 	return false
 }
 
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type CreateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -298,7 +473,13 @@ func (x *GetUserResponse) GetUser() *User {
 }
 
 type GetUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of users to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IsCafeOwner   *bool  `protobuf:"varint,3,opt,name=is_cafe_owner,json=isCafeOwner,proto3,oneof" json:"is_cafe_owner,omitempty"`
+	EmailPrefix   string `protobuf:"bytes,4,opt,name=email_prefix,json=emailPrefix,proto3" json:"email_prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -333,9 +514,39 @@ func (*GetUsersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *GetUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetUsersRequest) GetIsCafeOwner() bool {
+	if x != nil && x.IsCafeOwner != nil {
+		return *x.IsCafeOwner
+	}
+	return false
+}
+
+func (x *GetUsersRequest) GetEmailPrefix() string {
+	if x != nil {
+		return x.EmailPrefix
+	}
+	return ""
+}
+
 type GetUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty when there are no more users.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -377,12 +588,24 @@ func (x *GetUsersResponse) GetUsers() []*User {
 	return nil
 }
 
+func (x *GetUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	IsCafeOwner   bool                   `protobuf:"varint,4,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email       string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	IsCafeOwner bool                   `protobuf:"varint,4,opt,name=is_cafe_owner,json=isCafeOwner,proto3" json:"is_cafe_owner,omitempty"`
+	// New password; the current one is kept when empty.
+	Password string `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	// Fields to change, e.g. "name" or "is_cafe_owner"; the others are left as
+	// they are. Every field is replaced when the mask is empty.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -445,6 +668,20 @@ func (x *UpdateUserRequest) GetIsCafeOwner() bool {
 	return false
 }
 
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -577,44 +814,1749 @@ func (x *DeleteUserResponse) GetSuccess() bool {
 	return false
 }
 
-var File_proto_user_proto protoreflect.FileDescriptor
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_user_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/user.proto\x12\auser.v1\"\xa2\x01\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\"\n" +
-	"\ris_cafe_owner\x18\x04 \x01(\bR\visCafeOwner\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"a\n" +
-	"\x11CreateUserRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\"\n" +
-	"\ris_cafe_owner\x18\x03 \x01(\bR\visCafeOwner\"7\n" +
-	"\x12CreateUserResponse\x12!\n" +
-	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\" \n" +
-	"\x0eGetUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"4\n" +
-	"\x0fGetUserResponse\x12!\n" +
-	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\"\x11\n" +
-	"\x0fGetUsersRequest\"7\n" +
-	"\x10GetUsersResponse\x12#\n" +
-	"\x05users\x18\x01 \x03(\v2\r.user.v1.UserR\x05users\"q\n" +
-	"\x11UpdateUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\"\n" +
-	"\ris_cafe_owner\x18\x04 \x01(\bR\visCafeOwner\"7\n" +
-	"\x12UpdateUserResponse\x12!\n" +
-	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\"#\n" +
-	"\x11DeleteUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\".\n" +
-	"\x12DeleteUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe1\x02\n" +
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_proto_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Signed JWT to send as "authorization: Bearer <token>".
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// Seconds until the access token expires.
+	ExpiresIn int64 `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	// Opaque token for RefreshToken and Logout.
+	RefreshToken  string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	User          *User  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_proto_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_proto_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresIn   int64                  `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	// Replaces the refresh token that was sent.
+	RefreshToken  string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_proto_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefreshTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_proto_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_proto_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{16}
+}
+
+type ListDeletedUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of users to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersRequest) Reset() {
+	*x = ListDeletedUsersRequest{}
+	mi := &file_proto_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersRequest) ProtoMessage() {}
+
+func (x *ListDeletedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDeletedUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeletedUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty when there are no more users.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersResponse) Reset() {
+	*x = ListDeletedUsersResponse{}
+	mi := &file_proto_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersResponse) ProtoMessage() {}
+
+func (x *ListDeletedUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDeletedUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListDeletedUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_proto_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_proto_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type PurgeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserRequest) Reset() {
+	*x = PurgeUserRequest{}
+	mi := &file_proto_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserRequest) ProtoMessage() {}
+
+func (x *PurgeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserRequest.ProtoReflect.Descriptor instead.
+func (*PurgeUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserResponse) Reset() {
+	*x = PurgeUserResponse{}
+	mi := &file_proto_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserResponse) ProtoMessage() {}
+
+func (x *PurgeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserResponse.ProtoReflect.Descriptor instead.
+func (*PurgeUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PurgeUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UserEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases with every event in the order they were committed, so no
+	// event appears later with a lower sequence; pass the last one seen as
+	// after_sequence.
+	Sequence uint64        `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type     UserEventType `protobuf:"varint,2,opt,name=type,proto3,enum=user.v1.UserEventType" json:"type,omitempty"`
+	UserId   uint32        `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: use event_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	OccurredAt    string                 `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEvent) Reset() {
+	*x = UserEvent{}
+	mi := &file_proto_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEvent) ProtoMessage() {}
+
+func (x *UserEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UserEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UserEvent) GetType() UserEventType {
+	if x != nil {
+		return x.Type
+	}
+	return UserEventType_USER_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *UserEvent) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/user.proto.
+func (x *UserEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *UserEvent) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+type ListUserEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterSequence uint64                 `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// Maximum number of events to return; defaults to 50, capped at 100.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserEventsRequest) Reset() {
+	*x = ListUserEventsRequest{}
+	mi := &file_proto_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserEventsRequest) ProtoMessage() {}
+
+func (x *ListUserEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListUserEventsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *ListUserEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListUserEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when the caller has seen every event.
+	Events        []*UserEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserEventsResponse) Reset() {
+	*x = ListUserEventsResponse{}
+	mi := &file_proto_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserEventsResponse) ProtoMessage() {}
+
+func (x *ListUserEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListUserEventsResponse) GetEvents() []*UserEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type PointsEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Points added, or removed when negative.
+	Delta  int64        `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Reason PointsReason `protobuf:"varint,4,opt,name=reason,proto3,enum=user.v1.PointsReason" json:"reason,omitempty"`
+	// Order the points were earned or redeemed on.
+	OrderId uint32 `protobuf:"varint,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Balance once this entry was applied.
+	BalanceAfter int64 `protobuf:"varint,6,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	// Deprecated: use create_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PointsEntry) Reset() {
+	*x = PointsEntry{}
+	mi := &file_proto_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointsEntry) ProtoMessage() {}
+
+func (x *PointsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointsEntry.ProtoReflect.Descriptor instead.
+func (*PointsEntry) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PointsEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PointsEntry) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PointsEntry) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *PointsEntry) GetReason() PointsReason {
+	if x != nil {
+		return x.Reason
+	}
+	return PointsReason_POINTS_REASON_UNSPECIFIED
+}
+
+func (x *PointsEntry) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PointsEntry) GetBalanceAfter() int64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/user.proto.
+func (x *PointsEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PointsEntry) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type GetPointsLedgerRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Maximum number of entries to return; defaults to 50, capped at 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response to continue listing.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPointsLedgerRequest) Reset() {
+	*x = GetPointsLedgerRequest{}
+	mi := &file_proto_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPointsLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPointsLedgerRequest) ProtoMessage() {}
+
+func (x *GetPointsLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPointsLedgerRequest.ProtoReflect.Descriptor instead.
+func (*GetPointsLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPointsLedgerRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetPointsLedgerRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetPointsLedgerRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetPointsLedgerResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*PointsEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Empty when there are no more entries.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Balance       int64  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPointsLedgerResponse) Reset() {
+	*x = GetPointsLedgerResponse{}
+	mi := &file_proto_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPointsLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPointsLedgerResponse) ProtoMessage() {}
+
+func (x *GetPointsLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPointsLedgerResponse.ProtoReflect.Descriptor instead.
+func (*GetPointsLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetPointsLedgerResponse) GetEntries() []*PointsEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetPointsLedgerResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetPointsLedgerResponse) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type RedeemPointsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId uint32                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Points  int64                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	// Largest discount the order can take, in minor units. Redeeming points
+	// worth more fails with INVALID_ARGUMENT.
+	MaxDiscountCents int64 `protobuf:"varint,4,opt,name=max_discount_cents,json=maxDiscountCents,proto3" json:"max_discount_cents,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RedeemPointsRequest) Reset() {
+	*x = RedeemPointsRequest{}
+	mi := &file_proto_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPointsRequest) ProtoMessage() {}
+
+func (x *RedeemPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPointsRequest.ProtoReflect.Descriptor instead.
+func (*RedeemPointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RedeemPointsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RedeemPointsRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RedeemPointsRequest) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RedeemPointsRequest) GetMaxDiscountCents() int64 {
+	if x != nil {
+		return x.MaxDiscountCents
+	}
+	return 0
+}
+
+type RedeemPointsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Entry *PointsEntry           `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Value of the redeemed points in minor units.
+	DiscountCents int64 `protobuf:"varint,2,opt,name=discount_cents,json=discountCents,proto3" json:"discount_cents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemPointsResponse) Reset() {
+	*x = RedeemPointsResponse{}
+	mi := &file_proto_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemPointsResponse) ProtoMessage() {}
+
+func (x *RedeemPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemPointsResponse.ProtoReflect.Descriptor instead.
+func (*RedeemPointsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RedeemPointsResponse) GetEntry() *PointsEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *RedeemPointsResponse) GetDiscountCents() int64 {
+	if x != nil {
+		return x.DiscountCents
+	}
+	return 0
+}
+
+type CreditOrderPointsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId uint32                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Amount the customer paid, in minor units, that earns points.
+	AmountCents   int64 `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditOrderPointsRequest) Reset() {
+	*x = CreditOrderPointsRequest{}
+	mi := &file_proto_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditOrderPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditOrderPointsRequest) ProtoMessage() {}
+
+func (x *CreditOrderPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditOrderPointsRequest.ProtoReflect.Descriptor instead.
+func (*CreditOrderPointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreditOrderPointsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreditOrderPointsRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreditOrderPointsRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+type CreditOrderPointsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when the order earned no points.
+	Entry         *PointsEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditOrderPointsResponse) Reset() {
+	*x = CreditOrderPointsResponse{}
+	mi := &file_proto_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditOrderPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditOrderPointsResponse) ProtoMessage() {}
+
+func (x *CreditOrderPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditOrderPointsResponse.ProtoReflect.Descriptor instead.
+func (*CreditOrderPointsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreditOrderPointsResponse) GetEntry() *PointsEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type ReverseOrderPointsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId uint32                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Customer who placed the order.
+	UserId        uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseOrderPointsRequest) Reset() {
+	*x = ReverseOrderPointsRequest{}
+	mi := &file_proto_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseOrderPointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseOrderPointsRequest) ProtoMessage() {}
+
+func (x *ReverseOrderPointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseOrderPointsRequest.ProtoReflect.Descriptor instead.
+func (*ReverseOrderPointsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReverseOrderPointsRequest) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReverseOrderPointsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ReverseOrderPointsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when the order had no points to reverse.
+	Entry         *PointsEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseOrderPointsResponse) Reset() {
+	*x = ReverseOrderPointsResponse{}
+	mi := &file_proto_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseOrderPointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseOrderPointsResponse) ProtoMessage() {}
+
+func (x *ReverseOrderPointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseOrderPointsResponse.ProtoReflect.Descriptor instead.
+func (*ReverseOrderPointsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReverseOrderPointsResponse) GetEntry() *PointsEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type LoyaltySettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Points earned per whole currency unit spent.
+	PointsPerCurrencyUnit int64 `protobuf:"varint,1,opt,name=points_per_currency_unit,json=pointsPerCurrencyUnit,proto3" json:"points_per_currency_unit,omitempty"`
+	// Discount one point is worth, in minor units.
+	PointValueCents int64 `protobuf:"varint,2,opt,name=point_value_cents,json=pointValueCents,proto3" json:"point_value_cents,omitempty"`
+	// Deprecated: use update_time.
+	//
+	// Deprecated: Marked as deprecated in proto/user.proto.
+	UpdatedAt string `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Unset while the default rates apply.
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoyaltySettings) Reset() {
+	*x = LoyaltySettings{}
+	mi := &file_proto_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoyaltySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoyaltySettings) ProtoMessage() {}
+
+func (x *LoyaltySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoyaltySettings.ProtoReflect.Descriptor instead.
+func (*LoyaltySettings) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *LoyaltySettings) GetPointsPerCurrencyUnit() int64 {
+	if x != nil {
+		return x.PointsPerCurrencyUnit
+	}
+	return 0
+}
+
+func (x *LoyaltySettings) GetPointValueCents() int64 {
+	if x != nil {
+		return x.PointValueCents
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/user.proto.
+func (x *LoyaltySettings) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *LoyaltySettings) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type GetLoyaltySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoyaltySettingsRequest) Reset() {
+	*x = GetLoyaltySettingsRequest{}
+	mi := &file_proto_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoyaltySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoyaltySettingsRequest) ProtoMessage() {}
+
+func (x *GetLoyaltySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoyaltySettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetLoyaltySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{36}
+}
+
+type GetLoyaltySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *LoyaltySettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoyaltySettingsResponse) Reset() {
+	*x = GetLoyaltySettingsResponse{}
+	mi := &file_proto_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoyaltySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoyaltySettingsResponse) ProtoMessage() {}
+
+func (x *GetLoyaltySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoyaltySettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetLoyaltySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetLoyaltySettingsResponse) GetSettings() *LoyaltySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateLoyaltySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *LoyaltySettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLoyaltySettingsRequest) Reset() {
+	*x = UpdateLoyaltySettingsRequest{}
+	mi := &file_proto_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLoyaltySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLoyaltySettingsRequest) ProtoMessage() {}
+
+func (x *UpdateLoyaltySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLoyaltySettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLoyaltySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateLoyaltySettingsRequest) GetSettings() *LoyaltySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateLoyaltySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *LoyaltySettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLoyaltySettingsResponse) Reset() {
+	*x = UpdateLoyaltySettingsResponse{}
+	mi := &file_proto_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLoyaltySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLoyaltySettingsResponse) ProtoMessage() {}
+
+func (x *UpdateLoyaltySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLoyaltySettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLoyaltySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateLoyaltySettingsResponse) GetSettings() *LoyaltySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+var File_proto_user_proto protoreflect.FileDescriptor
+
+const file_proto_user_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/user.proto\x12\auser.v1\x1a\x1bbuf/validate/validate.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xab\x03\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\"\n" +
+	"\ris_cafe_owner\x18\x04 \x01(\bR\visCafeOwner\x12!\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tB\x02\x18\x01R\tcreatedAt\x12!\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tB\x02\x18\x01R\tupdatedAt\x12!\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\tB\x02\x18\x01R\tdeletedAt\x12%\n" +
+	"\x0epoints_balance\x18\b \x01(\x03R\rpointsBalance\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12;\n" +
+	"\vdelete_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\"\xa2\x01\n" +
+	"\x11CreateUserRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12 \n" +
+	"\x05email\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x18\xfe\x01`\x01R\x05email\x12\"\n" +
+	"\ris_cafe_owner\x18\x03 \x01(\bR\visCafeOwner\x12(\n" +
+	"\bpassword\x18\x04 \x01(\tB\f\xbaH\t\xd8\x01\x01r\x04\x10\b(HR\bpassword\"7\n" +
+	"\x12CreateUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"4\n" +
+	"\x0fGetUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\"\xbe\x01\n" +
+	"\x0fGetUsersRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12'\n" +
+	"\ris_cafe_owner\x18\x03 \x01(\bH\x00R\visCafeOwner\x88\x01\x01\x12+\n" +
+	"\femail_prefix\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\xfe\x01R\vemailPrefixB\x10\n" +
+	"\x0e_is_cafe_owner\"_\n" +
+	"\x10GetUsersResponse\x12#\n" +
+	"\x05users\x18\x01 \x03(\v2\r.user.v1.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf7\x01\n" +
+	"\x11UpdateUserRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\rB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12 \n" +
+	"\x05email\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x18\xfe\x01`\x01R\x05email\x12\"\n" +
+	"\ris_cafe_owner\x18\x04 \x01(\bR\visCafeOwner\x12(\n" +
+	"\bpassword\x18\x05 \x01(\tB\f\xbaH\t\xd8\x01\x01r\x04\x10\b(HR\bpassword\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"7\n" +
+	"\x12UpdateUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\",\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\".\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"P\n" +
+	"\fLoginRequest\x12\x1c\n" +
+	"\x05email\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05email\x12\"\n" +
+	"\bpassword\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bpassword\"\x99\x01\n" +
+	"\rLoginResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x03R\texpiresIn\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x12!\n" +
+	"\x04user\x18\x04 \x01(\v2\r.user.v1.UserR\x04user\"B\n" +
+	"\x13RefreshTokenRequest\x12+\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\frefreshToken\"}\n" +
+	"\x14RefreshTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x03R\texpiresIn\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"<\n" +
+	"\rLogoutRequest\x12+\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\frefreshToken\"\x10\n" +
+	"\x0eLogoutResponse\"^\n" +
+	"\x17ListDeletedUsersRequest\x12$\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"g\n" +
+	"\x18ListDeletedUsersResponse\x12#\n" +
+	"\x05users\x18\x01 \x03(\v2\r.user.v1.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"-\n" +
+	"\x12RestoreUserRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"8\n" +
+	"\x13RestoreUserResponse\x12!\n" +
+	"\x04user\x18\x01 \x01(\v2\r.user.v1.UserR\x04user\"+\n" +
+	"\x10PurgeUserRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x02id\"-\n" +
+	"\x11PurgeUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xcc\x01\n" +
+	"\tUserEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.user.v1.UserEventTypeR\x04type\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\rR\x06userId\x12#\n" +
+	"\voccurred_at\x18\x04 \x01(\tB\x02\x18\x01R\n" +
+	"occurredAt\x129\n" +
+	"\n" +
+	"event_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\"d\n" +
+	"\x15ListUserEventsRequest\x12%\n" +
+	"\x0eafter_sequence\x18\x01 \x01(\x04R\rafterSequence\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\"D\n" +
+	"\x16ListUserEventsResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.user.v1.UserEventR\x06events\"\x9b\x02\n" +
+	"\vPointsEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\x12-\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x15.user.v1.PointsReasonR\x06reason\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\rR\aorderId\x12#\n" +
+	"\rbalance_after\x18\x06 \x01(\x03R\fbalanceAfter\x12!\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tB\x02\x18\x01R\tcreatedAt\x12;\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\x7f\n" +
+	"\x16GetPointsLedgerRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06userId\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x8b\x01\n" +
+	"\x17GetPointsLedgerResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.user.v1.PointsEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x03R\abalance\"\xb3\x01\n" +
+	"\x13RedeemPointsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06userId\x12\"\n" +
+	"\border_id\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\aorderId\x12\x1f\n" +
+	"\x06points\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06points\x125\n" +
+	"\x12max_discount_cents\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x10maxDiscountCents\"i\n" +
+	"\x14RedeemPointsResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.user.v1.PointsEntryR\x05entry\x12%\n" +
+	"\x0ediscount_cents\x18\x02 \x01(\x03R\rdiscountCents\"\x8c\x01\n" +
+	"\x18CreditOrderPointsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06userId\x12\"\n" +
+	"\border_id\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\aorderId\x12*\n" +
+	"\famount_cents\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\vamountCents\"G\n" +
+	"\x19CreditOrderPointsResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.user.v1.PointsEntryR\x05entry\"a\n" +
+	"\x19ReverseOrderPointsRequest\x12\"\n" +
+	"\border_id\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\aorderId\x12 \n" +
+	"\auser_id\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06userId\"H\n" +
+	"\x1aReverseOrderPointsResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.user.v1.PointsEntryR\x05entry\"\xe8\x01\n" +
+	"\x0fLoyaltySettings\x12@\n" +
+	"\x18points_per_currency_unit\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x15pointsPerCurrencyUnit\x123\n" +
+	"\x11point_value_cents\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x0fpointValueCents\x12!\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\tB\x02\x18\x01R\tupdatedAt\x12;\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\x1b\n" +
+	"\x19GetLoyaltySettingsRequest\"R\n" +
+	"\x1aGetLoyaltySettingsResponse\x124\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.user.v1.LoyaltySettingsR\bsettings\"\\\n" +
+	"\x1cUpdateLoyaltySettingsRequest\x12<\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.user.v1.LoyaltySettingsB\x06\xbaH\x03\xc8\x01\x01R\bsettings\"U\n" +
+	"\x1dUpdateLoyaltySettingsResponse\x124\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.user.v1.LoyaltySettingsR\bsettings*\x87\x01\n" +
+	"\rUserEventType\x12\x1f\n" +
+	"\x1bUSER_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17USER_EVENT_TYPE_DELETED\x10\x01\x12\x1c\n" +
+	"\x18USER_EVENT_TYPE_RESTORED\x10\x02\x12\x1a\n" +
+	"\x16USER_EVENT_TYPE_PURGED\x10\x03*\x7f\n" +
+	"\fPointsReason\x12\x1d\n" +
+	"\x19POINTS_REASON_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14POINTS_REASON_EARNED\x10\x01\x12\x1a\n" +
+	"\x16POINTS_REASON_REDEEMED\x10\x02\x12\x1a\n" +
+	"\x16POINTS_REASON_REVERSED\x10\x032\x80\v\n" +
 	"\vUserService\x12E\n" +
 	"\n" +
 	"CreateUser\x12\x1a.user.v1.CreateUserRequest\x1a\x1b.user.v1.CreateUserResponse\x12<\n" +
@@ -623,7 +2565,20 @@ const file_proto_user_proto_rawDesc = "" +
 	"\n" +
 	"UpdateUser\x12\x1a.user.v1.UpdateUserRequest\x1a\x1b.user.v1.UpdateUserResponse\x12E\n" +
 	"\n" +
-	"DeleteUser\x12\x1a.user.v1.DeleteUserRequest\x1a\x1b.user.v1.DeleteUserResponseB\x1bZ\x19user-service/proto/userv1b\x06proto3"
+	"DeleteUser\x12\x1a.user.v1.DeleteUserRequest\x1a\x1b.user.v1.DeleteUserResponse\x126\n" +
+	"\x05Login\x12\x15.user.v1.LoginRequest\x1a\x16.user.v1.LoginResponse\x12K\n" +
+	"\fRefreshToken\x12\x1c.user.v1.RefreshTokenRequest\x1a\x1d.user.v1.RefreshTokenResponse\x129\n" +
+	"\x06Logout\x12\x16.user.v1.LogoutRequest\x1a\x17.user.v1.LogoutResponse\x12W\n" +
+	"\x10ListDeletedUsers\x12 .user.v1.ListDeletedUsersRequest\x1a!.user.v1.ListDeletedUsersResponse\x12H\n" +
+	"\vRestoreUser\x12\x1b.user.v1.RestoreUserRequest\x1a\x1c.user.v1.RestoreUserResponse\x12B\n" +
+	"\tPurgeUser\x12\x19.user.v1.PurgeUserRequest\x1a\x1a.user.v1.PurgeUserResponse\x12Q\n" +
+	"\x0eListUserEvents\x12\x1e.user.v1.ListUserEventsRequest\x1a\x1f.user.v1.ListUserEventsResponse\x12T\n" +
+	"\x0fGetPointsLedger\x12\x1f.user.v1.GetPointsLedgerRequest\x1a .user.v1.GetPointsLedgerResponse\x12K\n" +
+	"\fRedeemPoints\x12\x1c.user.v1.RedeemPointsRequest\x1a\x1d.user.v1.RedeemPointsResponse\x12Z\n" +
+	"\x11CreditOrderPoints\x12!.user.v1.CreditOrderPointsRequest\x1a\".user.v1.CreditOrderPointsResponse\x12]\n" +
+	"\x12ReverseOrderPoints\x12\".user.v1.ReverseOrderPointsRequest\x1a#.user.v1.ReverseOrderPointsResponse\x12]\n" +
+	"\x12GetLoyaltySettings\x12\".user.v1.GetLoyaltySettingsRequest\x1a#.user.v1.GetLoyaltySettingsResponse\x12f\n" +
+	"\x15UpdateLoyaltySettings\x12%.user.v1.UpdateLoyaltySettingsRequest\x1a&.user.v1.UpdateLoyaltySettingsResponseB\x1bZ\x19user-service/proto/userv1b\x06proto3"
 
 var (
 	file_proto_user_proto_rawDescOnce sync.Once
@@ -637,40 +2592,120 @@ func file_proto_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_proto_rawDescData
 }
 
-var file_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_user_proto_goTypes = []any{
-	(*User)(nil),               // 0: user.v1.User
-	(*CreateUserRequest)(nil),  // 1: user.v1.CreateUserRequest
-	(*CreateUserResponse)(nil), // 2: user.v1.CreateUserResponse
-	(*GetUserRequest)(nil),     // 3: user.v1.GetUserRequest
-	(*GetUserResponse)(nil),    // 4: user.v1.GetUserResponse
-	(*GetUsersRequest)(nil),    // 5: user.v1.GetUsersRequest
-	(*GetUsersResponse)(nil),   // 6: user.v1.GetUsersResponse
-	(*UpdateUserRequest)(nil),  // 7: user.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil), // 8: user.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),  // 9: user.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil), // 10: user.v1.DeleteUserResponse
+	(UserEventType)(0),                    // 0: user.v1.UserEventType
+	(PointsReason)(0),                     // 1: user.v1.PointsReason
+	(*User)(nil),                          // 2: user.v1.User
+	(*CreateUserRequest)(nil),             // 3: user.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),            // 4: user.v1.CreateUserResponse
+	(*GetUserRequest)(nil),                // 5: user.v1.GetUserRequest
+	(*GetUserResponse)(nil),               // 6: user.v1.GetUserResponse
+	(*GetUsersRequest)(nil),               // 7: user.v1.GetUsersRequest
+	(*GetUsersResponse)(nil),              // 8: user.v1.GetUsersResponse
+	(*UpdateUserRequest)(nil),             // 9: user.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),            // 10: user.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),             // 11: user.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),            // 12: user.v1.DeleteUserResponse
+	(*LoginRequest)(nil),                  // 13: user.v1.LoginRequest
+	(*LoginResponse)(nil),                 // 14: user.v1.LoginResponse
+	(*RefreshTokenRequest)(nil),           // 15: user.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),          // 16: user.v1.RefreshTokenResponse
+	(*LogoutRequest)(nil),                 // 17: user.v1.LogoutRequest
+	(*LogoutResponse)(nil),                // 18: user.v1.LogoutResponse
+	(*ListDeletedUsersRequest)(nil),       // 19: user.v1.ListDeletedUsersRequest
+	(*ListDeletedUsersResponse)(nil),      // 20: user.v1.ListDeletedUsersResponse
+	(*RestoreUserRequest)(nil),            // 21: user.v1.RestoreUserRequest
+	(*RestoreUserResponse)(nil),           // 22: user.v1.RestoreUserResponse
+	(*PurgeUserRequest)(nil),              // 23: user.v1.PurgeUserRequest
+	(*PurgeUserResponse)(nil),             // 24: user.v1.PurgeUserResponse
+	(*UserEvent)(nil),                     // 25: user.v1.UserEvent
+	(*ListUserEventsRequest)(nil),         // 26: user.v1.ListUserEventsRequest
+	(*ListUserEventsResponse)(nil),        // 27: user.v1.ListUserEventsResponse
+	(*PointsEntry)(nil),                   // 28: user.v1.PointsEntry
+	(*GetPointsLedgerRequest)(nil),        // 29: user.v1.GetPointsLedgerRequest
+	(*GetPointsLedgerResponse)(nil),       // 30: user.v1.GetPointsLedgerResponse
+	(*RedeemPointsRequest)(nil),           // 31: user.v1.RedeemPointsRequest
+	(*RedeemPointsResponse)(nil),          // 32: user.v1.RedeemPointsResponse
+	(*CreditOrderPointsRequest)(nil),      // 33: user.v1.CreditOrderPointsRequest
+	(*CreditOrderPointsResponse)(nil),     // 34: user.v1.CreditOrderPointsResponse
+	(*ReverseOrderPointsRequest)(nil),     // 35: user.v1.ReverseOrderPointsRequest
+	(*ReverseOrderPointsResponse)(nil),    // 36: user.v1.ReverseOrderPointsResponse
+	(*LoyaltySettings)(nil),               // 37: user.v1.LoyaltySettings
+	(*GetLoyaltySettingsRequest)(nil),     // 38: user.v1.GetLoyaltySettingsRequest
+	(*GetLoyaltySettingsResponse)(nil),    // 39: user.v1.GetLoyaltySettingsResponse
+	(*UpdateLoyaltySettingsRequest)(nil),  // 40: user.v1.UpdateLoyaltySettingsRequest
+	(*UpdateLoyaltySettingsResponse)(nil), // 41: user.v1.UpdateLoyaltySettingsResponse
+	(*timestamppb.Timestamp)(nil),         // 42: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),         // 43: google.protobuf.FieldMask
 }
 var file_proto_user_proto_depIdxs = []int32{
-	0,  // 0: user.v1.CreateUserResponse.user:type_name -> user.v1.User
-	0,  // 1: user.v1.GetUserResponse.user:type_name -> user.v1.User
-	0,  // 2: user.v1.GetUsersResponse.users:type_name -> user.v1.User
-	0,  // 3: user.v1.UpdateUserResponse.user:type_name -> user.v1.User
-	1,  // 4: user.v1.UserService.CreateUser:input_type -> user.v1.CreateUserRequest
-	3,  // 5: user.v1.UserService.GetUser:input_type -> user.v1.GetUserRequest
-	5,  // 6: user.v1.UserService.GetUsers:input_type -> user.v1.GetUsersRequest
-	7,  // 7: user.v1.UserService.UpdateUser:input_type -> user.v1.UpdateUserRequest
-	9,  // 8: user.v1.UserService.DeleteUser:input_type -> user.v1.DeleteUserRequest
-	2,  // 9: user.v1.UserService.CreateUser:output_type -> user.v1.CreateUserResponse
-	4,  // 10: user.v1.UserService.GetUser:output_type -> user.v1.GetUserResponse
-	6,  // 11: user.v1.UserService.GetUsers:output_type -> user.v1.GetUsersResponse
-	8,  // 12: user.v1.UserService.UpdateUser:output_type -> user.v1.UpdateUserResponse
-	10, // 13: user.v1.UserService.DeleteUser:output_type -> user.v1.DeleteUserResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	42, // 0: user.v1.User.create_time:type_name -> google.protobuf.Timestamp
+	42, // 1: user.v1.User.update_time:type_name -> google.protobuf.Timestamp
+	42, // 2: user.v1.User.delete_time:type_name -> google.protobuf.Timestamp
+	2,  // 3: user.v1.CreateUserResponse.user:type_name -> user.v1.User
+	2,  // 4: user.v1.GetUserResponse.user:type_name -> user.v1.User
+	2,  // 5: user.v1.GetUsersResponse.users:type_name -> user.v1.User
+	43, // 6: user.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 7: user.v1.UpdateUserResponse.user:type_name -> user.v1.User
+	2,  // 8: user.v1.LoginResponse.user:type_name -> user.v1.User
+	2,  // 9: user.v1.ListDeletedUsersResponse.users:type_name -> user.v1.User
+	2,  // 10: user.v1.RestoreUserResponse.user:type_name -> user.v1.User
+	0,  // 11: user.v1.UserEvent.type:type_name -> user.v1.UserEventType
+	42, // 12: user.v1.UserEvent.event_time:type_name -> google.protobuf.Timestamp
+	25, // 13: user.v1.ListUserEventsResponse.events:type_name -> user.v1.UserEvent
+	1,  // 14: user.v1.PointsEntry.reason:type_name -> user.v1.PointsReason
+	42, // 15: user.v1.PointsEntry.create_time:type_name -> google.protobuf.Timestamp
+	28, // 16: user.v1.GetPointsLedgerResponse.entries:type_name -> user.v1.PointsEntry
+	28, // 17: user.v1.RedeemPointsResponse.entry:type_name -> user.v1.PointsEntry
+	28, // 18: user.v1.CreditOrderPointsResponse.entry:type_name -> user.v1.PointsEntry
+	28, // 19: user.v1.ReverseOrderPointsResponse.entry:type_name -> user.v1.PointsEntry
+	42, // 20: user.v1.LoyaltySettings.update_time:type_name -> google.protobuf.Timestamp
+	37, // 21: user.v1.GetLoyaltySettingsResponse.settings:type_name -> user.v1.LoyaltySettings
+	37, // 22: user.v1.UpdateLoyaltySettingsRequest.settings:type_name -> user.v1.LoyaltySettings
+	37, // 23: user.v1.UpdateLoyaltySettingsResponse.settings:type_name -> user.v1.LoyaltySettings
+	3,  // 24: user.v1.UserService.CreateUser:input_type -> user.v1.CreateUserRequest
+	5,  // 25: user.v1.UserService.GetUser:input_type -> user.v1.GetUserRequest
+	7,  // 26: user.v1.UserService.GetUsers:input_type -> user.v1.GetUsersRequest
+	9,  // 27: user.v1.UserService.UpdateUser:input_type -> user.v1.UpdateUserRequest
+	11, // 28: user.v1.UserService.DeleteUser:input_type -> user.v1.DeleteUserRequest
+	13, // 29: user.v1.UserService.Login:input_type -> user.v1.LoginRequest
+	15, // 30: user.v1.UserService.RefreshToken:input_type -> user.v1.RefreshTokenRequest
+	17, // 31: user.v1.UserService.Logout:input_type -> user.v1.LogoutRequest
+	19, // 32: user.v1.UserService.ListDeletedUsers:input_type -> user.v1.ListDeletedUsersRequest
+	21, // 33: user.v1.UserService.RestoreUser:input_type -> user.v1.RestoreUserRequest
+	23, // 34: user.v1.UserService.PurgeUser:input_type -> user.v1.PurgeUserRequest
+	26, // 35: user.v1.UserService.ListUserEvents:input_type -> user.v1.ListUserEventsRequest
+	29, // 36: user.v1.UserService.GetPointsLedger:input_type -> user.v1.GetPointsLedgerRequest
+	31, // 37: user.v1.UserService.RedeemPoints:input_type -> user.v1.RedeemPointsRequest
+	33, // 38: user.v1.UserService.CreditOrderPoints:input_type -> user.v1.CreditOrderPointsRequest
+	35, // 39: user.v1.UserService.ReverseOrderPoints:input_type -> user.v1.ReverseOrderPointsRequest
+	38, // 40: user.v1.UserService.GetLoyaltySettings:input_type -> user.v1.GetLoyaltySettingsRequest
+	40, // 41: user.v1.UserService.UpdateLoyaltySettings:input_type -> user.v1.UpdateLoyaltySettingsRequest
+	4,  // 42: user.v1.UserService.CreateUser:output_type -> user.v1.CreateUserResponse
+	6,  // 43: user.v1.UserService.GetUser:output_type -> user.v1.GetUserResponse
+	8,  // 44: user.v1.UserService.GetUsers:output_type -> user.v1.GetUsersResponse
+	10, // 45: user.v1.UserService.UpdateUser:output_type -> user.v1.UpdateUserResponse
+	12, // 46: user.v1.UserService.DeleteUser:output_type -> user.v1.DeleteUserResponse
+	14, // 47: user.v1.UserService.Login:output_type -> user.v1.LoginResponse
+	16, // 48: user.v1.UserService.RefreshToken:output_type -> user.v1.RefreshTokenResponse
+	18, // 49: user.v1.UserService.Logout:output_type -> user.v1.LogoutResponse
+	20, // 50: user.v1.UserService.ListDeletedUsers:output_type -> user.v1.ListDeletedUsersResponse
+	22, // 51: user.v1.UserService.RestoreUser:output_type -> user.v1.RestoreUserResponse
+	24, // 52: user.v1.UserService.PurgeUser:output_type -> user.v1.PurgeUserResponse
+	27, // 53: user.v1.UserService.ListUserEvents:output_type -> user.v1.ListUserEventsResponse
+	30, // 54: user.v1.UserService.GetPointsLedger:output_type -> user.v1.GetPointsLedgerResponse
+	32, // 55: user.v1.UserService.RedeemPoints:output_type -> user.v1.RedeemPointsResponse
+	34, // 56: user.v1.UserService.CreditOrderPoints:output_type -> user.v1.CreditOrderPointsResponse
+	36, // 57: user.v1.UserService.ReverseOrderPoints:output_type -> user.v1.ReverseOrderPointsResponse
+	39, // 58: user.v1.UserService.GetLoyaltySettings:output_type -> user.v1.GetLoyaltySettingsResponse
+	41, // 59: user.v1.UserService.UpdateLoyaltySettings:output_type -> user.v1.UpdateLoyaltySettingsResponse
+	42, // [42:60] is the sub-list for method output_type
+	24, // [24:42] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_proto_init() }
@@ -678,18 +2713,20 @@ func file_proto_user_proto_init() {
 	if File_proto_user_proto != nil {
 		return
 	}
+	file_proto_user_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_proto_rawDesc), len(file_proto_user_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_user_proto_goTypes,
 		DependencyIndexes: file_proto_user_proto_depIdxs,
+		EnumInfos:         file_proto_user_proto_enumTypes,
 		MessageInfos:      file_proto_user_proto_msgTypes,
 	}.Build()
 	File_proto_user_proto = out.File
@@ -19,11 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_CreateUser_FullMethodName = "/user.v1.UserService/CreateUser"
-	UserService_GetUser_FullMethodName    = "/user.v1.UserService/GetUser"
-	UserService_GetUsers_FullMethodName   = "/user.v1.UserService/GetUsers"
-	UserService_UpdateUser_FullMethodName = "/user.v1.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName = "/user.v1.UserService/DeleteUser"
+	UserService_CreateUser_FullMethodName            = "/user.v1.UserService/CreateUser"
+	UserService_GetUser_FullMethodName               = "/user.v1.UserService/GetUser"
+	UserService_GetUsers_FullMethodName              = "/user.v1.UserService/GetUsers"
+	UserService_UpdateUser_FullMethodName            = "/user.v1.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName            = "/user.v1.UserService/DeleteUser"
+	UserService_Login_FullMethodName                 = "/user.v1.UserService/Login"
+	UserService_RefreshToken_FullMethodName          = "/user.v1.UserService/RefreshToken"
+	UserService_Logout_FullMethodName                = "/user.v1.UserService/Logout"
+	UserService_ListDeletedUsers_FullMethodName      = "/user.v1.UserService/ListDeletedUsers"
+	UserService_RestoreUser_FullMethodName           = "/user.v1.UserService/RestoreUser"
+	UserService_PurgeUser_FullMethodName             = "/user.v1.UserService/PurgeUser"
+	UserService_ListUserEvents_FullMethodName        = "/user.v1.UserService/ListUserEvents"
+	UserService_GetPointsLedger_FullMethodName       = "/user.v1.UserService/GetPointsLedger"
+	UserService_RedeemPoints_FullMethodName          = "/user.v1.UserService/RedeemPoints"
+	UserService_CreditOrderPoints_FullMethodName     = "/user.v1.UserService/CreditOrderPoints"
+	UserService_ReverseOrderPoints_FullMethodName    = "/user.v1.UserService/ReverseOrderPoints"
+	UserService_GetLoyaltySettings_FullMethodName    = "/user.v1.UserService/GetLoyaltySettings"
+	UserService_UpdateLoyaltySettings_FullMethodName = "/user.v1.UserService/UpdateLoyaltySettings"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -35,6 +48,47 @@ type UserServiceClient interface {
 	GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (*GetUsersResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	// Login exchanges an email and password for an access token and a
+	// refresh token.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// RefreshToken exchanges a refresh token for a new access token. The
+	// refresh token is rotated: the one sent is revoked and a new one returned.
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// Logout revokes a refresh token. Unknown or revoked tokens are ignored.
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	// ListDeletedUsers lists users that have been deleted but not yet purged.
+	ListDeletedUsers(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error)
+	// RestoreUser undoes DeleteUser. It fails with AlreadyExists when another
+	// user has registered the email since.
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	// PurgeUser permanently removes a deleted user. Deleted users are also
+	// purged automatically once the retention period has passed.
+	PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error)
+	// ListUserEvents returns user lifecycle events after after_sequence,
+	// oldest first, so other services can follow deletions. Only cafe
+	// services may call it.
+	ListUserEvents(ctx context.Context, in *ListUserEventsRequest, opts ...grpc.CallOption) (*ListUserEventsResponse, error)
+	// GetPointsLedger lists a user's loyalty points entries, oldest first,
+	// with the current balance.
+	GetPointsLedger(ctx context.Context, in *GetPointsLedgerRequest, opts ...grpc.CallOption) (*GetPointsLedgerResponse, error)
+	// RedeemPoints spends a customer's points on a discount for their order.
+	// It fails with FAILED_PRECONDITION when the balance is too low. An order
+	// can redeem points only once. Only cafe services may call it; customers
+	// redeem points through CreateOrder.
+	RedeemPoints(ctx context.Context, in *RedeemPointsRequest, opts ...grpc.CallOption) (*RedeemPointsResponse, error)
+	// CreditOrderPoints awards points for a completed order at the current
+	// earn rate. Crediting the same order again has no effect. Only cafe
+	// services may call it.
+	CreditOrderPoints(ctx context.Context, in *CreditOrderPointsRequest, opts ...grpc.CallOption) (*CreditOrderPointsResponse, error)
+	// ReverseOrderPoints takes back the points a customer's order earned and
+	// returns the points it redeemed, once the order is cancelled or refunded. The
+	// balance may go negative if earned points have been spent. Reversing the
+	// same order again has no effect. Only cafe services may call it.
+	ReverseOrderPoints(ctx context.Context, in *ReverseOrderPointsRequest, opts ...grpc.CallOption) (*ReverseOrderPointsResponse, error)
+	GetLoyaltySettings(ctx context.Context, in *GetLoyaltySettingsRequest, opts ...grpc.CallOption) (*GetLoyaltySettingsResponse, error)
+	// UpdateLoyaltySettings changes the earn rate and point value. Entries
+	// already in the ledger are not recalculated.
+	UpdateLoyaltySettings(ctx context.Context, in *UpdateLoyaltySettingsRequest, opts ...grpc.CallOption) (*UpdateLoyaltySettingsResponse, error)
 }
 
 type userServiceClient struct {
@@ -95,6 +149,136 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, UserService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDeletedUsers(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDeletedUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, UserService_RestoreUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeUserResponse)
+	err := c.cc.Invoke(ctx, UserService_PurgeUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUserEvents(ctx context.Context, in *ListUserEventsRequest, opts ...grpc.CallOption) (*ListUserEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserEventsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPointsLedger(ctx context.Context, in *GetPointsLedgerRequest, opts ...grpc.CallOption) (*GetPointsLedgerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPointsLedgerResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPointsLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RedeemPoints(ctx context.Context, in *RedeemPointsRequest, opts ...grpc.CallOption) (*RedeemPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_RedeemPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreditOrderPoints(ctx context.Context, in *CreditOrderPointsRequest, opts ...grpc.CallOption) (*CreditOrderPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreditOrderPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_CreditOrderPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReverseOrderPoints(ctx context.Context, in *ReverseOrderPointsRequest, opts ...grpc.CallOption) (*ReverseOrderPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseOrderPointsResponse)
+	err := c.cc.Invoke(ctx, UserService_ReverseOrderPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLoyaltySettings(ctx context.Context, in *GetLoyaltySettingsRequest, opts ...grpc.CallOption) (*GetLoyaltySettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLoyaltySettingsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLoyaltySettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateLoyaltySettings(ctx context.Context, in *UpdateLoyaltySettingsRequest, opts ...grpc.CallOption) (*UpdateLoyaltySettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLoyaltySettingsResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateLoyaltySettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -104,6 +288,47 @@ type UserServiceServer interface {
 	GetUsers(context.Context, *GetUsersRequest) (*GetUsersResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// Login exchanges an email and password for an access token and a
+	// refresh token.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// RefreshToken exchanges a refresh token for a new access token. The
+	// refresh token is rotated: the one sent is revoked and a new one returned.
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// Logout revokes a refresh token. Unknown or revoked tokens are ignored.
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// ListDeletedUsers lists users that have been deleted but not yet purged.
+	ListDeletedUsers(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersResponse, error)
+	// RestoreUser undoes DeleteUser. It fails with AlreadyExists when another
+	// user has registered the email since.
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	// PurgeUser permanently removes a deleted user. Deleted users are also
+	// purged automatically once the retention period has passed.
+	PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error)
+	// ListUserEvents returns user lifecycle events after after_sequence,
+	// oldest first, so other services can follow deletions. Only cafe
+	// services may call it.
+	ListUserEvents(context.Context, *ListUserEventsRequest) (*ListUserEventsResponse, error)
+	// GetPointsLedger lists a user's loyalty points entries, oldest first,
+	// with the current balance.
+	GetPointsLedger(context.Context, *GetPointsLedgerRequest) (*GetPointsLedgerResponse, error)
+	// RedeemPoints spends a customer's points on a discount for their order.
+	// It fails with FAILED_PRECONDITION when the balance is too low. An order
+	// can redeem points only once. Only cafe services may call it; customers
+	// redeem points through CreateOrder.
+	RedeemPoints(context.Context, *RedeemPointsRequest) (*RedeemPointsResponse, error)
+	// CreditOrderPoints awards points for a completed order at the current
+	// earn rate. Crediting the same order again has no effect. Only cafe
+	// services may call it.
+	CreditOrderPoints(context.Context, *CreditOrderPointsRequest) (*CreditOrderPointsResponse, error)
+	// ReverseOrderPoints takes back the points a customer's order earned and
+	// returns the points it redeemed, once the order is cancelled or refunded. The
+	// balance may go negative if earned points have been spent. Reversing the
+	// same order again has no effect. Only cafe services may call it.
+	ReverseOrderPoints(context.Context, *ReverseOrderPointsRequest) (*ReverseOrderPointsResponse, error)
+	GetLoyaltySettings(context.Context, *GetLoyaltySettingsRequest) (*GetLoyaltySettingsResponse, error)
+	// UpdateLoyaltySettings changes the earn rate and point value. Entries
+	// already in the ledger are not recalculated.
+	UpdateLoyaltySettings(context.Context, *UpdateLoyaltySettingsRequest) (*UpdateLoyaltySettingsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -129,6 +354,45 @@ func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserReq
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeletedUsers(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedUsers not implemented")
+}
+func (UnimplementedUserServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedUserServiceServer) PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListUserEvents(context.Context, *ListUserEventsRequest) (*ListUserEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserEvents not implemented")
+}
+func (UnimplementedUserServiceServer) GetPointsLedger(context.Context, *GetPointsLedgerRequest) (*GetPointsLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPointsLedger not implemented")
+}
+func (UnimplementedUserServiceServer) RedeemPoints(context.Context, *RedeemPointsRequest) (*RedeemPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemPoints not implemented")
+}
+func (UnimplementedUserServiceServer) CreditOrderPoints(context.Context, *CreditOrderPointsRequest) (*CreditOrderPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreditOrderPoints not implemented")
+}
+func (UnimplementedUserServiceServer) ReverseOrderPoints(context.Context, *ReverseOrderPointsRequest) (*ReverseOrderPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseOrderPoints not implemented")
+}
+func (UnimplementedUserServiceServer) GetLoyaltySettings(context.Context, *GetLoyaltySettingsRequest) (*GetLoyaltySettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoyaltySettings not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateLoyaltySettings(context.Context, *UpdateLoyaltySettingsRequest) (*UpdateLoyaltySettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLoyaltySettings not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +504,240 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDeletedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeletedUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, req.(*ListDeletedUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RestoreUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PurgeUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PurgeUser(ctx, req.(*PurgeUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUserEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserEvents(ctx, req.(*ListUserEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPointsLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPointsLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPointsLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPointsLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPointsLedger(ctx, req.(*GetPointsLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RedeemPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RedeemPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RedeemPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RedeemPoints(ctx, req.(*RedeemPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreditOrderPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreditOrderPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreditOrderPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreditOrderPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreditOrderPoints(ctx, req.(*CreditOrderPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReverseOrderPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseOrderPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReverseOrderPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReverseOrderPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReverseOrderPoints(ctx, req.(*ReverseOrderPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLoyaltySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoyaltySettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLoyaltySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLoyaltySettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLoyaltySettings(ctx, req.(*GetLoyaltySettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateLoyaltySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLoyaltySettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateLoyaltySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateLoyaltySettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateLoyaltySettings(ctx, req.(*UpdateLoyaltySettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +765,58 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
+		{
+			MethodName: "ListDeletedUsers",
+			Handler:    _UserService_ListDeletedUsers_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserService_RestoreUser_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _UserService_PurgeUser_Handler,
+		},
+		{
+			MethodName: "ListUserEvents",
+			Handler:    _UserService_ListUserEvents_Handler,
+		},
+		{
+			MethodName: "GetPointsLedger",
+			Handler:    _UserService_GetPointsLedger_Handler,
+		},
+		{
+			MethodName: "RedeemPoints",
+			Handler:    _UserService_RedeemPoints_Handler,
+		},
+		{
+			MethodName: "CreditOrderPoints",
+			Handler:    _UserService_CreditOrderPoints_Handler,
+		},
+		{
+			MethodName: "ReverseOrderPoints",
+			Handler:    _UserService_ReverseOrderPoints_Handler,
+		},
+		{
+			MethodName: "GetLoyaltySettings",
+			Handler:    _UserService_GetLoyaltySettings_Handler,
+		},
+		{
+			MethodName: "UpdateLoyaltySettings",
+			Handler:    _UserService_UpdateLoyaltySettings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user.proto",
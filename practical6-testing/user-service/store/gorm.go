@@ -0,0 +1,326 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"eventseq"
+	"log"
+	"pagination"
+	"strings"
+	"time"
+	"user-service/models"
+
+	"gorm.io/gorm"
+)
+
+// outboxFeed numbers the outbox events.
+const outboxFeed = "user_events"
+
+// GormStore keeps users in a SQL database through GORM. It works on both
+// Postgres and SQLite.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore wraps db, which must already be migrated.
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// Migrate creates or updates the tables used by GormStore.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.OutboxEvent{},
+		&models.PointsEntry{}, &models.LoyaltySettings{}); err != nil {
+		return err
+	}
+	if err := eventseq.Migrate(db, outboxFeed, &models.OutboxEvent{}); err != nil {
+		return err
+	}
+	if err := dropLegacyEmailIndex(db); err != nil {
+		return err
+	}
+	if err := dropLegacyPointsIndex(db); err != nil {
+		return err
+	}
+	return lowercaseEmails(db)
+}
+
+// lowercaseEmails lower-cases the emails stored before UserServer
+// normalised them, so that idx_users_email_active also rejects addresses
+// that differ only in case. Active users whose addresses clash once
+// lower-cased are left alone and logged to be merged by hand.
+func lowercaseEmails(db *gorm.DB) error {
+	err := db.Exec(`UPDATE users SET email = LOWER(email)
+		WHERE email <> LOWER(email) AND (deleted_at IS NOT NULL OR NOT EXISTS (
+			SELECT 1 FROM users other
+			WHERE other.id <> users.id AND other.deleted_at IS NULL AND LOWER(other.email) = LOWER(users.email)))`).Error
+	if err != nil {
+		return err
+	}
+
+	var clashes []string
+	if err := db.Model(&models.User{}).Where("email <> LOWER(email)").Pluck("email", &clashes).Error; err != nil {
+		return err
+	}
+	if len(clashes) > 0 {
+		log.Printf("Users %q share an email with another user once lower-cased and cannot log in until merged", clashes)
+	}
+	return nil
+}
+
+// dropLegacyEmailIndex drops the unique index on users.email that predates
+// soft-delete recovery. It also covered deleted users, which blocked their
+// address from being registered again.
+func dropLegacyEmailIndex(db *gorm.DB) error {
+	const legacyIndex = "idx_users_email"
+	if !db.Migrator().HasIndex(&models.User{}, legacyIndex) {
+		return nil
+	}
+	log.Printf("Dropping index %s in favour of idx_users_email_active", legacyIndex)
+	return db.Migrator().DropIndex(&models.User{}, legacyIndex)
+}
+
+// dropLegacyPointsIndex drops the unique index that allowed one entry per
+// order and reason whoever the user, so that any customer could block
+// another's redemption by using their order ID first.
+func dropLegacyPointsIndex(db *gorm.DB) error {
+	const legacyIndex = "idx_points_entries_order_reason"
+	if !db.Migrator().HasIndex(&models.PointsEntry{}, legacyIndex) {
+		return nil
+	}
+	log.Printf("Dropping index %s in favour of idx_points_entries_user_order_reason", legacyIndex)
+	return db.Migrator().DropIndex(&models.PointsEntry{}, legacyIndex)
+}
+
+func (s *GormStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *GormStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (s *GormStore) Transaction(ctx context.Context, fn func(tx UserStore) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormStore{db: tx})
+	})
+}
+
+func (s *GormStore) CreateUser(ctx context.Context, user *models.User) error {
+	return s.db.WithContext(ctx).Create(user).Error
+}
+
+func (s *GormStore) GetUser(ctx context.Context, id uint) (models.User, error) {
+	var user models.User
+	err := s.db.WithContext(ctx).First(&user, id).Error
+	return user, err
+}
+
+func (s *GormStore) GetUserIncludingDeleted(ctx context.Context, id uint) (models.User, error) {
+	var user models.User
+	err := s.db.WithContext(ctx).Unscoped().First(&user, id).Error
+	return user, err
+}
+
+func (s *GormStore) FindUserByEmail(ctx context.Context, email string) (models.User, error) {
+	var user models.User
+	err := s.db.WithContext(ctx).Where("email = ?", strings.ToLower(email)).First(&user).Error
+	return user, err
+}
+
+func (s *GormStore) ListUsers(ctx context.Context, filter UserFilter, page Page) ([]models.User, error) {
+	query := s.db.WithContext(ctx)
+	if filter.IsCafeOwner != nil {
+		query = query.Where("is_cafe_owner = ?", *filter.IsCafeOwner)
+	}
+	if filter.EmailPrefix != "" {
+		query = query.Where(`LOWER(email) LIKE ? ESCAPE '\'`, strings.ToLower(pagination.EscapeLike(filter.EmailPrefix))+"%")
+	}
+
+	var users []models.User
+	err := pagination.Apply(query, page).Find(&users).Error
+	return users, err
+}
+
+func (s *GormStore) ListDeletedUsers(ctx context.Context, page Page) ([]models.User, error) {
+	var users []models.User
+	err := pagination.Apply(s.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL"), page).Find(&users).Error
+	return users, err
+}
+
+func (s *GormStore) UpdateUser(ctx context.Context, user *models.User) error {
+	return s.db.WithContext(ctx).Omit("points_balance").Save(user).Error
+}
+
+func (s *GormStore) DeleteUser(ctx context.Context, id uint) (bool, error) {
+	result := s.db.WithContext(ctx).Delete(&models.User{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) RestoreUser(ctx context.Context, id uint) (bool, error) {
+	// Guarded on deleted_at so a concurrent restore is not applied twice
+	result := s.db.WithContext(ctx).Unscoped().Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) PurgeDeletedUsers(ctx context.Context, cutoff time.Time) (int64, error) {
+	return s.purgeUsers(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at <= ?", cutoff)
+	})
+}
+
+func (s *GormStore) PurgeUser(ctx context.Context, id uint) (int64, error) {
+	return s.purgeUsers(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+}
+
+// purgeUsers hard-deletes the soft-deleted users selected by scope.
+func (s *GormStore) purgeUsers(ctx context.Context, scope func(*gorm.DB) *gorm.DB) (int64, error) {
+	var purged int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Unscoped().Model(&models.User{}).Scopes(scope).
+			Where("deleted_at IS NOT NULL").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.PointsEntry{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&models.User{}, ids)
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		for _, id := range ids {
+			if err := recordEvent(tx, models.EventUserPurged, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+func (s *GormStore) RecordEvent(ctx context.Context, eventType string, userID uint) error {
+	return recordEvent(s.db.WithContext(ctx), eventType, userID)
+}
+
+// recordEvent numbers the event from the outbox feed's counter, whose row
+// stays locked until tx commits. Events therefore become visible in sequence
+// order and a reader paging by sequence never skips one still committing.
+func recordEvent(tx *gorm.DB, eventType string, userID uint) error {
+	return tx.Transaction(func(tx *gorm.DB) error {
+		sequence, err := eventseq.Next(tx, outboxFeed)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.OutboxEvent{Sequence: sequence, Type: eventType, UserID: userID}).Error
+	})
+}
+
+func (s *GormStore) ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.WithContext(ctx).Where("sequence > ?", afterSequence).Order("sequence").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (s *GormStore) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return s.db.WithContext(ctx).Create(token).Error
+}
+
+func (s *GormStore) FindRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := s.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	return token, err
+}
+
+func (s *GormStore) RevokeRefreshToken(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("revoked_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *GormStore) AppendPoints(ctx context.Context, entry *models.PointsEntry) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		update := tx.Unscoped().Model(&models.User{}).Where("id = ?", entry.UserID)
+		if entry.Reason == models.PointsRedeemed {
+			update = update.Where("points_balance + ? >= 0", entry.Delta)
+		}
+		result := update.Update("points_balance", gorm.Expr("points_balance + ?", entry.Delta))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var exists int64
+			if err := tx.Unscoped().Model(&models.User{}).Where("id = ?", entry.UserID).Count(&exists).Error; err != nil {
+				return err
+			}
+			if exists == 0 {
+				return ErrNotFound
+			}
+			return ErrInsufficientPoints
+		}
+
+		if err := tx.Unscoped().Model(&models.User{}).Where("id = ?", entry.UserID).
+			Pluck("points_balance", &entry.BalanceAfter).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (s *GormStore) OrderPointsEntry(ctx context.Context, userID, orderID uint, reason string) (*models.PointsEntry, error) {
+	var entries []models.PointsEntry
+	if err := s.db.WithContext(ctx).Where("user_id = ? AND order_id = ? AND reason = ?", userID, orderID, reason).
+		Limit(1).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+func (s *GormStore) ListOrderPointsEntries(ctx context.Context, userID, orderID uint) ([]models.PointsEntry, error) {
+	var entries []models.PointsEntry
+	err := s.db.WithContext(ctx).Where("user_id = ? AND order_id = ?", userID, orderID).Order("id").Find(&entries).Error
+	return entries, err
+}
+
+func (s *GormStore) ListPointsEntries(ctx context.Context, userID uint, page Page) ([]models.PointsEntry, error) {
+	var entries []models.PointsEntry
+	err := pagination.Apply(s.db.WithContext(ctx).Where("user_id = ?", userID), page).Find(&entries).Error
+	return entries, err
+}
+
+func (s *GormStore) LoyaltySettings(ctx context.Context) (models.LoyaltySettings, error) {
+	var settings models.LoyaltySettings
+	err := s.db.WithContext(ctx).First(&settings, loyaltySettingsID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultLoyaltySettings, nil
+	}
+	return settings, err
+}
+
+func (s *GormStore) SaveLoyaltySettings(ctx context.Context, settings *models.LoyaltySettings) error {
+	settings.ID = loyaltySettingsID
+	return s.db.WithContext(ctx).Save(settings).Error
+}
@@ -0,0 +1,449 @@
+package store
+
+import (
+	"context"
+	"dberr"
+	"maps"
+	"slices"
+	"storedriver"
+	"strings"
+	"time"
+	"user-service/models"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStore keeps users in process memory for development and tests.
+type MemoryStore struct {
+	storedriver.Memory
+	data *memoryData
+}
+
+// memoryData is the content of a MemoryStore. Records are stored by value
+// and replaced rather than modified, so a shallow copy of the maps is a
+// snapshot.
+type memoryData struct {
+	users    map[uint]models.User
+	tokens   map[uint]models.RefreshToken
+	events   []models.OutboxEvent
+	entries  map[uint]models.PointsEntry
+	settings *models.LoyaltySettings
+	ids      storedriver.MemoryIDs
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Memory: storedriver.NewMemory(),
+		data: &memoryData{
+			users:   make(map[uint]models.User),
+			tokens:  make(map[uint]models.RefreshToken),
+			entries: make(map[uint]models.PointsEntry),
+			ids:     storedriver.MemoryIDs{},
+		},
+	}
+}
+
+func (d memoryData) Clone() memoryData {
+	return memoryData{
+		users:    maps.Clone(d.users),
+		tokens:   maps.Clone(d.tokens),
+		events:   slices.Clone(d.events),
+		entries:  maps.Clone(d.entries),
+		settings: d.settings,
+		ids:      maps.Clone(d.ids),
+	}
+}
+
+func (s *MemoryStore) Transaction(ctx context.Context, fn func(tx UserStore) error) error {
+	return storedriver.MemoryTransaction(ctx, s.Memory, s.data, func(tx storedriver.Memory) error {
+		return fn(&MemoryStore{Memory: tx, data: s.data})
+	})
+}
+
+func (s *MemoryStore) CreateUser(ctx context.Context, user *models.User) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.emailTaken(user.Email, 0) {
+		return &dberr.UniqueViolation{Field: "email"}
+	}
+	now := time.Now()
+	user.ID = s.data.ids.Next("users")
+	user.CreatedAt, user.UpdatedAt = now, now
+	s.data.users[user.ID] = *user
+	return nil
+}
+
+// emailTaken reports whether a user other than id that has not been deleted
+// uses email.
+func (s *MemoryStore) emailTaken(email string, id uint) bool {
+	for _, user := range s.data.users {
+		if user.ID != id && !user.DeletedAt.Valid && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryStore) GetUser(ctx context.Context, id uint) (models.User, error) {
+	user, err := s.GetUserIncludingDeleted(ctx, id)
+	if err == nil && user.DeletedAt.Valid {
+		return models.User{}, ErrNotFound
+	}
+	return user, err
+}
+
+func (s *MemoryStore) GetUserIncludingDeleted(ctx context.Context, id uint) (models.User, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.User{}, err
+	}
+	defer unlock()
+
+	user, ok := s.data.users[id]
+	if !ok {
+		return models.User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+func (s *MemoryStore) FindUserByEmail(ctx context.Context, email string) (models.User, error) {
+	users, err := s.listUsers(ctx, Page{}, func(user models.User) bool {
+		return !user.DeletedAt.Valid && strings.EqualFold(user.Email, email)
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	if len(users) == 0 {
+		return models.User{}, ErrNotFound
+	}
+	return users[0], nil
+}
+
+func (s *MemoryStore) ListUsers(ctx context.Context, filter UserFilter, page Page) ([]models.User, error) {
+	prefix := strings.ToLower(filter.EmailPrefix)
+	return s.listUsers(ctx, page, func(user models.User) bool {
+		return !user.DeletedAt.Valid &&
+			(filter.IsCafeOwner == nil || user.IsCafeOwner == *filter.IsCafeOwner) &&
+			strings.HasPrefix(strings.ToLower(user.Email), prefix)
+	})
+}
+
+func (s *MemoryStore) ListDeletedUsers(ctx context.Context, page Page) ([]models.User, error) {
+	return s.listUsers(ctx, page, func(user models.User) bool {
+		return user.DeletedAt.Valid
+	})
+}
+
+// listUsers returns the users matching keep in ID order, limited to page
+// when it has a limit.
+func (s *MemoryStore) listUsers(ctx context.Context, page Page, keep func(models.User) bool) ([]models.User, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var users []models.User
+	for _, id := range slices.Sorted(maps.Keys(s.data.users)) {
+		if id <= page.AfterID || !keep(s.data.users[id]) {
+			continue
+		}
+		users = append(users, s.data.users[id])
+		if len(users) == page.Limit {
+			break
+		}
+	}
+	return users, nil
+}
+
+func (s *MemoryStore) UpdateUser(ctx context.Context, user *models.User) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	stored, ok := s.data.users[user.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	if !user.DeletedAt.Valid && s.emailTaken(user.Email, user.ID) {
+		return &dberr.UniqueViolation{Field: "email"}
+	}
+	user.UpdatedAt = time.Now()
+	updated := *user
+	updated.PointsBalance = stored.PointsBalance
+	s.data.users[user.ID] = updated
+	return nil
+}
+
+func (s *MemoryStore) DeleteUser(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	user, ok := s.data.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return false, nil
+	}
+	user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	s.data.users[id] = user
+	return true, nil
+}
+
+func (s *MemoryStore) RestoreUser(ctx context.Context, id uint) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	user, ok := s.data.users[id]
+	if !ok || !user.DeletedAt.Valid {
+		return false, nil
+	}
+	if s.emailTaken(user.Email, id) {
+		return false, &dberr.UniqueViolation{Field: "email"}
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	s.data.users[id] = user
+	return true, nil
+}
+
+func (s *MemoryStore) PurgeDeletedUsers(ctx context.Context, cutoff time.Time) (int64, error) {
+	return s.purgeUsers(ctx, func(user models.User) bool {
+		return !user.DeletedAt.Time.After(cutoff)
+	})
+}
+
+func (s *MemoryStore) PurgeUser(ctx context.Context, id uint) (int64, error) {
+	return s.purgeUsers(ctx, func(user models.User) bool {
+		return user.ID == id
+	})
+}
+
+// purgeUsers hard-deletes the soft-deleted users selected by match.
+func (s *MemoryStore) purgeUsers(ctx context.Context, match func(models.User) bool) (int64, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var purged int64
+	for _, id := range slices.Sorted(maps.Keys(s.data.users)) {
+		user := s.data.users[id]
+		if !user.DeletedAt.Valid || !match(user) {
+			continue
+		}
+		maps.DeleteFunc(s.data.tokens, func(_ uint, token models.RefreshToken) bool { return token.UserID == id })
+		maps.DeleteFunc(s.data.entries, func(_ uint, entry models.PointsEntry) bool { return entry.UserID == id })
+		delete(s.data.users, id)
+		s.recordEvent(models.EventUserPurged, id)
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *MemoryStore) RecordEvent(ctx context.Context, eventType string, userID uint) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.recordEvent(eventType, userID)
+	return nil
+}
+
+func (s *MemoryStore) recordEvent(eventType string, userID uint) {
+	// The store lock serialises writers, so IDs already follow commit order.
+	id := s.data.ids.Next("outbox_events")
+	s.data.events = append(s.data.events, models.OutboxEvent{
+		ID:        id,
+		Sequence:  uint64(id),
+		Type:      eventType,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *MemoryStore) ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var events []models.OutboxEvent
+	for _, event := range s.data.events {
+		if event.Sequence > afterSequence && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, existing := range s.data.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return &dberr.UniqueViolation{Field: "token_hash"}
+		}
+	}
+	token.ID = s.data.ids.Next("refresh_tokens")
+	token.CreatedAt = time.Now()
+	s.data.tokens[token.ID] = *token
+	return nil
+}
+
+func (s *MemoryStore) FindRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.RefreshToken{}, err
+	}
+	defer unlock()
+
+	for _, token := range s.data.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return models.RefreshToken{}, ErrNotFound
+}
+
+func (s *MemoryStore) RevokeRefreshToken(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	for id, token := range s.data.tokens {
+		if token.TokenHash != tokenHash || token.RevokedAt != nil || !token.ExpiresAt.After(now) {
+			continue
+		}
+		token.RevokedAt = &now
+		s.data.tokens[id] = token
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *MemoryStore) AppendPoints(ctx context.Context, entry *models.PointsEntry) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	user, ok := s.data.users[entry.UserID]
+	if !ok {
+		return ErrNotFound
+	}
+	balance := user.PointsBalance + entry.Delta
+	if entry.Reason == models.PointsRedeemed && balance < 0 {
+		return ErrInsufficientPoints
+	}
+	if entry.OrderID != nil {
+		for _, existing := range s.data.entries {
+			if existing.UserID == entry.UserID && existing.OrderID != nil && *existing.OrderID == *entry.OrderID &&
+				existing.Reason == entry.Reason {
+				return &dberr.UniqueViolation{Field: "user_id, reason, order_id"}
+			}
+		}
+	}
+
+	user.PointsBalance = balance
+	s.data.users[user.ID] = user
+	entry.ID = s.data.ids.Next("points_entries")
+	entry.BalanceAfter = balance
+	entry.CreatedAt = time.Now()
+	s.data.entries[entry.ID] = *entry
+	return nil
+}
+
+func (s *MemoryStore) OrderPointsEntry(ctx context.Context, userID, orderID uint, reason string) (*models.PointsEntry, error) {
+	entries, err := s.ListOrderPointsEntries(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Reason == reason {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) ListOrderPointsEntries(ctx context.Context, userID, orderID uint) ([]models.PointsEntry, error) {
+	return s.listEntries(ctx, Page{}, func(entry models.PointsEntry) bool {
+		return entry.UserID == userID && entry.OrderID != nil && *entry.OrderID == orderID
+	})
+}
+
+func (s *MemoryStore) ListPointsEntries(ctx context.Context, userID uint, page Page) ([]models.PointsEntry, error) {
+	return s.listEntries(ctx, page, func(entry models.PointsEntry) bool {
+		return entry.UserID == userID
+	})
+}
+
+// listEntries returns the points entries matching keep in ID order, limited
+// to page when it has a limit.
+func (s *MemoryStore) listEntries(ctx context.Context, page Page, keep func(models.PointsEntry) bool) ([]models.PointsEntry, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var entries []models.PointsEntry
+	for _, id := range slices.Sorted(maps.Keys(s.data.entries)) {
+		if id <= page.AfterID || !keep(s.data.entries[id]) {
+			continue
+		}
+		entries = append(entries, s.data.entries[id])
+		if len(entries) == page.Limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+func (s *MemoryStore) LoyaltySettings(ctx context.Context) (models.LoyaltySettings, error) {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return models.LoyaltySettings{}, err
+	}
+	defer unlock()
+
+	if s.data.settings == nil {
+		return DefaultLoyaltySettings, nil
+	}
+	return *s.data.settings, nil
+}
+
+func (s *MemoryStore) SaveLoyaltySettings(ctx context.Context, settings *models.LoyaltySettings) error {
+	unlock, err := s.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	settings.ID = loyaltySettingsID
+	settings.UpdatedAt = time.Now()
+	saved := *settings
+	s.data.settings = &saved
+	return nil
+}
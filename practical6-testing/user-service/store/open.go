@@ -0,0 +1,19 @@
+package store
+
+import (
+	"storedriver"
+
+	"gorm.io/gorm"
+)
+
+// Backends builds an UserStore on each store driver.
+var Backends = storedriver.Backends[UserStore]{
+	Migrate:   Migrate,
+	NewGorm:   func(db *gorm.DB) UserStore { return NewGormStore(db) },
+	NewMemory: func() UserStore { return NewMemoryStore() },
+}
+
+// Open opens the user store selected by cfg.
+func Open(cfg storedriver.Config) (UserStore, error) {
+	return storedriver.Open(cfg, Backends)
+}
@@ -0,0 +1,109 @@
+// Package store persists users with their refresh tokens, lifecycle events
+// and loyalty points. The gRPC handlers depend only on UserStore.
+package store
+
+import (
+	"context"
+	"errors"
+	"pagination"
+	"storedriver"
+	"time"
+	"user-service/models"
+)
+
+// ErrNotFound is returned when no row matches.
+var ErrNotFound = storedriver.ErrNotFound
+
+// ErrInsufficientPoints is returned by AppendPoints when a redemption would
+// take the balance below zero.
+var ErrInsufficientPoints = errors.New("insufficient points")
+
+// DefaultDeletedRetention is how long deleted users can be restored before
+// they are purged.
+const DefaultDeletedRetention = 30 * 24 * time.Hour
+
+// DefaultLoyaltySettings apply until a cafe owner changes them: one point
+// per currency unit spent, each worth one minor unit.
+var DefaultLoyaltySettings = models.LoyaltySettings{
+	ID:                    loyaltySettingsID,
+	PointsPerCurrencyUnit: 1,
+	PointValueCents:       1,
+}
+
+// loyaltySettingsID is the primary key of the only loyalty settings row.
+const loyaltySettingsID = 1
+
+// Page selects the rows a list reads, in ID order.
+type Page = pagination.Page
+
+// UserFilter narrows ListUsers. Zero fields match every user.
+type UserFilter struct {
+	IsCafeOwner *bool
+	// EmailPrefix matches the start of the email case-insensitively, with
+	// no wildcards.
+	EmailPrefix string
+}
+
+// UserStore is the persistence used by the user service. Reads of users
+// skip deleted ones unless the method says otherwise.
+type UserStore interface {
+	storedriver.Store[UserStore]
+
+	// CreateUser inserts user and fills in its ID and timestamps. Emails
+	// are unique among users that have not been deleted.
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUser(ctx context.Context, id uint) (models.User, error)
+	// GetUserIncludingDeleted also returns soft-deleted users.
+	GetUserIncludingDeleted(ctx context.Context, id uint) (models.User, error)
+	// FindUserByEmail matches the email case-insensitively. Emails are
+	// stored lower-cased.
+	FindUserByEmail(ctx context.Context, email string) (models.User, error)
+	ListUsers(ctx context.Context, filter UserFilter, page Page) ([]models.User, error)
+	ListDeletedUsers(ctx context.Context, page Page) ([]models.User, error)
+	// UpdateUser saves every field but the points balance, which only
+	// AppendPoints changes, so a concurrent credit is never overwritten.
+	UpdateUser(ctx context.Context, user *models.User) error
+	// DeleteUser soft-deletes a user and reports whether one was deleted.
+	DeleteUser(ctx context.Context, id uint) (bool, error)
+	// RestoreUser undeletes a user and reports whether a deleted one was
+	// found.
+	RestoreUser(ctx context.Context, id uint) (bool, error)
+	// PurgeDeletedUsers permanently removes users deleted at or before
+	// cutoff, with their refresh tokens and points ledger, and returns how
+	// many were removed. A UserPurged event is recorded for each.
+	PurgeDeletedUsers(ctx context.Context, cutoff time.Time) (int64, error)
+	// PurgeUser permanently removes a deleted user like PurgeDeletedUsers.
+	// It returns 0 when no deleted user has the ID.
+	PurgeUser(ctx context.Context, id uint) (int64, error)
+
+	// RecordEvent writes a user event to the outbox. It should be called
+	// in the transaction that makes the change the event describes.
+	RecordEvent(ctx context.Context, eventType string, userID uint) error
+	// ListEvents returns up to limit events after the sequence number, in
+	// sequence order.
+	ListEvents(ctx context.Context, afterSequence uint64, limit int) ([]models.OutboxEvent, error)
+
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	// RevokeRefreshToken revokes the token if it is neither revoked nor
+	// expired at now, and reports whether it did. Only one of several
+	// concurrent calls succeeds.
+	RevokeRefreshToken(ctx context.Context, tokenHash string, now time.Time) (bool, error)
+
+	// AppendPoints adds entry to the ledger and applies its delta to the
+	// user's balance, filling in entry.BalanceAfter. Redemptions may not
+	// take the balance below zero; reversals may, when earned points have
+	// been spent. Deleted users keep earning and losing points so that
+	// their balance is right if they are restored. The balance and ledger
+	// are changed together or not at all.
+	AppendPoints(ctx context.Context, entry *models.PointsEntry) error
+	// OrderPointsEntry returns the user's ledger entry for the order with
+	// the given reason, or nil if there is none.
+	OrderPointsEntry(ctx context.Context, userID, orderID uint, reason string) (*models.PointsEntry, error)
+	ListOrderPointsEntries(ctx context.Context, userID, orderID uint) ([]models.PointsEntry, error)
+	ListPointsEntries(ctx context.Context, userID uint, page Page) ([]models.PointsEntry, error)
+	// LoyaltySettings returns the current loyalty rates, or
+	// DefaultLoyaltySettings if they have never been changed.
+	LoyaltySettings(ctx context.Context) (models.LoyaltySettings, error)
+	SaveLoyaltySettings(ctx context.Context, settings *models.LoyaltySettings) error
+}
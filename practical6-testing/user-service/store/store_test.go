@@ -0,0 +1,51 @@
+package store_test
+
+import (
+	"storedriver/storedrivertest"
+	"testing"
+	"user-service/store"
+	"user-service/store/storetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestStore also runs against the database in TEST_POSTGRES_DSN, whose
+// tables it drops.
+func TestStore(t *testing.T) {
+	storedrivertest.Run(t, store.Backends, storetest.Run,
+		"users", "refresh_tokens", "outbox_events", "event_sequences", "points_entries", "loyalty_settings")
+}
+
+func TestMigrate_LowercasesEmails(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(db))
+
+	// Rows written before emails were normalised
+	require.NoError(t, db.Exec(`INSERT INTO users (name, email, created_at) VALUES
+		('Ada', 'Ada@Example.com', CURRENT_TIMESTAMP),
+		('Bob', 'Bob@Example.com', CURRENT_TIMESTAMP),
+		('Other Bob', 'BOB@example.com', CURRENT_TIMESTAMP)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO users (name, email, created_at, deleted_at) VALUES
+		('Old Ada', 'ADA@example.com', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`).Error)
+	require.NoError(t, store.Migrate(db))
+
+	var emails []string
+	require.NoError(t, db.Table("users").Order("id").Pluck("email", &emails).Error)
+	assert.Equal(t, []string{"ada@example.com", "Bob@Example.com", "BOB@example.com", "ada@example.com"}, emails,
+		"clashing active users are left to be merged by hand")
+}
+
+func TestMigrate_DropsOrderOnlyPointsIndex(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(db))
+	require.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_points_entries_order_reason ON points_entries (reason, order_id)").Error)
+
+	require.NoError(t, store.Migrate(db))
+	assert.False(t, db.Migrator().HasIndex("points_entries", "idx_points_entries_order_reason"))
+	assert.True(t, db.Migrator().HasIndex("points_entries", "idx_points_entries_user_order_reason"))
+}
@@ -0,0 +1,399 @@
+// Package storetest is the conformance suite every store.UserStore
+// implementation must pass.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/models"
+	"user-service/store"
+
+	"dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Run runs the suite against stores returned by newStore, which must be
+// empty and independent of each other.
+func Run(t *testing.T, newStore func(t *testing.T) store.UserStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.UserStore)
+	}{
+		{"Ping", testPing},
+		{"Close", testClose},
+		{"CreateAndGet", testCreateAndGet},
+		{"UniqueEmail", testUniqueEmail},
+		{"ListUsers", testListUsers},
+		{"UpdateKeepsBalance", testUpdateKeepsBalance},
+		{"DeleteRestorePurge", testDeleteRestorePurge},
+		{"Events", testEvents},
+		{"RefreshTokens", testRefreshTokens},
+		{"Points", testPoints},
+		{"LoyaltySettings", testLoyaltySettings},
+		{"TransactionRollback", testTransactionRollback},
+		{"CancelledContext", testCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+func createUser(t *testing.T, s store.UserStore, name, email string, owner bool) models.User {
+	t.Helper()
+	user := models.User{Name: name, Email: email, IsCafeOwner: owner}
+	require.NoError(t, s.CreateUser(context.Background(), &user))
+	return user
+}
+
+func ids(users []models.User) []uint {
+	var ids []uint
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func testCreateAndGet(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+	assert.NotZero(t, user.ID)
+	assert.WithinDuration(t, time.Now(), user.CreatedAt, time.Minute)
+
+	got, err := s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", got.Name)
+	assert.Equal(t, "ada@example.com", got.Email)
+
+	byEmail, err := s.FindUserByEmail(ctx, "Ada@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byEmail.ID)
+
+	_, err = s.GetUser(ctx, user.ID+100)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.FindUserByEmail(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func testUniqueEmail(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	first := createUser(t, s, "Ada", "ada@example.com", false)
+
+	err := s.CreateUser(ctx, &models.User{Name: "Other Ada", Email: "ada@example.com"})
+	require.Error(t, err)
+	st := dberr.Status(err, "user")
+	assert.Equal(t, codes.AlreadyExists, status.Code(st))
+	assert.Equal(t, "email", dberr.ConflictingField(st))
+
+	// A deleted user's address can be registered again, after which the
+	// deleted user cannot be restored
+	deleted, err := s.DeleteUser(ctx, first.ID)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	createUser(t, s, "New Ada", "ada@example.com", false)
+
+	_, err = s.RestoreUser(ctx, first.ID)
+	assert.Equal(t, codes.AlreadyExists, status.Code(dberr.Status(err, "user")))
+}
+
+func testListUsers(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	owner1 := createUser(t, s, "Owner 1", "owner1@cafe.com", true)
+	student1 := createUser(t, s, "Student 1", "student1@uni.edu", false)
+	owner2 := createUser(t, s, "Owner 2", "owner2@cafe.com", true)
+	student2 := createUser(t, s, "Student 2", "Student_2@uni.edu", false)
+	deleted := createUser(t, s, "Gone", "gone@uni.edu", false)
+	_, err := s.DeleteUser(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	all, err := s.ListUsers(ctx, store.UserFilter{}, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{owner1.ID, student1.ID, owner2.ID, student2.ID}, ids(all))
+
+	page, err := s.ListUsers(ctx, store.UserFilter{}, store.Page{AfterID: student1.ID, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{owner2.ID}, ids(page))
+
+	owner := true
+	owners, err := s.ListUsers(ctx, store.UserFilter{IsCafeOwner: &owner}, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{owner1.ID, owner2.ID}, ids(owners))
+
+	// Wildcards are matched literally and case is ignored
+	prefixed, err := s.ListUsers(ctx, store.UserFilter{EmailPrefix: "student_"}, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{student2.ID}, ids(prefixed))
+
+	deletedUsers, err := s.ListDeletedUsers(ctx, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{deleted.ID}, ids(deletedUsers))
+}
+
+func testUpdateKeepsBalance(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+
+	// The stale copy read before the credit must not overwrite it
+	stale := user
+	require.NoError(t, s.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: 10, Reason: models.PointsEarned}))
+	stale.Name = "Ada Lovelace"
+	require.NoError(t, s.UpdateUser(ctx, &stale))
+
+	got, err := s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", got.Name)
+	assert.Equal(t, int64(10), got.PointsBalance)
+}
+
+func testDeleteRestorePurge(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+	require.NoError(t, s.CreateRefreshToken(ctx, &models.RefreshToken{UserID: user.ID, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	deleted, err := s.DeleteUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = s.DeleteUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "a deleted user is not deleted twice")
+
+	_, err = s.GetUser(ctx, user.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	got, err := s.GetUserIncludingDeleted(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, got.DeletedAt.Valid)
+
+	restored, err := s.RestoreUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	restored, err = s.RestoreUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, restored, "a user that is not deleted is not restored")
+
+	purged, err := s.PurgeUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, purged, "only deleted users are purged")
+
+	_, err = s.DeleteUser(ctx, user.ID)
+	require.NoError(t, err)
+	kept := createUser(t, s, "Kept", "kept@example.com", false)
+	_, err = s.DeleteUser(ctx, kept.ID)
+	require.NoError(t, err)
+
+	purged, err = s.PurgeDeletedUsers(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged, "users deleted after the cutoff are kept")
+
+	purged, err = s.PurgeUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	_, err = s.GetUserIncludingDeleted(ctx, user.ID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = s.FindRefreshToken(ctx, "hash")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	purged, err = s.PurgeDeletedUsers(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	events, err := s.ListEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	var purgedIDs []uint
+	for _, event := range events {
+		assert.Equal(t, models.EventUserPurged, event.Type)
+		purgedIDs = append(purgedIDs, event.UserID)
+	}
+	assert.Equal(t, []uint{user.ID, kept.ID}, purgedIDs)
+}
+
+func testEvents(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	for id := uint(1); id <= 3; id++ {
+		require.NoError(t, s.RecordEvent(ctx, models.EventUserDeleted, id))
+	}
+
+	events, err := s.ListEvents(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, events[0].Sequence+1, events[1].Sequence)
+	assert.Equal(t, uint(1), events[0].UserID)
+	assert.WithinDuration(t, time.Now(), events[0].CreatedAt, time.Minute)
+
+	rest, err := s.ListEvents(ctx, events[1].Sequence, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, uint(3), rest[0].UserID)
+
+	// A rolled back event leaves no gap in the sequence
+	errRollback := errors.New("rollback")
+	err = s.Transaction(ctx, func(tx store.UserStore) error {
+		require.NoError(t, tx.RecordEvent(ctx, models.EventUserDeleted, 4))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	require.NoError(t, s.RecordEvent(ctx, models.EventUserDeleted, 5))
+
+	rest, err = s.ListEvents(ctx, rest[0].Sequence, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, uint(5), rest[0].UserID)
+	assert.Equal(t, events[1].Sequence+2, rest[0].Sequence)
+}
+
+func testRefreshTokens(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	now := time.Now()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+	require.NoError(t, s.CreateRefreshToken(ctx, &models.RefreshToken{UserID: user.ID, TokenHash: "live", ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, s.CreateRefreshToken(ctx, &models.RefreshToken{UserID: user.ID, TokenHash: "expired", ExpiresAt: now.Add(-time.Hour)}))
+
+	token, err := s.FindRefreshToken(ctx, "live")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, token.UserID)
+
+	revoked, err := s.RevokeRefreshToken(ctx, "live", now)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = s.RevokeRefreshToken(ctx, "live", now)
+	require.NoError(t, err)
+	assert.False(t, revoked, "a token is revoked only once")
+
+	revoked, err = s.RevokeRefreshToken(ctx, "expired", now)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	revoked, err = s.RevokeRefreshToken(ctx, "unknown", now)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func testPoints(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+	orderID := uint(7)
+
+	earned := models.PointsEntry{UserID: user.ID, Delta: 30, Reason: models.PointsEarned, OrderID: &orderID}
+	require.NoError(t, s.AppendPoints(ctx, &earned))
+	assert.Equal(t, int64(30), earned.BalanceAfter)
+
+	err := s.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: -31, Reason: models.PointsRedeemed})
+	assert.ErrorIs(t, err, store.ErrInsufficientPoints)
+	err = s.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID + 100, Delta: 1, Reason: models.PointsEarned})
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	// A user's order has at most one entry for each reason
+	err = s.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: 30, Reason: models.PointsEarned, OrderID: &orderID})
+	assert.Equal(t, codes.AlreadyExists, status.Code(dberr.Status(err, "points entry")))
+	// Another user's entries for the same order ID are their own
+	other := createUser(t, s, "Bob", "bob@example.com", false)
+	require.NoError(t, s.AppendPoints(ctx, &models.PointsEntry{UserID: other.ID, Delta: 5, Reason: models.PointsEarned, OrderID: &orderID}))
+
+	redeemed := models.PointsEntry{UserID: user.ID, Delta: -20, Reason: models.PointsRedeemed, OrderID: &orderID}
+	require.NoError(t, s.AppendPoints(ctx, &redeemed))
+	// Reversals may go below zero once earned points have been spent
+	reversed := models.PointsEntry{UserID: user.ID, Delta: -30, Reason: models.PointsReversed}
+	require.NoError(t, s.AppendPoints(ctx, &reversed))
+	assert.Equal(t, int64(-20), reversed.BalanceAfter)
+
+	got, err := s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-20), got.PointsBalance)
+
+	entry, err := s.OrderPointsEntry(ctx, user.ID, orderID, models.PointsRedeemed)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, redeemed.ID, entry.ID)
+	entry, err = s.OrderPointsEntry(ctx, user.ID, orderID, models.PointsReversed)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	forOrder, err := s.ListOrderPointsEntries(ctx, user.ID, orderID)
+	require.NoError(t, err)
+	assert.Len(t, forOrder, 2)
+	forOrder, err = s.ListOrderPointsEntries(ctx, other.ID, orderID)
+	require.NoError(t, err)
+	assert.Len(t, forOrder, 1)
+
+	ledger, err := s.ListPointsEntries(ctx, user.ID, store.Page{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, ledger, 2)
+	assert.Equal(t, earned.ID, ledger[0].ID)
+	rest, err := s.ListPointsEntries(ctx, user.ID, store.Page{AfterID: ledger[1].ID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, reversed.ID, rest[0].ID)
+
+	// Deleted users keep their ledger
+	_, err = s.DeleteUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, s.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: 5, Reason: models.PointsEarned}))
+}
+
+func testLoyaltySettings(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	settings, err := s.LoyaltySettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, store.DefaultLoyaltySettings.PointsPerCurrencyUnit, settings.PointsPerCurrencyUnit)
+	assert.Equal(t, store.DefaultLoyaltySettings.PointValueCents, settings.PointValueCents)
+
+	require.NoError(t, s.SaveLoyaltySettings(ctx, &models.LoyaltySettings{PointsPerCurrencyUnit: 5, PointValueCents: 2}))
+	require.NoError(t, s.SaveLoyaltySettings(ctx, &models.LoyaltySettings{PointsPerCurrencyUnit: 3, PointValueCents: 4}))
+	settings, err = s.LoyaltySettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), settings.PointsPerCurrencyUnit)
+	assert.Equal(t, int64(4), settings.PointValueCents)
+}
+
+func testTransactionRollback(t *testing.T, s store.UserStore) {
+	ctx := context.Background()
+	user := createUser(t, s, "Ada", "ada@example.com", false)
+	errAbort := errors.New("abort")
+
+	err := s.Transaction(ctx, func(tx store.UserStore) error {
+		if err := tx.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: 10, Reason: models.PointsEarned}); err != nil {
+			return err
+		}
+		if err := tx.RecordEvent(ctx, models.EventUserDeleted, user.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	got, err := s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, got.PointsBalance)
+	events, err := s.ListEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	err = s.Transaction(ctx, func(tx store.UserStore) error {
+		return tx.AppendPoints(ctx, &models.PointsEntry{UserID: user.ID, Delta: 10, Reason: models.PointsEarned})
+	})
+	require.NoError(t, err)
+	got, err = s.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), got.PointsBalance)
+}
+
+func testCancelledContext(t *testing.T, s store.UserStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.GetUser(ctx, 1)
+	assert.Equal(t, codes.Canceled, status.Code(dberr.Status(err, "user")))
+}
+
+func testPing(t *testing.T, s store.UserStore) {
+	require.NoError(t, s.Ping(context.Background()))
+}
+
+func testClose(t *testing.T, s store.UserStore) {
+	require.NoError(t, s.Close())
+}
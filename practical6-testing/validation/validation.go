@@ -0,0 +1,152 @@
+// Package validation enforces the buf.validate rules declared on the proto
+// request messages and reports broken rules as google.rpc.BadRequest details.
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// updateMaskField names the google.protobuf.FieldMask field of partial
+// update requests.
+const updateMaskField = "update_mask"
+
+// Check validates msg against the rules declared in its proto definition.
+// It returns nil for valid messages and for values that are not proto
+// messages, and an InvalidArgument status listing every violating field
+// otherwise.
+//
+// When msg has a non-empty update_mask, fields the mask leaves out are not
+// validated because the update does not touch them. Fields marked required,
+// such as the ID of the record being updated, are always validated.
+func Check(msg any) error {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	var opts []protovalidate.ValidationOption
+	if filter := updateMaskFilter(m.ProtoReflect()); filter != nil {
+		opts = append(opts, protovalidate.WithFilter(filter))
+	}
+	err := protovalidate.Validate(m, opts...)
+	if err == nil {
+		return nil
+	}
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return status.Errorf(codes.Internal, "failed to validate %s: %v", m.ProtoReflect().Descriptor().Name(), err)
+	}
+	return invalidArgument(m, verr)
+}
+
+// updateMaskFilter returns a filter that skips the fields of root left out of
+// its update_mask, or nil when root has no such mask or the mask is empty.
+func updateMaskFilter(root protoreflect.Message) protovalidate.Filter {
+	maskField := root.Descriptor().Fields().ByName(updateMaskField)
+	if maskField == nil || maskField.Message() == nil || maskField.Message().FullName() != "google.protobuf.FieldMask" || !root.Has(maskField) {
+		return nil
+	}
+	mask := root.Get(maskField).Message()
+	paths := mask.Get(mask.Descriptor().Fields().ByName("paths")).List()
+	if paths.Len() == 0 {
+		return nil
+	}
+
+	listed := make(map[protoreflect.Name]bool, paths.Len())
+	for i := 0; i < paths.Len(); i++ {
+		name, _, _ := strings.Cut(paths.Get(i).String(), ".")
+		listed[protoreflect.Name(name)] = true
+	}
+
+	return protovalidate.FilterFunc(func(msg protoreflect.Message, desc protoreflect.Descriptor) bool {
+		field, ok := desc.(protoreflect.FieldDescriptor)
+		if !ok || msg.Descriptor() != root.Descriptor() || field == maskField || listed[field.Name()] {
+			return true
+		}
+		rules, _ := proto.GetExtension(field.Options(), validate.E_Field).(*validate.FieldRules)
+		return rules.GetRequired()
+	})
+}
+
+// invalidArgument builds an InvalidArgument status whose message summarizes
+// the violations and whose BadRequest detail lists them field by field.
+func invalidArgument(m proto.Message, verr *protovalidate.ValidationError) error {
+	details := &errdetails.BadRequest{}
+	summary := make([]string, 0, len(verr.Violations))
+	for _, v := range verr.Violations {
+		field := protovalidate.FieldPathString(v.Proto.GetField())
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Proto.GetMessage(),
+			Reason:      v.Proto.GetRuleId(),
+		})
+		summary = append(summary, v.String())
+	}
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid %s: %s",
+		m.ProtoReflect().Descriptor().Name(), strings.Join(summary, "; ")))
+	withDetails, err := st.WithDetails(details)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// FieldViolations returns the BadRequest field violations carried by err,
+// or nil when it has none.
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			violations = append(violations, br.GetFieldViolations()...)
+		}
+	}
+	return violations
+}
+
+// UnaryServerInterceptor rejects requests that break their message's rules
+// before they reach the handler.
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := Check(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor validates every message the client sends on a
+// stream.
+func StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatedStream{ServerStream: stream})
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return Check(m)
+}
+
+// UnaryClientInterceptor validates outgoing requests so that calls which
+// the server would reject fail without a round trip.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := Check(req); err != nil {
+		return err
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
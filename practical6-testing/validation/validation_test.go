@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newSignupRequest builds a message equivalent to
+//
+//	message SignupRequest {
+//	  string name = 1 [(buf.validate.field).string.min_len = 1];
+//	  uint32 age = 2 [(buf.validate.field).uint32.gt = 0];
+//	}
+func newSignupRequest(t *testing.T, name string, age uint32) *dynamicpb.Message {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, rules *validate.FieldRules) *descriptorpb.FieldDescriptorProto {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, validate.E_Field, rules)
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			Options:  opts,
+		}
+	}
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("validation_test.proto"),
+		Package:    proto.String("validation.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"buf/validate/validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("SignupRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, validate.FieldRules_builder{
+					String: validate.StringRules_builder{MinLen: proto.Uint64(1)}.Build(),
+				}.Build()),
+				field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT32, validate.FieldRules_builder{
+					Uint32: validate.UInt32Rules_builder{Gt: proto.Uint32(0)}.Build(),
+				}.Build()),
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(file.Messages().Get(0))
+	msg.Set(msg.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString(name))
+	msg.Set(msg.Descriptor().Fields().ByName("age"), protoreflect.ValueOfUint32(age))
+	return msg
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("valid message", func(t *testing.T) {
+		assert.NoError(t, Check(newSignupRequest(t, "Ada", 36)))
+	})
+
+	t.Run("not a proto message", func(t *testing.T) {
+		assert.NoError(t, Check("plain string"))
+	})
+
+	t.Run("every violation is reported", func(t *testing.T) {
+		err := Check(newSignupRequest(t, "", 0))
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "SignupRequest")
+
+		violations := FieldViolations(err)
+		require.Len(t, violations, 2)
+		assert.Equal(t, "name", violations[0].Field)
+		assert.Equal(t, "string.min_len", violations[0].Reason)
+		assert.NotEmpty(t, violations[0].Description)
+		assert.Equal(t, "age", violations[1].Field)
+		assert.Equal(t, "uint32.gt", violations[1].Reason)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/validation.test.Service/Signup"}
+
+	_, err := UnaryServerInterceptor(context.Background(), newSignupRequest(t, "", 1), info, handler)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called, "handler must not run for invalid requests")
+
+	resp, err := UnaryServerInterceptor(context.Background(), newSignupRequest(t, "Ada", 1), info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.True(t, called)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	invoked := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked = true
+		return nil
+	}
+
+	err := UnaryClientInterceptor(context.Background(), "/validation.test.Service/Signup", newSignupRequest(t, "Ada", 0), nil, nil, invoker)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, invoked, "invalid requests must not be sent")
+}